@@ -10,6 +10,8 @@ function CreateTour() {
   const [description, setDescription] = useState('');
   const [difficulty, setDifficulty] = useState('easy');
   const [tags, setTags] = useState('');
+  const [executionMode, setExecutionMode] = useState('free_roam');
+  const [proximityRadius, setProximityRadius] = useState('');
   const [loading, setLoading] = useState(false);
   const [error, setError] = useState('');
   
@@ -29,7 +31,9 @@ function CreateTour() {
         name,
         description,
         difficulty,
-        tags: tagArray
+        tags: tagArray,
+        executionMode,
+        proximityRadius: proximityRadius ? parseFloat(proximityRadius) : 0
       });
 
       if (response.data.success) {
@@ -94,6 +98,32 @@ function CreateTour() {
             </select>
           </div>
 
+          <div className="form-group">
+            <label>Key Point Order</label>
+            <select
+              value={executionMode}
+              onChange={(e) => setExecutionMode(e.target.value)}
+              disabled={loading}
+            >
+              <option value="free_roam">Free roam - visit key points in any order</option>
+              <option value="sequential">Sequential - visit key points in tour order</option>
+            </select>
+          </div>
+
+          <div className="form-group">
+            <label>Proximity Radius (meters)</label>
+            <input
+              type="number"
+              value={proximityRadius}
+              onChange={(e) => setProximityRadius(e.target.value)}
+              min="5"
+              max="2000"
+              placeholder="50"
+              disabled={loading}
+            />
+            <small>How close tourists must get to a key point to complete it</small>
+          </div>
+
           <div className="form-group">
             <label>Tags (comma-separated)</label>
             <input
@@ -39,6 +39,13 @@ function KeyPoints() {
   const [longitude, setLongitude] = useState('');
   const [image, setImage] = useState('');
   const [order, setOrder] = useState(1);
+  const [proximityRadius, setProximityRadius] = useState('');
+
+  // Import state
+  const [importFile, setImportFile] = useState(null);
+  const [importMode, setImportMode] = useState('append');
+  const [importing, setImporting] = useState(false);
+  const [importReport, setImportReport] = useState(null);
   
   // Map state
   const [selectedPosition, setSelectedPosition] = useState(null);
@@ -93,7 +100,8 @@ function KeyPoints() {
         name,
         description,
         image,
-        order
+        order,
+        proximityRadius: proximityRadius ? parseFloat(proximityRadius) : 0
       });
 
       if (response.data.success) {
@@ -104,6 +112,7 @@ function KeyPoints() {
         setLatitude('');
         setLongitude('');
         setImage('');
+        setProximityRadius('');
         setSelectedPosition(null);
         // Refresh keypoints
         fetchKeyPoints();
@@ -118,6 +127,39 @@ function KeyPoints() {
     }
   };
 
+  const importFormat = (fileName) => {
+    const extension = fileName.split('.').pop().toLowerCase();
+    return extension === 'json' ? 'geojson' : extension;
+  };
+
+  const handleImport = async (e) => {
+    e.preventDefault();
+    if (importMode === 'replace' && !window.confirm('Replace all existing key points with the imported ones?')) {
+      return;
+    }
+    setImporting(true);
+    setImportReport(null);
+
+    try {
+      const response = await tourAPI.importKeyPoints(tourId, {
+        guideId: user.userId,
+        format: importFormat(importFile.name),
+        data: await importFile.text(),
+        mode: importMode
+      });
+
+      setImportReport(response.data);
+      if (response.data.success) {
+        fetchKeyPoints();
+      }
+    } catch (err) {
+      alert('Failed to import key points');
+      console.error(err);
+    } finally {
+      setImporting(false);
+    }
+  };
+
   if (loading) return <Layout><div className="loading">Loading...</div></Layout>;
 
   return (
@@ -241,11 +283,67 @@ function KeyPoints() {
                 />
               </div>
 
+              <div className="form-group">
+                <label>Proximity Radius (meters)</label>
+                <input
+                  type="number"
+                  value={proximityRadius}
+                  onChange={(e) => setProximityRadius(e.target.value)}
+                  min="5"
+                  max="2000"
+                  placeholder="Tour default"
+                  disabled={saving}
+                />
+              </div>
+
               <button type="submit" className="btn-submit" disabled={saving || !latitude || !longitude}>
                 {saving ? 'Adding...' : 'Add Key Point'}
               </button>
             </form>
 
+            {/* Import Section */}
+            <div className="import-section">
+              <h2>Import Key Points</h2>
+              <form onSubmit={handleImport}>
+                <div className="form-group">
+                  <label>GPX, KML or GeoJSON file</label>
+                  <input
+                    type="file"
+                    accept=".gpx,.kml,.geojson,.json"
+                    onChange={(e) => setImportFile(e.target.files[0] || null)}
+                    disabled={importing}
+                  />
+                </div>
+
+                <div className="form-group">
+                  <label>Mode</label>
+                  <select
+                    value={importMode}
+                    onChange={(e) => setImportMode(e.target.value)}
+                    disabled={importing}
+                  >
+                    <option value="append">Add after existing key points</option>
+                    <option value="replace">Replace existing key points</option>
+                  </select>
+                </div>
+
+                <button type="submit" className="btn-submit" disabled={importing || !importFile}>
+                  {importing ? 'Importing...' : 'Import'}
+                </button>
+              </form>
+
+              {importReport && (
+                <div className="import-report">
+                  <p>{importReport.message}</p>
+                  {importReport.features.filter(f => !f.imported).map(f => (
+                    <div key={f.index} className="import-rejected">
+                      <strong>#{f.index + 1} {f.name}</strong>: {f.reason}
+                    </div>
+                  ))}
+                </div>
+              )}
+            </div>
+
             {/* Existing Keypoints List */}
             <div className="keypoints-list">
               <h3>Existing Key Points ({keypoints.length})</h3>
@@ -258,7 +356,7 @@ function KeyPoints() {
                     <div className="kp-details">
                       <strong>{kp.name}</strong>
                       <p>{kp.description}</p>
-                      <small>{kp.latitude.toFixed(4)}, {kp.longitude.toFixed(4)}</small>
+                      <small>{kp.latitude.toFixed(4)}, {kp.longitude.toFixed(4)} · {kp.effectiveProximityRadius} m radius</small>
                     </div>
                   </div>
                 ))
@@ -9,6 +9,9 @@ function MyExecutions() {
   const [purchasedTours, setPurchasedTours] = useState([]);
   const [activeExecutions, setActiveExecutions] = useState({}); // Add this
   const [loading, setLoading] = useState(true);
+  const [history, setHistory] = useState([]);
+  const [historyStatus, setHistoryStatus] = useState('');
+  const [historyToken, setHistoryToken] = useState('');
   const { user } = useAuth();
   const navigate = useNavigate();
 
@@ -40,6 +43,23 @@ function MyExecutions() {
     fetchPurchasedTours();
   }, [fetchPurchasedTours]);
 
+  const fetchHistory = useCallback(async (status, pageToken = '') => {
+    try {
+      const response = await tourAPI.listExecutions(user.userId, status, pageToken);
+      if (response.data.success) {
+        const entries = response.data.executions;
+        setHistory((previous) => (pageToken ? [...previous, ...entries] : entries));
+        setHistoryToken(response.data.nextPageToken);
+      }
+    } catch (err) {
+      console.error('Failed to load tour history:', err);
+    }
+  }, [user.userId]);
+
+  useEffect(() => {
+    fetchHistory(historyStatus);
+  }, [fetchHistory, historyStatus]);
+
   const handleStartTour = async (tour) => {
     // First, get current position
     try {
@@ -75,6 +95,24 @@ function MyExecutions() {
     }
   };
 
+  const handleExport = async (tour, format) => {
+    try {
+      const response = await tourAPI.exportTour(tour.id, format, user.userId);
+      const disposition = response.headers['content-disposition'] || '';
+      const match = disposition.match(/filename="?([^";]+)"?/);
+
+      const url = URL.createObjectURL(response.data);
+      const link = document.createElement('a');
+      link.href = url;
+      link.download = match ? match[1] : `tour.${format}`;
+      link.click();
+      URL.revokeObjectURL(url);
+    } catch (err) {
+      console.error('Failed to export tour:', err);
+      alert('Failed to export tour');
+    }
+  };
+
   if (loading) return <Layout><div className="loading">Loading your tours...</div></Layout>;
 
   return (
@@ -115,6 +153,19 @@ function MyExecutions() {
                   ))}
                 </div>
                 
+                <div className="tour-export">
+                  <span>Download for navigation apps:</span>
+                  {['gpx', 'kml', 'geojson'].map((format) => (
+                    <button
+                      key={format}
+                      onClick={() => handleExport(tour, format)}
+                      className="btn-export"
+                    >
+                      {format.toUpperCase()}
+                    </button>
+                  ))}
+                </div>
+
                 <div className="tour-footer">
                   <span className="purchased-badge">✓ Purchased</span>
                   <button 
@@ -128,6 +179,50 @@ function MyExecutions() {
             ))}
           </div>
         )}
+
+        <div className="page-header history-header">
+          <h2>Tour History</h2>
+          <select
+            value={historyStatus}
+            onChange={(e) => setHistoryStatus(e.target.value)}
+            className="history-filter"
+          >
+            <option value="">All</option>
+            <option value="active">Active</option>
+            <option value="completed">Completed</option>
+            <option value="abandoned">Abandoned</option>
+          </select>
+        </div>
+
+        {history.length === 0 ? (
+          <p className="history-empty">No tours here yet.</p>
+        ) : (
+          <div className="history-list">
+            {history.map(({ execution, tourName, completedKeyPoints, totalKeyPoints }) => (
+              <div key={execution.id} className="history-item">
+                <div>
+                  <h4>{tourName || 'Deleted tour'}</h4>
+                  <span className="history-date">
+                    Started {new Date(execution.startedAt).toLocaleString()}
+                  </span>
+                </div>
+                <span className="history-progress">
+                  {completedKeyPoints}/{totalKeyPoints} key points
+                </span>
+                <span className={`status-badge ${execution.status}`}>{execution.status}</span>
+              </div>
+            ))}
+          </div>
+        )}
+
+        {historyToken && (
+          <button
+            onClick={() => fetchHistory(historyStatus, historyToken)}
+            className="btn-export"
+          >
+            Load more
+          </button>
+        )}
       </div>
     </Layout>
   );
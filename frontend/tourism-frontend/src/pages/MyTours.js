@@ -41,6 +41,11 @@ useEffect(() => {
       if (response.data.success) {
         alert('Tour published!');
         fetchMyTours();
+      } else {
+        const missing = (response.data.violations || [])
+          .map((v) => `- ${v.description}`)
+          .join('\n');
+        alert(missing ? `${response.data.message}:\n${missing}` : response.data.message);
       }
     } catch (err) {
       alert('Failed to publish tour');
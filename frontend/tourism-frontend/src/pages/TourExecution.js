@@ -1,7 +1,7 @@
 import React, { useState, useEffect, useCallback, useRef } from 'react';
 import { useParams, useNavigate, useLocation } from 'react-router-dom';
 import Layout from '../components/Layout';
-import { MapContainer, TileLayer, Marker, Polyline } from 'react-leaflet';
+import { MapContainer, TileLayer, Marker, Circle, Polyline, useMapEvents } from 'react-leaflet';
 import { tourAPI } from '../services/api';
 import { useAuth } from '../context/AuthContext';
 import L from 'leaflet';
@@ -36,6 +36,23 @@ const pendingIcon = new L.Icon({
   shadowSize: [41, 41]
 });
 
+// Moves the tourist to wherever the map is clicked
+function PositionPicker({ onPick }) {
+  useMapEvents({
+    click: (e) => onPick({ lat: e.latlng.lat, lng: e.latlng.lng }),
+  });
+  return null;
+}
+
+function describeSummary(summary) {
+  if (!summary) {
+    return '';
+  }
+  const km = (summary.distance / 1000).toFixed(2);
+  const minutes = Math.round(summary.durationSeconds / 60);
+  return `${summary.completedKeyPoints}/${summary.totalKeyPoints} key points (${Math.round(summary.completionPercentage)}%) · ${km} km · ${minutes} min`;
+}
+
 function TourExecution() {
   const [execution, setExecution] = useState(null);
   const [tour, setTour] = useState(null);
@@ -43,13 +60,14 @@ function TourExecution() {
   const [currentPosition, setCurrentPosition] = useState(null);
   const [nearbyKeypoint, setNearbyKeypoint] = useState(null);
   const [loading, setLoading] = useState(true);
-  const [checking, setChecking] = useState(false);
+  const [tracking, setTracking] = useState(false);
+  const [walkedPath, setWalkedPath] = useState([]);
   
   const { executionId } = useParams();
   const { user } = useAuth();
   const navigate = useNavigate();
   const location = useLocation();
-  const intervalRef = useRef(null);
+  const socketRef = useRef(null);
 
   const fetchExecutionData = useCallback(async () => {
     try {
@@ -64,6 +82,12 @@ function TourExecution() {
           setKeypoints(kpResponse.data.keyPoints);
         }
         
+        // Get the path walked so far
+        const trackResponse = await tourAPI.getExecutionTrack(location.state.execution.id, user.userId);
+        if (trackResponse.data.success) {
+          setWalkedPath(trackResponse.data.points.map(p => [p.latitude, p.longitude]));
+        }
+
         // Get current position
         const posResponse = await tourAPI.getPosition(user.userId);
         if (posResponse.data.success && posResponse.data.position) {
@@ -88,113 +112,88 @@ function TourExecution() {
     }
   }, [executionId, user.userId, navigate, location.state]);
 
-  const checkProximity = useCallback(async () => {
-  if (!currentPosition || !execution || execution.status !== 'active') {
-    console.log('Skipping proximity check:', {
-      hasPosition: !!currentPosition,
-      hasExecution: !!execution,
-      status: execution?.status
-    });
-    return;
-  }
+  useEffect(() => {
+    fetchExecutionData();
+  }, [fetchExecutionData]);
 
-  console.log('Checking proximity...', {
-    currentPosition,
-    executionId,
-    touristId: user.userId
-  });
+  const isActive = execution?.status === 'active';
 
-  setChecking(true);
-    try {
-      const response = await tourAPI.checkProximity(executionId, {
-        touristId: user.userId,
-        latitude: currentPosition.lat,      // ✅ Correct - matches DTO
-        longitude: currentPosition.lng      // ✅ Correct - matches DTO
-      });
-
-      console.log('Proximity check response:', response.data);
-
-      console.log('Proximity check response:', response.data);
-      console.log('nearKeyPoint value:', response.data.nearKeyPoint);
-      console.log('nearbyKeyPoint value:', response.data.nearbyKeyPoint);
-      console.log('Type check - success:', typeof response.data.success, response.data.success);
-      console.log('Type check - nearKeyPoint:', typeof response.data.nearKeyPoint, response.data.nearKeyPoint);
-      if (response.data.success && response.data.nearKeyPoint) {  // Capital K!
-        console.log('=== NEAR KEYPOINT DETECTED ===');
-        console.log('Nearby keypoint:', response.data.nearbyKeyPoint);  // Capital K!
-        console.log('Current execution completedKeypoints:', execution.completedKeypoints);
-        
-        setNearbyKeypoint(response.data.nearbyKeyPoint);  // Capital K!
-        
-        // Update execution state immediately
+  // Keypoints are reported by the tracking stream as positions are sent
+  useEffect(() => {
+    if (!isActive) {
+      return;
+    }
+
+    const socket = tourAPI.trackExecution(executionId, user.userId);
+    socketRef.current = socket;
+
+    socket.onopen = () => setTracking(true);
+    socket.onclose = () => setTracking(false);
+    socket.onerror = (err) => console.error('Tracking error:', err);
+
+    socket.onmessage = (message) => {
+      const event = JSON.parse(message.data);
+
+      if (event.type === 'keyPointReached') {
+        setNearbyKeypoint(event.keyPoint);
         setExecution(prev => {
-          console.log('Previous execution state:', prev);
-          
-          // Check if already completed
-          const alreadyCompleted = prev.completedKeypoints.some(
-            kp => kp.keypointId === response.data.nearbyKeyPoint.id  // Capital K!
-          );
-          
-          console.log('Already completed?', alreadyCompleted);
-          
-          if (alreadyCompleted) {
-            console.log('Skipping - already marked as complete');
+          if (prev.completedKeypoints.some(kp => kp.keypointId === event.keyPoint.id)) {
             return prev;
           }
-          
-          // Add new completed keypoint
-          const updated = {
+          return {
             ...prev,
             completedKeypoints: [
               ...prev.completedKeypoints,
-              {
-                keypointId: response.data.nearbyKeyPoint.id,  // Capital K!
-                completedAt: new Date().toISOString()
-              }
+              { keypointId: event.keyPoint.id, completedAt: new Date().toISOString() }
             ]
           };
-          
-          console.log('Updated execution state:', updated);
-          return updated;
         });
+      } else if (event.type === 'tourCompleted') {
+        setExecution(event.execution);
+        alert(`🎉 Tour completed!\n${describeSummary(event.summary)}`);
+        navigate('/my-executions');
+      } else if (event.type === 'executionExpired') {
+        setExecution(event.execution);
+        alert(`This tour is no longer active: ${event.reason}`);
+        navigate('/my-executions');
       }
-    } catch (err) {
-      console.error('Error checking proximity:', err);
-      console.error('Error response:', err.response?.data);
-    } finally {
-      setChecking(false);
-    }
-  }, [currentPosition, execution, executionId, user.userId, fetchExecutionData]);
+    };
 
-  useEffect(() => {
-    fetchExecutionData();
-  }, [fetchExecutionData]);
+    return () => {
+      socketRef.current = null;
+      socket.close();
+    };
+  }, [isActive, executionId, user.userId, navigate]);
 
-  // Set up proximity checking interval
+  // Send every position change, and the current one once tracking starts
   useEffect(() => {
-    if (execution && execution.status === 'active') {
-      // Check immediately
-      checkProximity();
-
-      // Then check every 10 seconds
-      intervalRef.current = setInterval(() => {
-        checkProximity();
-      }, 10000);
-
-      return () => {
-        if (intervalRef.current) {
-          clearInterval(intervalRef.current);
-        }
-      };
+    const socket = socketRef.current;
+    if (tracking && currentPosition && socket?.readyState === WebSocket.OPEN) {
+      socket.send(JSON.stringify({
+        latitude: currentPosition.lat,
+        longitude: currentPosition.lng
+      }));
+
+      // The service records the same sample, so extend the path locally
+      const point = [currentPosition.lat, currentPosition.lng];
+      setWalkedPath(prev => [...prev, point]);
     }
-  }, [execution, checkProximity]);
+  }, [tracking, currentPosition]);
 
+  // Reaching the last key point completes the tour on its own, so this only
+  // finishes it early
   const handleCompleteTour = async () => {
+    if (!window.confirm('Finish the tour without visiting every key point?')) {
+      return;
+    }
+
     try {
-      const response = await tourAPI.completeTour(executionId, user.userId);
+      const response = await tourAPI.completeTour(executionId, user.userId, true);
       if (response.data.success) {
-        alert('🎉 Tour completed successfully!');
+        alert(`Tour finished\n${describeSummary(response.data.summary)}`);
         navigate('/my-executions');
+      } else {
+        alert(response.data.message);
       }
     } catch (err) {
       alert('Failed to complete tour');
@@ -230,6 +229,20 @@ function TourExecution() {
   const completedCount = completedKeypointIds.length;
   const totalCount = keypoints.length;
   const progress = totalCount > 0 ? (completedCount / totalCount) * 100 : 0;
+  const sequential = execution.mode === 'sequential';
+
+  // The keypoint to head for: the next in order, or the nearest one left
+  const pendingKeypoints = keypoints.filter(kp => !completedKeypointIds.includes(kp.id));
+  const candidates = sequential ? pendingKeypoints.slice(0, 1) : pendingKeypoints;
+  const distanceTo = (kp) => L.latLng(currentPosition.lat, currentPosition.lng).distanceTo([kp.latitude, kp.longitude]);
+  const nextKeypoint = currentPosition && candidates.length > 0
+    ? candidates.reduce((best, kp) => (distanceTo(kp) < distanceTo(best) ? kp : best))
+    : null;
+
+  const walkedDistance = walkedPath.reduce(
+    (sum, point, i) => (i === 0 ? 0 : sum + L.latLng(walkedPath[i - 1]).distanceTo(point)),
+    0
+  );
 
   return (
     <Layout>
@@ -249,7 +262,7 @@ function TourExecution() {
           <div className="map-section">
             <div className="map-header">
               <h3>Tour Map</h3>
-              {checking && <span className="checking-indicator">🔄 Checking proximity...</span>}
+              {tracking && <span className="checking-indicator">📡 Tracking position</span>}
             </div>
             
             <div className="map-wrapper">
@@ -263,6 +276,8 @@ function TourExecution() {
                   url="https://{s}.tile.openstreetmap.org/{z}/{x}/{y}.png"
                 />
                 
+                {isActive && <PositionPicker onPick={setCurrentPosition} />}
+
                 {/* Current position marker */}
                 {currentPosition && (
                   <Marker 
@@ -275,14 +290,30 @@ function TourExecution() {
                 {keypoints.map((kp) => {
                   const isCompleted = completedKeypointIds.includes(kp.id);
                   return (
-                    <Marker
-                      key={kp.id}
-                      position={[kp.latitude, kp.longitude]}
-                      icon={isCompleted ? completedIcon : pendingIcon}
-                    />
+                    <React.Fragment key={kp.id}>
+                      <Marker
+                        position={[kp.latitude, kp.longitude]}
+                        icon={isCompleted ? completedIcon : pendingIcon}
+                      />
+                      <Circle
+                        center={[kp.latitude, kp.longitude]}
+                        radius={kp.effectiveProximityRadius || 50}
+                        pathOptions={{ color: isCompleted ? '#28a745' : '#667eea', weight: 1, fillOpacity: 0.1 }}
+                      />
+                    </React.Fragment>
                   );
                 })}
                 
+                {/* Path walked so far */}
+                {walkedPath.length > 1 && (
+                  <Polyline
+                    positions={walkedPath}
+                    color="#28a745"
+                    weight={4}
+                    dashArray="6 8"
+                  />
+                )}
+
                 {/* Route line */}
                 {keypoints.length > 0 && (
                   <Polyline
@@ -309,8 +340,19 @@ function TourExecution() {
               <p className="progress-text">
                 {completedCount} of {totalCount} key points completed ({progress.toFixed(0)}%)
               </p>
+              <p className="progress-text">
+                {(walkedDistance / 1000).toFixed(2)} km walked
+              </p>
             </div>
 
+            {nextKeypoint && (
+              <div className="next-keypoint">
+                <h4>{sequential ? '➡️ Next key point' : '📍 Nearest key point'}</h4>
+                <p className="nearby-name">{nextKeypoint.name}</p>
+                <p className="next-distance">{Math.round(distanceTo(nextKeypoint))} m away</p>
+              </div>
+            )}
+
             {nearbyKeypoint && (
               <div className="nearby-alert">
                 <h4>🎯 You're near a key point!</h4>
@@ -348,30 +390,30 @@ function TourExecution() {
                 Update Position
               </button>
               
-              {completedCount === totalCount ? (
-                <button 
-                  onClick={handleCompleteTour}
-                  className="btn-complete"
-                >
-                  Complete Tour ✓
-                </button>
-              ) : (
-                <button 
-                  onClick={handleAbandonTour}
-                  className="btn-abandon"
-                >
-                  Abandon Tour
-                </button>
-              )}
+              <button 
+                onClick={handleCompleteTour}
+                className="btn-complete"
+                disabled={completedCount === 0}
+              >
+                Finish Early ({completedCount}/{totalCount}) ✓
+              </button>
+
+              <button 
+                onClick={handleAbandonTour}
+                className="btn-abandon"
+              >
+                Abandon Tour
+              </button>
             </div>
 
             <div className="info-box">
               <h4>ℹ️ How it works</h4>
               <ul>
-                <li>Your position is checked every 10 seconds</li>
-                <li>Get within 50 meters of a key point to mark it as completed</li>
-                <li>Update your position in the simulator as you move</li>
-                <li>Complete all key points to finish the tour!</li>
+                <li>Your position is tracked live while the tour is active</li>
+                <li>Enter the circle around a key point to mark it as completed</li>
+                {sequential && <li>This tour is sequential: key points only count in tour order</li>}
+                <li>Click the map or use the simulator to move</li>
+                <li>The tour completes on its own once every key point is reached!</li>
               </ul>
             </div>
           </div>
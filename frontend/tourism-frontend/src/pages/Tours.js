@@ -9,6 +9,9 @@ function Tours() {
   const [tours, setTours] = useState([]);
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState('');
+  const [query, setQuery] = useState('');
+  const [searchResults, setSearchResults] = useState(null);
+  const [nextPageToken, setNextPageToken] = useState('');
   const { user } = useAuth();
   const navigate = useNavigate();
 
@@ -30,6 +33,46 @@ function Tours() {
     }
   };
 
+  const searchTours = async (pageToken = '') => {
+    try {
+      const response = await tourAPI.searchTours(query, pageToken);
+      if (response.data.success) {
+        const results = response.data.results;
+        setSearchResults(pageToken ? [...searchResults, ...results] : results);
+        setNextPageToken(response.data.nextPageToken);
+      } else {
+        alert(response.data.message);
+      }
+    } catch (err) {
+      alert('Failed to search tours');
+      console.error(err);
+    }
+  };
+
+  const handleSearch = (e) => {
+    e.preventDefault();
+    if (!query.trim()) {
+      setSearchResults(null);
+      setNextPageToken('');
+      return;
+    }
+    searchTours();
+  };
+
+  // Highlight offsets count characters, which Array.from splits strings into
+  const renderSnippet = (snippet) => {
+    const chars = Array.from(snippet.text);
+    const parts = [];
+    let last = 0;
+    snippet.highlights.forEach((h, index) => {
+      parts.push(chars.slice(last, h.start).join(''));
+      parts.push(<mark key={index}>{chars.slice(h.start, h.end).join('')}</mark>);
+      last = h.end;
+    });
+    parts.push(chars.slice(last).join(''));
+    return parts;
+  };
+
   const handleAddToCart = async (tourId) => {
     try {
       const response = await tourAPI.addToCart(user.userId, tourId);
@@ -59,7 +102,57 @@ function Tours() {
           <p>Discover amazing tours from our guides</p>
         </div>
 
-        {tours.length === 0 ? (
+        <form className="tour-search" onSubmit={handleSearch}>
+          <input
+            type="text"
+            value={query}
+            onChange={(e) => setQuery(e.target.value)}
+            placeholder="Search tours, places, tags..."
+          />
+          <button type="submit" className="btn-primary">Search</button>
+        </form>
+
+        {searchResults !== null ? (
+          searchResults.length === 0 ? (
+            <div className="empty-state">
+              <p>No tours match your search.</p>
+            </div>
+          ) : (
+            <div className="tours-grid">
+              {searchResults.map(({ tour, snippets }) => (
+                <div key={tour.id} className="tour-card">
+                  <div className="tour-header">
+                    <h3>{tour.name}</h3>
+                    <span className={`difficulty-badge ${tour.difficulty}`}>
+                      {tour.difficulty}
+                    </span>
+                  </div>
+
+                  {snippets.map((snippet, index) => (
+                    <p key={index} className="tour-snippet">{renderSnippet(snippet)}</p>
+                  ))}
+
+                  <div className="tour-footer">
+                    <span className="tour-price">${tour.price.toFixed(2)}</span>
+                    <div className="tour-actions">
+                      <button
+                        onClick={() => handleAddToCart(tour.id)}
+                        className="btn-primary"
+                      >
+                        Add to Cart
+                      </button>
+                    </div>
+                  </div>
+                </div>
+              ))}
+              {nextPageToken && (
+                <button onClick={() => searchTours(nextPageToken)} className="btn-secondary">
+                  Load more
+                </button>
+              )}
+            </div>
+          )
+        ) : tours.length === 0 ? (
           <div className="empty-state">
             <p>No tours available yet. Check back soon!</p>
           </div>
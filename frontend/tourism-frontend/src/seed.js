@@ -485,6 +485,10 @@ async function seedTourDb(client) {
       order: 2,
     },
   ];
+  // GeoJSON location used by the tour service's "tours near me" search
+  for (const kp of keypoints) {
+    kp.location = { type: "Point", coordinates: [kp.longitude, kp.latitude] };
+  }
   await db.collection("keypoints").insertMany(keypoints);
 
   // ── Purchase Tokens (tourist1 and tourist2 bought tour1; tourist1 also bought tour2) ──
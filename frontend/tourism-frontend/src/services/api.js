@@ -37,9 +37,14 @@ export const tourAPI = {
   getTours: (publishedOnly = true) => api.get(`/tour?publishedOnly=${publishedOnly}`),
   getMyTours: (guideId) => api.get(`/tour/my/${guideId}`),
   getTourById: (tourId) => api.get(`/tour/${tourId}`),
+  searchTours: (query, pageToken = '') =>
+    api.get(`/tour/search?query=${encodeURIComponent(query)}&pageToken=${encodeURIComponent(pageToken)}`),
   publishTour: (tourId, data) => api.put(`/tour/${tourId}/publish`, data),
   addKeyPoint: (tourId, data) => api.post(`/tour/${tourId}/keypoints`, data),
   getKeyPoints: (tourId, userId) => api.get(`/tour/${tourId}/keypoints?userId=${userId}`),
+  importKeyPoints: (tourId, data) => api.post(`/tour/${tourId}/keypoints/import`, data),
+  exportTour: (tourId, format, userId) =>
+    api.get(`/tour/${tourId}/export?format=${format}&userId=${userId}`, { responseType: 'blob' }),
   
   // Position
   updatePosition: (data) => api.post('/tour/position', data),
@@ -54,9 +59,16 @@ export const tourAPI = {
   // Tour execution
   getExecution: (executionId, touristId) => 
     api.get(`/tour/executions/${executionId}?touristId=${touristId}`),
+  listExecutions: (touristId, status = '', pageToken = '') =>
+    api.get('/tour/executions', { params: { touristId, status, pageToken } }),
   startExecution: (tourId, data) => api.post(`/tour/${tourId}/execute`, data),
   checkProximity: (executionId, data) => api.post(`/tour/executions/${executionId}/proximity`, data),
-  completeTour: (executionId, touristId) => api.post(`/tour/executions/${executionId}/complete`, JSON.stringify(touristId)),
+  getExecutionTrack: (executionId, touristId) =>
+    api.get(`/tour/executions/${executionId}/trajectory?touristId=${touristId}`),
+  // Browsers cannot set headers on WebSockets, so the token travels in the query
+  trackExecution: (executionId, touristId) =>
+    new WebSocket(`${API_BASE_URL.replace(/^http/, 'ws')}/tour/executions/${executionId}/track?touristId=${touristId}&access_token=${encodeURIComponent(localStorage.getItem('token') || '')}`),
+  completeTour: (executionId, touristId, partial = false) => api.post(`/tour/executions/${executionId}/complete`, JSON.stringify(touristId), { params: { partial } }),
   abandonTour: (executionId, touristId) => api.post(`/tour/executions/${executionId}/abandon`, JSON.stringify(touristId)),
 };
 
@@ -1,6 +1,10 @@
 ﻿using Gateway.DTOs;
 using Gateway.GrpcClients;
+using Google.Protobuf;
+using Grpc.Core;
 using Microsoft.AspNetCore.Mvc;
+using System.Net.WebSockets;
+using System.Text.Json;
 using TourService.Protos;
 
 namespace Gateway.Controllers;
@@ -9,6 +13,8 @@ namespace Gateway.Controllers;
 [Route("api/[controller]")]
 public class TourController : ControllerBase
 {
+    private static readonly JsonSerializerOptions TrackJsonOptions = new(JsonSerializerDefaults.Web);
+
     private readonly TourGrpcClient _tourClient;
     private readonly ILogger<TourController> _logger;
 
@@ -31,7 +37,9 @@ public class TourController : ControllerBase
                 Name = request.Name,
                 Description = request.Description,
                 Difficulty = request.Difficulty,
-                Tags = { request.Tags }
+                Tags = { request.Tags },
+                ExecutionMode = request.ExecutionMode,
+                ProximityRadius = request.ProximityRadius
             };
 
             var response = await _tourClient.Client.CreateTourAsync(grpcRequest);
@@ -55,14 +63,50 @@ public class TourController : ControllerBase
     }
 
     [HttpGet]
-    public async Task<ActionResult<ToursResponseDto>> GetTours([FromQuery] bool publishedOnly = true)
+    public async Task<ActionResult<ToursResponseDto>> GetTours(
+        [FromQuery] bool publishedOnly = true,
+        [FromQuery] int maxDurationMinutes = 0,
+        [FromQuery] string? transportMode = null,
+        [FromQuery] int pageSize = 0,
+        [FromQuery] string? pageToken = null,
+        [FromQuery] TourSort sort = TourSort.Newest,
+        [FromQuery] string? difficulty = null,
+        [FromQuery] List<string>? tags = null,
+        [FromQuery] bool matchAllTags = false,
+        [FromQuery] double? minPrice = null,
+        [FromQuery] double? maxPrice = null,
+        [FromQuery] string? guideId = null,
+        [FromQuery] string? createdAfter = null,
+        [FromQuery] string? createdBefore = null)
     {
         try
         {
             var grpcRequest = new GetToursRequest
             {
-                PublishedOnly = publishedOnly
+                PublishedOnly = publishedOnly,
+                MaxDurationMinutes = maxDurationMinutes,
+                TransportMode = transportMode ?? string.Empty,
+                PageSize = pageSize,
+                PageToken = pageToken ?? string.Empty,
+                Sort = sort,
+                Difficulty = difficulty ?? string.Empty,
+                MatchAllTags = matchAllTags,
+                GuideId = guideId ?? string.Empty,
+                CreatedAfter = createdAfter ?? string.Empty,
+                CreatedBefore = createdBefore ?? string.Empty
             };
+            if (tags != null)
+            {
+                grpcRequest.Tags.AddRange(tags);
+            }
+            if (minPrice.HasValue)
+            {
+                grpcRequest.MinPrice = minPrice.Value;
+            }
+            if (maxPrice.HasValue)
+            {
+                grpcRequest.MaxPrice = maxPrice.Value;
+            }
 
             var response = await _tourClient.Client.GetToursAsync(grpcRequest);
 
@@ -70,7 +114,8 @@ public class TourController : ControllerBase
             {
                 Success = response.Success,
                 Message = response.Message,
-                Tours = response.Tours.Select(MapTourToDto).ToList()
+                Tours = response.Tours.Select(MapTourToDto).ToList(),
+                NextPageToken = response.NextPageToken
             });
         }
         catch (Exception ex)
@@ -114,6 +159,97 @@ public class TourController : ControllerBase
         }
     }
 
+    [HttpGet("nearby")]
+    public async Task<ActionResult<NearbyToursResponseDto>> SearchToursNearby(
+        [FromQuery] double latitude,
+        [FromQuery] double longitude,
+        [FromQuery] double radiusKm,
+        [FromQuery] int limit = 0)
+    {
+        try
+        {
+            var grpcRequest = new SearchToursNearbyRequest
+            {
+                Latitude = latitude,
+                Longitude = longitude,
+                RadiusKm = radiusKm,
+                Limit = limit
+            };
+
+            var response = await _tourClient.Client.SearchToursNearbyAsync(grpcRequest);
+
+            return Ok(new NearbyToursResponseDto
+            {
+                Success = response.Success,
+                Message = response.Message,
+                Tours = response.Tours.Select(t => new NearbyTourDto
+                {
+                    Tour = MapTourToDto(t.Tour),
+                    DistanceKm = t.DistanceKm
+                }).ToList()
+            });
+        }
+        catch (Exception ex)
+        {
+            _logger.LogError($"Error searching nearby tours: {ex.Message}");
+            return StatusCode(500, new NearbyToursResponseDto
+            {
+                Success = false,
+                Message = "An error occurred while searching tours."
+            });
+        }
+    }
+
+    [HttpGet("search")]
+    public async Task<ActionResult<SearchToursResponseDto>> SearchTours(
+        [FromQuery] string query,
+        [FromQuery] int pageSize = 0,
+        [FromQuery] string? pageToken = null)
+    {
+        try
+        {
+            var grpcRequest = new SearchToursRequest
+            {
+                Query = query ?? string.Empty,
+                PageSize = pageSize,
+                PageToken = pageToken ?? string.Empty
+            };
+
+            var response = await _tourClient.Client.SearchToursAsync(grpcRequest);
+
+            return Ok(new SearchToursResponseDto
+            {
+                Success = response.Success,
+                Message = response.Message,
+                Results = response.Results.Select(r => new TourSearchResultDto
+                {
+                    Tour = MapTourToDto(r.Tour),
+                    Score = r.Score,
+                    Snippets = r.Snippets.Select(s => new SnippetDto
+                    {
+                        Field = s.Field,
+                        Text = s.Text,
+                        Highlights = s.Highlights.Select(h => new HighlightDto
+                        {
+                            Start = h.Start,
+                            End = h.End
+                        }).ToList()
+                    }).ToList()
+                }).ToList(),
+                NextPageToken = response.NextPageToken
+            });
+        }
+        catch (Exception ex)
+        {
+            _logger.LogError($"Error searching tours: {ex.Message}");
+            return StatusCode(500, new SearchToursResponseDto
+            {
+                Success = false,
+                Message = "An error occurred while searching tours."
+            });
+        }
+    }
+
     [HttpGet("{tourId}")]
     public async Task<ActionResult<TourResponseDto>> GetTourById(string tourId)
     {
@@ -171,7 +307,12 @@ public class TourController : ControllerBase
             {
                 Success = response.Success,
                 Message = response.Message,
-                Tour = response.Tour != null ? MapTourToDto(response.Tour) : null
+                Tour = response.Tour != null ? MapTourToDto(response.Tour) : null,
+                Violations = response.Violations.Select(v => new ViolationDto
+                {
+                    Field = v.Field,
+                    Description = v.Description
+                }).ToList()
             });
         }
         catch (Exception ex)
@@ -201,7 +342,8 @@ public class TourController : ControllerBase
                 Name = request.Name,
                 Description = request.Description,
                 Image = request.Image,
-                Order = request.Order
+                Order = request.Order,
+                ProximityRadius = request.ProximityRadius
             };
 
             var response = await _tourClient.Client.AddKeyPointAsync(grpcRequest);
@@ -256,6 +398,117 @@ public class TourController : ControllerBase
         }
     }
 
+    [HttpPut("{tourId}/keypoints/order")]
+    public async Task<ActionResult<KeyPointsResponseDto>> ReorderKeyPoints(string tourId, [FromBody] ReorderKeyPointsRequestDto request)
+    {
+        try
+        {
+            var grpcRequest = new ReorderKeyPointsRequest
+            {
+                TourId = tourId,
+                GuideId = request.GuideId
+            };
+            grpcRequest.KeyPointIds.AddRange(request.KeyPointIds);
+
+            var response = await _tourClient.Client.ReorderKeyPointsAsync(grpcRequest);
+
+            return Ok(new KeyPointsResponseDto
+            {
+                Success = response.Success,
+                Message = response.Message,
+                KeyPoints = response.KeyPoints.Select(MapKeyPointToDto).ToList()
+            });
+        }
+        catch (Exception ex)
+        {
+            _logger.LogError($"Error reordering keypoints: {ex.Message}");
+            return StatusCode(500, new KeyPointsResponseDto
+            {
+                Success = false,
+                Message = "An error occurred while reordering keypoints."
+            });
+        }
+    }
+
+    [HttpPost("{tourId}/keypoints/import")]
+    public async Task<ActionResult<ImportKeyPointsResponseDto>> ImportKeyPoints(string tourId, [FromBody] ImportKeyPointsRequestDto request)
+    {
+        try
+        {
+            var grpcRequest = new ImportKeyPointsRequest
+            {
+                TourId = tourId,
+                GuideId = request.GuideId,
+                Format = request.Format,
+                Data = ByteString.CopyFromUtf8(request.Data),
+                Mode = request.Mode
+            };
+
+            var response = await _tourClient.Client.ImportKeyPointsAsync(grpcRequest);
+
+            return Ok(new ImportKeyPointsResponseDto
+            {
+                Success = response.Success,
+                Message = response.Message,
+                ImportedCount = response.ImportedCount,
+                RejectedCount = response.RejectedCount,
+                Features = response.Features.Select(f => new ImportedFeatureDto
+                {
+                    Index = f.Index,
+                    Name = f.Name,
+                    Imported = f.Imported,
+                    KeyPoint = f.KeyPoint != null ? MapKeyPointToDto(f.KeyPoint) : null,
+                    Reason = f.Reason
+                }).ToList()
+            });
+        }
+        catch (Exception ex)
+        {
+            _logger.LogError($"Error importing keypoints: {ex.Message}");
+            return StatusCode(500, new ImportKeyPointsResponseDto
+            {
+                Success = false,
+                Message = "An error occurred while importing keypoints."
+            });
+        }
+    }
+
+    [HttpGet("{tourId}/export")]
+    public async Task<IActionResult> ExportTour(string tourId, [FromQuery] string format = "gpx", [FromQuery] string? userId = null)
+    {
+        try
+        {
+            var grpcRequest = new ExportTourRequest
+            {
+                TourId = tourId,
+                UserId = userId ?? string.Empty,
+                Format = format
+            };
+
+            var response = await _tourClient.Client.ExportTourAsync(grpcRequest);
+
+            if (!response.Success)
+            {
+                return Ok(new ExportTourResponseDto
+                {
+                    Success = response.Success,
+                    Message = response.Message
+                });
+            }
+
+            return File(response.Data.ToByteArray(), response.ContentType, response.FileName);
+        }
+        catch (Exception ex)
+        {
+            _logger.LogError($"Error exporting tour: {ex.Message}");
+            return StatusCode(500, new ExportTourResponseDto
+            {
+                Success = false,
+                Message = "An error occurred while exporting the tour."
+            });
+        }
+    }
+
     // ============ Position Simulator ============
 
     [HttpPost("position")]
@@ -415,13 +668,14 @@ public class TourController : ControllerBase
     }
 
     [HttpPost("cart/{touristId}/checkout")]
-    public async Task<ActionResult<CheckoutResponseDto>> Checkout(string touristId)
+    public async Task<ActionResult<CheckoutResponseDto>> Checkout(string touristId, [FromHeader(Name = "Idempotency-Key")] string? idempotencyKey = null)
     {
         try
         {
             var grpcRequest = new CheckoutRequest
             {
-                TouristId = touristId
+                TouristId = touristId,
+                IdempotencyKey = idempotencyKey ?? string.Empty
             };
 
             var response = await _tourClient.Client.CheckoutAsync(grpcRequest);
@@ -430,6 +684,7 @@ public class TourController : ControllerBase
             {
                 Success = response.Success,
                 Message = response.Message,
+                Replayed = response.Replayed,
                 Tokens = response.Tokens.Select(t => new PurchaseTokenDto
                 {
                     TourId = t.TourId,
@@ -484,6 +739,56 @@ public class TourController : ControllerBase
         }
     }
 
+    [HttpGet("executions")]
+    public async Task<ActionResult<ExecutionsResponseDto>> ListExecutions(
+        [FromQuery] string touristId,
+        [FromQuery] string? status = null,
+        [FromQuery] string? tourId = null,
+        [FromQuery] string? startedAfter = null,
+        [FromQuery] string? startedBefore = null,
+        [FromQuery] int pageSize = 0,
+        [FromQuery] string? pageToken = null)
+    {
+        try
+        {
+            var grpcRequest = new ListExecutionsRequest
+            {
+                TouristId = touristId,
+                Status = status ?? string.Empty,
+                TourId = tourId ?? string.Empty,
+                StartedAfter = startedAfter ?? string.Empty,
+                StartedBefore = startedBefore ?? string.Empty,
+                PageSize = pageSize,
+                PageToken = pageToken ?? string.Empty
+            };
+
+            var response = await _tourClient.Client.ListExecutionsAsync(grpcRequest);
+
+            return Ok(new ExecutionsResponseDto
+            {
+                Success = response.Success,
+                Message = response.Message,
+                Executions = response.Executions.Select(e => new ExecutionListEntryDto
+                {
+                    Execution = MapExecutionToDto(e.Execution),
+                    TourName = e.TourName,
+                    CompletedKeyPoints = e.CompletedKeyPoints,
+                    TotalKeyPoints = e.TotalKeyPoints
+                }).ToList(),
+                NextPageToken = response.NextPageToken
+            });
+        }
+        catch (Exception ex)
+        {
+            _logger.LogError($"Error listing executions: {ex.Message}");
+            return StatusCode(500, new ExecutionsResponseDto
+            {
+                Success = false,
+                Message = "An error occurred while listing executions."
+            });
+        }
+    }
+
     [HttpPost("executions/{executionId}/proximity")]
     public async Task<ActionResult<ProximityResponseDto>> CheckProximity(string executionId, [FromBody] UpdatePositionRequestDto request)
     {
@@ -505,7 +810,11 @@ public class TourController : ControllerBase
                 Message = response.Message,
                 NearKeyPoint = response.NearKeyPoint,
                 NearbyKeyPoint = response.NearbyKeyPoint != null ? MapKeyPointToDto(response.NearbyKeyPoint) : null,
-                Distance = response.Distance
+                Distance = response.Distance,
+                NextKeyPoint = response.NextKeyPoint != null ? MapKeyPointToDto(response.NextKeyPoint) : null,
+                NextKeyPointDistance = response.NextKeyPointDistance,
+                TourCompleted = response.TourCompleted,
+                Summary = response.Summary != null ? MapSummaryToDto(response.Summary) : null
             });
         }
         catch (Exception ex)
@@ -519,15 +828,156 @@ public class TourController : ControllerBase
         }
     }
 
+    // Bridges a WebSocket to the TrackExecution stream: the client sends
+    // {"latitude", "longitude"} messages and receives one JSON event per
+    // reached keypoint, then a final tourCompleted or executionExpired.
+    [HttpGet("executions/{executionId}/track")]
+    public async Task TrackExecution(string executionId, [FromQuery] string touristId)
+    {
+        if (!HttpContext.WebSockets.IsWebSocketRequest)
+        {
+            HttpContext.Response.StatusCode = StatusCodes.Status400BadRequest;
+            return;
+        }
+
+        using var socket = await HttpContext.WebSockets.AcceptWebSocketAsync();
+        using var call = _tourClient.Client.TrackExecution(cancellationToken: HttpContext.RequestAborted);
+
+        var forwarding = ForwardPositionsAsync(socket, call.RequestStream, executionId, touristId);
+        try
+        {
+            await foreach (var trackEvent in call.ResponseStream.ReadAllAsync(HttpContext.RequestAborted))
+            {
+                var payload = JsonSerializer.SerializeToUtf8Bytes(MapTrackEventToDto(trackEvent), TrackJsonOptions);
+                await socket.SendAsync(payload, WebSocketMessageType.Text, true, HttpContext.RequestAborted);
+            }
+
+            if (socket.State == WebSocketState.Open || socket.State == WebSocketState.CloseReceived)
+            {
+                await socket.CloseAsync(WebSocketCloseStatus.NormalClosure, "Tracking ended", CancellationToken.None);
+            }
+        }
+        catch (RpcException ex) when (ex.StatusCode != Grpc.Core.StatusCode.Cancelled)
+        {
+            _logger.LogError($"Error tracking execution: {ex.Status.Detail}");
+            if (socket.State == WebSocketState.Open || socket.State == WebSocketState.CloseReceived)
+            {
+                await socket.CloseAsync(WebSocketCloseStatus.PolicyViolation, "Tracking failed", CancellationToken.None);
+            }
+        }
+        catch (Exception ex) when (ex is OperationCanceledException || ex is RpcException || ex is WebSocketException)
+        {
+            // The browser went away; nothing is left to tell it
+        }
+
+        try
+        {
+            await forwarding;
+        }
+        catch (Exception ex) when (ex is OperationCanceledException || ex is RpcException || ex is WebSocketException || ex is InvalidOperationException)
+        {
+            // The stream ended before the socket did
+        }
+    }
+
+    private async Task ForwardPositionsAsync(WebSocket socket, IClientStreamWriter<TrackExecutionRequest> requests, string executionId, string touristId)
+    {
+        var buffer = new byte[4096];
+        using var message = new MemoryStream();
+
+        while (socket.State == WebSocketState.Open)
+        {
+            var result = await socket.ReceiveAsync(buffer, HttpContext.RequestAborted);
+            if (result.MessageType == WebSocketMessageType.Close)
+            {
+                break;
+            }
+
+            message.Write(buffer, 0, result.Count);
+            if (!result.EndOfMessage)
+            {
+                continue;
+            }
+
+            TrackPositionDto? position;
+            try
+            {
+                position = JsonSerializer.Deserialize<TrackPositionDto>(message.ToArray(), TrackJsonOptions);
+            }
+            catch (JsonException)
+            {
+                position = null;
+            }
+            message.SetLength(0);
+
+            if (position == null)
+            {
+                continue;
+            }
+
+            await requests.WriteAsync(new TrackExecutionRequest
+            {
+                ExecutionId = executionId,
+                TouristId = touristId,
+                Latitude = position.Latitude,
+                Longitude = position.Longitude
+            });
+        }
+
+        await requests.CompleteAsync();
+    }
+
+    [HttpGet("executions/{executionId}/trajectory")]
+    public async Task<ActionResult<ExecutionTrackResponseDto>> GetExecutionTrack(string executionId, [FromQuery] string touristId)
+    {
+        try
+        {
+            var grpcRequest = new GetExecutionTrackRequest
+            {
+                ExecutionId = executionId,
+                TouristId = touristId
+            };
+
+            var response = await _tourClient.Client.GetExecutionTrackAsync(grpcRequest);
+
+            return Ok(new ExecutionTrackResponseDto
+            {
+                Success = response.Success,
+                Message = response.Message,
+                Points = response.Points.Select(p => new TrackPointDto
+                {
+                    Latitude = p.Latitude,
+                    Longitude = p.Longitude,
+                    RecordedAt = p.RecordedAt
+                }).ToList(),
+                Distance = response.Distance,
+                DurationSeconds = response.DurationSeconds,
+                AverageSpeed = response.AverageSpeed
+            });
+        }
+        catch (Exception ex)
+        {
+            _logger.LogError($"Error getting execution track: {ex.Message}");
+            return StatusCode(500, new ExecutionTrackResponseDto
+            {
+                Success = false,
+                Message = "An error occurred while getting the execution track."
+            });
+        }
+    }
+
+    // Executions complete on their own once every keypoint is reached;
+    // ?partial=true completes one earlier.
     [HttpPost("executions/{executionId}/complete")]
-    public async Task<ActionResult<ExecutionResponseDto>> CompleteTour(string executionId, [FromBody] string touristId)
+    public async Task<ActionResult<ExecutionResponseDto>> CompleteTour(string executionId, [FromBody] string touristId, [FromQuery] bool partial = false)
     {
         try
         {
             var grpcRequest = new CompleteExecutionRequest
             {
                 ExecutionId = executionId,
-                TouristId = touristId
+                TouristId = touristId,
+                Partial = partial
             };
 
             var response = await _tourClient.Client.CompleteTourAsync(grpcRequest);
@@ -536,7 +986,8 @@ public class TourController : ControllerBase
             {
                 Success = response.Success,
                 Message = response.Message,
-                Execution = response.Execution != null ? MapExecutionToDto(response.Execution) : null
+                Execution = response.Execution != null ? MapExecutionToDto(response.Execution) : null,
+                Summary = response.Summary != null ? MapSummaryToDto(response.Summary) : null
             });
         }
         catch (Exception ex)
@@ -581,6 +1032,103 @@ public class TourController : ControllerBase
         }
     }
 
+    // ============ Reviews ============
+
+    [HttpPost("{tourId}/reviews")]
+    public async Task<ActionResult<ReviewResponseDto>> CreateReview(string tourId, [FromBody] CreateReviewRequestDto request)
+    {
+        try
+        {
+            var grpcRequest = new CreateReviewRequest
+            {
+                TourId = tourId,
+                TouristId = request.TouristId,
+                Rating = request.Rating,
+                Comment = request.Comment,
+                VisitDate = request.VisitDate
+            };
+            grpcRequest.Images.AddRange(request.Images);
+
+            var response = await _tourClient.Client.CreateReviewAsync(grpcRequest);
+
+            return Ok(new ReviewResponseDto
+            {
+                Success = response.Success,
+                Message = response.Message,
+                Review = response.Review != null ? MapReviewToDto(response.Review) : null
+            });
+        }
+        catch (Exception ex)
+        {
+            _logger.LogError($"Error creating review: {ex.Message}");
+            return StatusCode(500, new ReviewResponseDto
+            {
+                Success = false,
+                Message = "An error occurred while creating review."
+            });
+        }
+    }
+
+    [HttpGet("{tourId}/reviews")]
+    public async Task<ActionResult<ReviewsResponseDto>> GetReviews(string tourId)
+    {
+        try
+        {
+            var response = await _tourClient.Client.GetReviewsAsync(new GetReviewsRequest { TourId = tourId });
+
+            return Ok(new ReviewsResponseDto
+            {
+                Success = response.Success,
+                Message = response.Message,
+                Reviews = response.Reviews.Select(MapReviewToDto).ToList()
+            });
+        }
+        catch (Exception ex)
+        {
+            _logger.LogError($"Error getting reviews: {ex.Message}");
+            return StatusCode(500, new ReviewsResponseDto
+            {
+                Success = false,
+                Message = "An error occurred while getting reviews."
+            });
+        }
+    }
+
+    [HttpPut("reviews/{reviewId}")]
+    public async Task<ActionResult<ReviewResponseDto>> UpdateReview(string reviewId, [FromBody] UpdateReviewRequestDto request)
+    {
+        try
+        {
+            var grpcRequest = new UpdateReviewRequest
+            {
+                ReviewId = reviewId,
+                TouristId = request.TouristId,
+                Rating = request.Rating,
+                Comment = request.Comment,
+                VisitDate = request.VisitDate
+            };
+            grpcRequest.Images.AddRange(request.Images);
+
+            var response = await _tourClient.Client.UpdateReviewAsync(grpcRequest);
+
+            return Ok(new ReviewResponseDto
+            {
+                Success = response.Success,
+                Message = response.Message,
+                Review = response.Review != null ? MapReviewToDto(response.Review) : null
+            });
+        }
+        catch (Exception ex)
+        {
+            _logger.LogError($"Error updating review: {ex.Message}");
+            return StatusCode(500, new ReviewResponseDto
+            {
+                Success = false,
+                Message = "An error occurred while updating review."
+            });
+        }
+    }
+
     // ============ Helper Methods ============
 
     private static TourDto MapTourToDto(Tour tour)
@@ -597,7 +1145,35 @@ public class TourController : ControllerBase
             Price = tour.Price,
             IsPublished = tour.IsPublished,
             PublishedAt = tour.PublishedAt,
-            CreatedAt = tour.CreatedAt
+            CreatedAt = tour.CreatedAt,
+            ArchivedAt = tour.ArchivedAt,
+            LengthKm = tour.LengthKm,
+            TransportTimes = tour.TransportTimes.Select(t => new TransportTimeDto
+            {
+                Mode = t.Mode,
+                Minutes = t.Minutes,
+                Suggested = t.Suggested
+            }).ToList(),
+            AverageRating = tour.AverageRating,
+            ReviewCount = tour.ReviewCount,
+            ExecutionMode = tour.ExecutionMode,
+            ProximityRadius = tour.ProximityRadius
+        };
+    }
+
+    private static ReviewDto MapReviewToDto(Review review)
+    {
+        return new ReviewDto
+        {
+            Id = review.Id,
+            TourId = review.TourId,
+            TouristId = review.TouristId,
+            Rating = review.Rating,
+            Comment = review.Comment,
+            VisitDate = review.VisitDate,
+            Images = review.Images.ToList(),
+            CreatedAt = review.CreatedAt,
+            UpdatedAt = review.UpdatedAt
         };
     }
 
@@ -612,7 +1188,9 @@ public class TourController : ControllerBase
             Name = kp.Name,
             Description = kp.Description,
             Image = kp.Image,
-            Order = kp.Order
+            Order = kp.Order,
+            ProximityRadius = kp.ProximityRadius,
+            EffectiveProximityRadius = kp.EffectiveProximityRadius
         };
     }
 
@@ -642,6 +1220,36 @@ public class TourController : ControllerBase
         };
     }
 
+    private static TrackExecutionEventDto MapTrackEventToDto(TrackExecutionEvent trackEvent)
+    {
+        return trackEvent.EventCase switch
+        {
+            TrackExecutionEvent.EventOneofCase.KeyPointReached => new TrackExecutionEventDto
+            {
+                Type = "keyPointReached",
+                KeyPoint = trackEvent.KeyPointReached.KeyPoint != null ? MapKeyPointToDto(trackEvent.KeyPointReached.KeyPoint) : null,
+                Distance = trackEvent.KeyPointReached.Distance,
+                CompletedCount = trackEvent.KeyPointReached.CompletedCount,
+                TotalCount = trackEvent.KeyPointReached.TotalCount,
+                NextKeyPoint = trackEvent.KeyPointReached.NextKeyPoint != null ? MapKeyPointToDto(trackEvent.KeyPointReached.NextKeyPoint) : null,
+                NextKeyPointDistance = trackEvent.KeyPointReached.NextKeyPointDistance
+            },
+            TrackExecutionEvent.EventOneofCase.TourCompleted => new TrackExecutionEventDto
+            {
+                Type = "tourCompleted",
+                Execution = trackEvent.TourCompleted.Execution != null ? MapExecutionToDto(trackEvent.TourCompleted.Execution) : null,
+                Summary = trackEvent.TourCompleted.Summary != null ? MapSummaryToDto(trackEvent.TourCompleted.Summary) : null
+            },
+            TrackExecutionEvent.EventOneofCase.ExecutionExpired => new TrackExecutionEventDto
+            {
+                Type = "executionExpired",
+                Execution = trackEvent.ExecutionExpired.Execution != null ? MapExecutionToDto(trackEvent.ExecutionExpired.Execution) : null,
+                Reason = trackEvent.ExecutionExpired.Reason
+            },
+            _ => new TrackExecutionEventDto { Type = "unknown" }
+        };
+    }
+
     private static TourExecutionDto MapExecutionToDto(TourExecution exec)
     {
         return new TourExecutionDto
@@ -658,7 +1266,21 @@ public class TourController : ControllerBase
             {
                 KeypointId = kp.KeypointId,
                 CompletedAt = kp.CompletedAt
-            }).ToList()
+            }).ToList(),
+            Mode = exec.Mode,
+            CompletionPercentage = exec.CompletionPercentage
+        };
+    }
+
+    private static CompletionSummaryDto MapSummaryToDto(CompletionSummary summary)
+    {
+        return new CompletionSummaryDto
+        {
+            CompletedKeyPoints = summary.CompletedKeyPoints,
+            TotalKeyPoints = summary.TotalKeyPoints,
+            CompletionPercentage = summary.CompletionPercentage,
+            DurationSeconds = summary.DurationSeconds,
+            Distance = summary.Distance
         };
     }
 }
\ No newline at end of file
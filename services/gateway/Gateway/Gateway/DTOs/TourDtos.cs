@@ -8,6 +8,8 @@ public class CreateTourRequestDto
     public string Description { get; set; } = string.Empty;
     public string Difficulty { get; set; } = string.Empty;
     public List<string> Tags { get; set; } = new();
+    public string ExecutionMode { get; set; } = string.Empty;
+    public double ProximityRadius { get; set; }
 }
 
 public class TourDto
@@ -23,6 +25,20 @@ public class TourDto
     public bool IsPublished { get; set; }
     public string PublishedAt { get; set; } = string.Empty;
     public string CreatedAt { get; set; } = string.Empty;
+    public string ArchivedAt { get; set; } = string.Empty;
+    public double LengthKm { get; set; }
+    public List<TransportTimeDto> TransportTimes { get; set; } = new();
+    public double AverageRating { get; set; }
+    public int ReviewCount { get; set; }
+    public string ExecutionMode { get; set; } = string.Empty;
+    public double ProximityRadius { get; set; }
+}
+
+public class TransportTimeDto
+{
+    public string Mode { get; set; } = string.Empty;
+    public int Minutes { get; set; }
+    public bool Suggested { get; set; }
 }
 
 public class TourResponseDto
@@ -30,6 +46,13 @@ public class TourResponseDto
     public bool Success { get; set; }
     public string Message { get; set; } = string.Empty;
     public TourDto? Tour { get; set; }
+    public List<ViolationDto> Violations { get; set; } = new();
+}
+
+public class ViolationDto
+{
+    public string Field { get; set; } = string.Empty;
+    public string Description { get; set; } = string.Empty;
 }
 
 public class ToursResponseDto
@@ -37,6 +60,48 @@ public class ToursResponseDto
     public bool Success { get; set; }
     public string Message { get; set; } = string.Empty;
     public List<TourDto> Tours { get; set; } = new();
+    public string NextPageToken { get; set; } = string.Empty;
+}
+
+public class NearbyTourDto
+{
+    public TourDto Tour { get; set; } = new();
+    public double DistanceKm { get; set; }
+}
+
+public class NearbyToursResponseDto
+{
+    public bool Success { get; set; }
+    public string Message { get; set; } = string.Empty;
+    public List<NearbyTourDto> Tours { get; set; } = new();
+}
+
+public class HighlightDto
+{
+    public int Start { get; set; }
+    public int End { get; set; }
+}
+
+public class SnippetDto
+{
+    public string Field { get; set; } = string.Empty;
+    public string Text { get; set; } = string.Empty;
+    public List<HighlightDto> Highlights { get; set; } = new();
+}
+
+public class TourSearchResultDto
+{
+    public TourDto Tour { get; set; } = new();
+    public double Score { get; set; }
+    public List<SnippetDto> Snippets { get; set; } = new();
+}
+
+public class SearchToursResponseDto
+{
+    public bool Success { get; set; }
+    public string Message { get; set; } = string.Empty;
+    public List<TourSearchResultDto> Results { get; set; } = new();
+    public string NextPageToken { get; set; } = string.Empty;
 }
 
 public class PublishTourRequestDto
@@ -55,6 +120,13 @@ public class AddKeyPointRequestDto
     public string Description { get; set; } = string.Empty;
     public string Image { get; set; } = string.Empty;
     public int Order { get; set; }
+    public double ProximityRadius { get; set; }
+}
+
+public class ReorderKeyPointsRequestDto
+{
+    public string GuideId { get; set; } = string.Empty;
+    public List<string> KeyPointIds { get; set; } = new();
 }
 
 public class KeyPointDto
@@ -67,6 +139,8 @@ public class KeyPointDto
     public string Description { get; set; } = string.Empty;
     public string Image { get; set; } = string.Empty;
     public int Order { get; set; }
+    public double ProximityRadius { get; set; }
+    public double EffectiveProximityRadius { get; set; }
 }
 
 public class KeyPointResponseDto
@@ -84,6 +158,38 @@ public class KeyPointsResponseDto
     public bool IsPurchased { get; set; }
 }
 
+public class ImportKeyPointsRequestDto
+{
+    public string GuideId { get; set; } = string.Empty;
+    public string Format { get; set; } = string.Empty;
+    public string Data { get; set; } = string.Empty;
+    public string Mode { get; set; } = string.Empty;
+}
+
+public class ImportKeyPointsResponseDto
+{
+    public bool Success { get; set; }
+    public string Message { get; set; } = string.Empty;
+    public int ImportedCount { get; set; }
+    public int RejectedCount { get; set; }
+    public List<ImportedFeatureDto> Features { get; set; } = new();
+}
+
+public class ImportedFeatureDto
+{
+    public int Index { get; set; }
+    public string Name { get; set; } = string.Empty;
+    public bool Imported { get; set; }
+    public KeyPointDto? KeyPoint { get; set; }
+    public string Reason { get; set; } = string.Empty;
+}
+
+public class ExportTourResponseDto
+{
+    public bool Success { get; set; }
+    public string Message { get; set; } = string.Empty;
+}
+
 // Position
 public class UpdatePositionRequestDto
 {
@@ -134,6 +240,7 @@ public class CheckoutResponseDto
     public bool Success { get; set; }
     public string Message { get; set; } = string.Empty;
     public List<PurchaseTokenDto> Tokens { get; set; } = new();
+    public bool Replayed { get; set; }
 }
 
 public class PurchaseTokenDto
@@ -163,6 +270,8 @@ public class TourExecutionDto
     public string LastActivity { get; set; } = string.Empty;
     public PositionDto? StartPosition { get; set; }
     public List<CompletedKeyPointDto> CompletedKeypoints { get; set; } = new();
+    public string Mode { get; set; } = string.Empty;
+    public double CompletionPercentage { get; set; }
 }
 
 public class CompletedKeyPointDto
@@ -176,6 +285,32 @@ public class ExecutionResponseDto
     public bool Success { get; set; }
     public string Message { get; set; } = string.Empty;
     public TourExecutionDto? Execution { get; set; }
+    public CompletionSummaryDto? Summary { get; set; }
+}
+
+public class ExecutionListEntryDto
+{
+    public TourExecutionDto Execution { get; set; } = new();
+    public string TourName { get; set; } = string.Empty;
+    public int CompletedKeyPoints { get; set; }
+    public int TotalKeyPoints { get; set; }
+}
+
+public class ExecutionsResponseDto
+{
+    public bool Success { get; set; }
+    public string Message { get; set; } = string.Empty;
+    public List<ExecutionListEntryDto> Executions { get; set; } = new();
+    public string NextPageToken { get; set; } = string.Empty;
+}
+
+public class CompletionSummaryDto
+{
+    public int CompletedKeyPoints { get; set; }
+    public int TotalKeyPoints { get; set; }
+    public double CompletionPercentage { get; set; }
+    public double DurationSeconds { get; set; }
+    public double Distance { get; set; }
 }
 
 public class ProximityResponseDto
@@ -185,4 +320,91 @@ public class ProximityResponseDto
     public bool NearKeyPoint { get; set; }
     public KeyPointDto? NearbyKeyPoint { get; set; }
     public double Distance { get; set; }
+    public KeyPointDto? NextKeyPoint { get; set; }
+    public double NextKeyPointDistance { get; set; }
+    public bool TourCompleted { get; set; }
+    public CompletionSummaryDto? Summary { get; set; }
+}
+
+public class TrackPositionDto
+{
+    public double Latitude { get; set; }
+    public double Longitude { get; set; }
+}
+
+public class TrackExecutionEventDto
+{
+    public string Type { get; set; } = string.Empty;
+    public KeyPointDto? KeyPoint { get; set; }
+    public double Distance { get; set; }
+    public int CompletedCount { get; set; }
+    public int TotalCount { get; set; }
+    public KeyPointDto? NextKeyPoint { get; set; }
+    public double NextKeyPointDistance { get; set; }
+    public TourExecutionDto? Execution { get; set; }
+    public CompletionSummaryDto? Summary { get; set; }
+    public string Reason { get; set; } = string.Empty;
+}
+
+public class ExecutionTrackResponseDto
+{
+    public bool Success { get; set; }
+    public string Message { get; set; } = string.Empty;
+    public List<TrackPointDto> Points { get; set; } = new();
+    public double Distance { get; set; }
+    public double DurationSeconds { get; set; }
+    public double AverageSpeed { get; set; }
+}
+
+public class TrackPointDto
+{
+    public double Latitude { get; set; }
+    public double Longitude { get; set; }
+    public string RecordedAt { get; set; } = string.Empty;
+}
+
+// Reviews
+public class CreateReviewRequestDto
+{
+    public string TouristId { get; set; } = string.Empty;
+    public int Rating { get; set; }
+    public string Comment { get; set; } = string.Empty;
+    public string VisitDate { get; set; } = string.Empty;
+    public List<string> Images { get; set; } = new();
+}
+
+public class UpdateReviewRequestDto
+{
+    public string TouristId { get; set; } = string.Empty;
+    public int Rating { get; set; }
+    public string Comment { get; set; } = string.Empty;
+    public string VisitDate { get; set; } = string.Empty;
+    public List<string> Images { get; set; } = new();
+}
+
+public class ReviewDto
+{
+    public string Id { get; set; } = string.Empty;
+    public string TourId { get; set; } = string.Empty;
+    public string TouristId { get; set; } = string.Empty;
+    public int Rating { get; set; }
+    public string Comment { get; set; } = string.Empty;
+    public string VisitDate { get; set; } = string.Empty;
+    public List<string> Images { get; set; } = new();
+    public string CreatedAt { get; set; } = string.Empty;
+    public string UpdatedAt { get; set; } = string.Empty;
+}
+
+public class ReviewResponseDto
+{
+    public bool Success { get; set; }
+    public string Message { get; set; } = string.Empty;
+    public ReviewDto? Review { get; set; }
+}
+
+public class ReviewsResponseDto
+{
+    public bool Success { get; set; }
+    public string Message { get; set; } = string.Empty;
+    public List<ReviewDto> Reviews { get; set; } = new();
 }
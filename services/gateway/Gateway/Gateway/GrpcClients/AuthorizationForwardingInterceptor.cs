@@ -0,0 +1,77 @@
+﻿using Grpc.Core;
+using Grpc.Core.Interceptors;
+
+namespace Gateway.GrpcClients;
+
+// Forwards the caller's bearer token to the backend services so they can
+// authenticate the user behind each request. Browsers cannot set headers on
+// WebSocket requests, so those send the token as ?access_token= instead.
+public class AuthorizationForwardingInterceptor : Interceptor
+{
+    private const string AccessTokenQueryKey = "access_token";
+
+    private readonly IHttpContextAccessor _httpContextAccessor;
+
+    public AuthorizationForwardingInterceptor(IHttpContextAccessor httpContextAccessor)
+    {
+        _httpContextAccessor = httpContextAccessor;
+    }
+
+    public override TResponse BlockingUnaryCall<TRequest, TResponse>(TRequest request, ClientInterceptorContext<TRequest, TResponse> context, BlockingUnaryCallContinuation<TRequest, TResponse> continuation)
+    {
+        return continuation(request, WithAuthorization(context));
+    }
+
+    public override AsyncUnaryCall<TResponse> AsyncUnaryCall<TRequest, TResponse>(TRequest request, ClientInterceptorContext<TRequest, TResponse> context, AsyncUnaryCallContinuation<TRequest, TResponse> continuation)
+    {
+        return continuation(request, WithAuthorization(context));
+    }
+
+    public override AsyncServerStreamingCall<TResponse> AsyncServerStreamingCall<TRequest, TResponse>(TRequest request, ClientInterceptorContext<TRequest, TResponse> context, AsyncServerStreamingCallContinuation<TRequest, TResponse> continuation)
+    {
+        return continuation(request, WithAuthorization(context));
+    }
+
+    public override AsyncClientStreamingCall<TRequest, TResponse> AsyncClientStreamingCall<TRequest, TResponse>(ClientInterceptorContext<TRequest, TResponse> context, AsyncClientStreamingCallContinuation<TRequest, TResponse> continuation)
+    {
+        return continuation(WithAuthorization(context));
+    }
+
+    public override AsyncDuplexStreamingCall<TRequest, TResponse> AsyncDuplexStreamingCall<TRequest, TResponse>(ClientInterceptorContext<TRequest, TResponse> context, AsyncDuplexStreamingCallContinuation<TRequest, TResponse> continuation)
+    {
+        return continuation(WithAuthorization(context));
+    }
+
+    private ClientInterceptorContext<TRequest, TResponse> WithAuthorization<TRequest, TResponse>(ClientInterceptorContext<TRequest, TResponse> context)
+        where TRequest : class
+        where TResponse : class
+    {
+        var authorization = CallerAuthorization();
+        if (string.IsNullOrEmpty(authorization))
+        {
+            return context;
+        }
+
+        var headers = context.Options.Headers ?? new Metadata();
+        headers.Add("authorization", authorization);
+        return new ClientInterceptorContext<TRequest, TResponse>(context.Method, context.Host, context.Options.WithHeaders(headers));
+    }
+
+    private string? CallerAuthorization()
+    {
+        var request = _httpContextAccessor.HttpContext?.Request;
+        if (request == null)
+        {
+            return null;
+        }
+
+        var header = request.Headers.Authorization.ToString();
+        if (!string.IsNullOrEmpty(header))
+        {
+            return header;
+        }
+
+        var token = request.Query[AccessTokenQueryKey].ToString();
+        return string.IsNullOrEmpty(token) ? null : $"Bearer {token}";
+    }
+}
@@ -1,4 +1,5 @@
-﻿using Grpc.Net.Client;
+﻿using Grpc.Core.Interceptors;
+using Grpc.Net.Client;
 using TourService.Protos;
 
 namespace Gateway.GrpcClients;
@@ -8,10 +9,10 @@ public class TourGrpcClient
     private readonly GrpcChannel _channel;
     private readonly TourService.Protos.TourService.TourServiceClient _client;
 
-    public TourGrpcClient(string serviceUrl)
+    public TourGrpcClient(string serviceUrl, AuthorizationForwardingInterceptor authorization)
     {
         _channel = GrpcChannel.ForAddress(serviceUrl);
-        _client = new TourService.Protos.TourService.TourServiceClient(_channel);
+        _client = new TourService.Protos.TourService.TourServiceClient(_channel.Intercept(authorization));
     }
 
     public TourService.Protos.TourService.TourServiceClient Client => _client;
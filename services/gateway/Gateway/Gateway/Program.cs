@@ -13,8 +13,12 @@ var serviceSettings = builder.Configuration.GetSection("ServiceSettings").Get<Se
     ?? throw new Exception("ServiceSettings not found in configuration");
 
 // Register gRPC clients as singletons
+builder.Services.AddHttpContextAccessor();
+builder.Services.AddSingleton<AuthorizationForwardingInterceptor>();
 builder.Services.AddSingleton(new AuthGrpcClient(serviceSettings.AuthServiceUrl));
-builder.Services.AddSingleton(new TourGrpcClient(serviceSettings.TourServiceUrl));
+builder.Services.AddSingleton(sp => new TourGrpcClient(
+    serviceSettings.TourServiceUrl,
+    sp.GetRequiredService<AuthorizationForwardingInterceptor>()));
 builder.Services.AddSingleton(new BlogGrpcClient(serviceSettings.BlogServiceUrl));
 
 // Configure CORS (for frontend)
@@ -24,7 +28,8 @@ builder.Services.AddCors(options =>
     {
         policy.AllowAnyOrigin()
               .AllowAnyMethod()
-              .AllowAnyHeader();
+              .AllowAnyHeader()
+              .WithExposedHeaders("Content-Disposition"); // file names of tour exports
     });
 });
 
@@ -39,6 +44,8 @@ var app = builder.Build();
 
 app.UseCors("AllowAll");
 
+app.UseWebSockets();
+
 app.UseAuthorization();
 
 app.MapControllers();
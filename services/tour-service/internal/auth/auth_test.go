@@ -0,0 +1,239 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	authpb "tour-service/proto/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ── Helpers ───────────────────────────────────────────────────────────────────
+
+type fakeValidator struct {
+	principals map[string]*Principal
+	err        error
+	calls      int
+}
+
+func (f *fakeValidator) ValidateToken(ctx context.Context, token string) (*Principal, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	p, ok := f.principals[token]
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	return p, nil
+}
+
+func newFakeValidator() *fakeValidator {
+	return &fakeValidator{principals: map[string]*Principal{
+		"good-token": {UserID: "guide123", Username: "marko", Role: "guide"},
+	}}
+}
+
+func contextWithAuthorization(value string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", value))
+}
+
+func runUnary(t *testing.T, i *Interceptor, ctx context.Context) (*Principal, error) {
+	t.Helper()
+	var seen *Principal
+	_, err := i.Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/tour.TourService/CreateTour"},
+		func(ctx context.Context, req any) (any, error) {
+			seen, _ = FromContext(ctx)
+			return nil, nil
+		})
+	return seen, err
+}
+
+// runChain runs a call through the Interceptor and an Authorizer for policy
+// in the order main.go installs them.
+func runChain(t *testing.T, policy Policy, method string, ctx context.Context) error {
+	t.Helper()
+	authenticate := NewInterceptor(newFakeValidator()).Unary()
+	authorize := NewAuthorizer(policy).Unary()
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	_, err := authenticate(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+		return authorize(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+			return nil, nil
+		})
+	})
+	return err
+}
+
+// ── Interceptor ───────────────────────────────────────────────────────────────
+
+func TestInterceptor_ValidToken_InjectsPrincipal(t *testing.T) {
+	interceptor := NewInterceptor(newFakeValidator())
+
+	principal, err := runUnary(t, interceptor, contextWithAuthorization("Bearer good-token"))
+
+	require.NoError(t, err)
+	require.NotNil(t, principal)
+	assert.Equal(t, "guide123", principal.UserID)
+	assert.Equal(t, "guide", principal.Role)
+}
+
+func TestInterceptor_InvalidToken_ReturnsUnauthenticated(t *testing.T) {
+	interceptor := NewInterceptor(newFakeValidator())
+
+	_, err := runUnary(t, interceptor, contextWithAuthorization("Bearer forged"))
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestInterceptor_MalformedHeader_ReturnsUnauthenticated(t *testing.T) {
+	interceptor := NewInterceptor(newFakeValidator())
+
+	_, err := runUnary(t, interceptor, contextWithAuthorization("good-token"))
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestInterceptor_MissingToken_PassesThroughAnonymously(t *testing.T) {
+	interceptor := NewInterceptor(newFakeValidator())
+
+	principal, err := runUnary(t, interceptor, context.Background())
+
+	assert.NoError(t, err)
+	assert.Nil(t, principal)
+}
+
+func TestInterceptor_MissingToken_PublicMethod_ReachesHandler(t *testing.T) {
+	policy := Policy{"/tour.TourService/GetTours": Public}
+
+	err := runChain(t, policy, "/tour.TourService/GetTours", context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestInterceptor_MissingToken_RestrictedMethod_ReturnsUnauthenticated(t *testing.T) {
+	policy := Policy{"/tour.TourService/CreateTour": Require(RoleGuide)}
+
+	err := runChain(t, policy, "/tour.TourService/CreateTour", context.Background())
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestInterceptor_AuthServiceDown_ReturnsUnavailable(t *testing.T) {
+	validator := newFakeValidator()
+	validator.err = errors.New("connection refused")
+	interceptor := NewInterceptor(validator)
+
+	_, err := runUnary(t, interceptor, contextWithAuthorization("Bearer good-token"))
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+// ── CachingValidator ──────────────────────────────────────────────────────────
+
+func TestCachingValidator_ReusesResultWithinTTL(t *testing.T) {
+	next := newFakeValidator()
+	cache := NewCachingValidator(next, time.Minute)
+
+	_, err := cache.ValidateToken(context.Background(), "good-token")
+	require.NoError(t, err)
+	_, err = cache.ValidateToken(context.Background(), "good-token")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, next.calls)
+}
+
+func TestCachingValidator_RevalidatesAfterTTL(t *testing.T) {
+	next := newFakeValidator()
+	cache := NewCachingValidator(next, time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	_, _ = cache.ValidateToken(context.Background(), "good-token")
+	now = now.Add(2 * time.Minute)
+	_, _ = cache.ValidateToken(context.Background(), "good-token")
+
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestCachingValidator_EvictExpired_DropsOnlyStaleEntries(t *testing.T) {
+	next := newFakeValidator()
+	next.principals["other-token"] = &Principal{UserID: "tourist123", Role: RoleTourist}
+	cache := NewCachingValidator(next, time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	_, _ = cache.ValidateToken(context.Background(), "good-token")
+	now = now.Add(40 * time.Second)
+	_, _ = cache.ValidateToken(context.Background(), "other-token")
+	now = now.Add(40 * time.Second)
+	cache.evictExpired()
+
+	assert.NotContains(t, cache.entries, "good-token")
+	assert.Contains(t, cache.entries, "other-token")
+}
+
+func TestCachingValidator_DoesNotCacheRejections(t *testing.T) {
+	next := newFakeValidator()
+	cache := NewCachingValidator(next, time.Minute)
+
+	_, err1 := cache.ValidateToken(context.Background(), "forged")
+	_, err2 := cache.ValidateToken(context.Background(), "forged")
+
+	assert.ErrorIs(t, err1, ErrInvalidToken)
+	assert.ErrorIs(t, err2, ErrInvalidToken)
+	assert.Equal(t, 2, next.calls)
+}
+
+// ── GrpcTokenValidator ────────────────────────────────────────────────────────
+
+type fakeAuthClient struct {
+	authpb.AuthServiceClient
+	user *authpb.UserProfile
+}
+
+func (f *fakeAuthClient) ValidateToken(ctx context.Context, in *authpb.ValidateTokenRequest, opts ...grpc.CallOption) (*authpb.ValidateTokenResponse, error) {
+	if in.Token != "good-token" {
+		return &authpb.ValidateTokenResponse{Valid: false}, nil
+	}
+	return &authpb.ValidateTokenResponse{Valid: true, UserId: f.user.UserId, Role: "tourist"}, nil
+}
+
+func (f *fakeAuthClient) GetUserById(ctx context.Context, in *authpb.GetUserByIdRequest, opts ...grpc.CallOption) (*authpb.UserResponse, error) {
+	return &authpb.UserResponse{Success: true, User: f.user}, nil
+}
+
+func TestGrpcTokenValidator_UsesRoleFromUserRecord(t *testing.T) {
+	client := &fakeAuthClient{user: &authpb.UserProfile{UserId: "u1", Username: "marko", Role: RoleGuide}}
+	validator := NewGrpcTokenValidator(client)
+
+	principal, err := validator.ValidateToken(context.Background(), "good-token")
+
+	require.NoError(t, err)
+	assert.Equal(t, RoleGuide, principal.Role)
+}
+
+func TestGrpcTokenValidator_BlockedUser_ReturnsErrUserBlocked(t *testing.T) {
+	client := &fakeAuthClient{user: &authpb.UserProfile{UserId: "u1", Role: RoleGuide, IsBlocked: true}}
+	validator := NewGrpcTokenValidator(client)
+
+	_, err := validator.ValidateToken(context.Background(), "good-token")
+
+	assert.ErrorIs(t, err, ErrUserBlocked)
+}
+
+func TestGrpcTokenValidator_InvalidToken_ReturnsErrInvalidToken(t *testing.T) {
+	client := &fakeAuthClient{user: &authpb.UserProfile{UserId: "u1"}}
+	validator := NewGrpcTokenValidator(client)
+
+	_, err := validator.ValidateToken(context.Background(), "forged")
+
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const authorizationHeader = "authorization"
+
+// Interceptor authenticates incoming RPCs from the bearer token in the
+// "authorization" metadata entry and stores the resulting Principal in the
+// request context. Calls without a token pass through anonymously; the
+// Authorizer decides which methods they may reach.
+type Interceptor struct {
+	validator TokenValidator
+}
+
+func NewInterceptor(validator TokenValidator) *Interceptor {
+	return &Interceptor{validator: validator}
+}
+
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := i.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (i *Interceptor) authenticate(ctx context.Context) (context.Context, error) {
+	token, err := bearerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return ctx, nil
+	}
+
+	principal, err := i.validator.ValidateToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, ErrInvalidToken) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if errors.Is(err, ErrUserBlocked) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		log.Printf("Error validating token: %v", err)
+		return nil, status.Error(codes.Unavailable, "failed to validate token")
+	}
+	return NewContext(ctx, principal), nil
+}
+
+// bearerToken extracts the token from the authorization metadata. It returns
+// an empty token when the header is absent.
+func bearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, "bearer") || strings.TrimSpace(token) == "" {
+		return "", status.Error(codes.Unauthenticated, "malformed authorization header")
+	}
+	return strings.TrimSpace(token), nil
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
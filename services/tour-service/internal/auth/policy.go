@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Roles as stored on auth service user records.
+const (
+	RoleAdmin   = "admin"
+	RoleGuide   = "guide"
+	RoleTourist = "tourist"
+)
+
+// Rule lists the roles allowed to call an RPC. An empty rule marks the RPC
+// as public. Admins are allowed everywhere.
+type Rule struct {
+	Roles []string
+}
+
+// Public is the rule for RPCs any caller may invoke.
+var Public = Rule{}
+
+// Require builds a rule admitting the given roles.
+func Require(roles ...string) Rule {
+	return Rule{Roles: roles}
+}
+
+func (r Rule) allows(role string) bool {
+	return len(r.Roles) == 0 || role == RoleAdmin || slices.Contains(r.Roles, role)
+}
+
+// Policy maps full gRPC method names to their authorization rule. Methods
+// missing from the policy are denied.
+type Policy map[string]Rule
+
+// Authorizer enforces a Policy against the principal injected by Interceptor.
+// Calls without a principal may only reach Public methods.
+type Authorizer struct {
+	policy Policy
+}
+
+func NewAuthorizer(policy Policy) *Authorizer {
+	return &Authorizer{policy: policy}
+}
+
+func (a *Authorizer) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := a.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a *Authorizer) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (a *Authorizer) authorize(ctx context.Context, method string) error {
+	rule, ok := a.policy[method]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "no authorization rule for %s", method)
+	}
+	if len(rule.Roles) == 0 {
+		return nil
+	}
+
+	principal, ok := FromContext(ctx)
+	if !ok {
+		return status.Errorf(codes.Unauthenticated, "%s requires an authenticated user", method)
+	}
+	if !rule.allows(principal.Role) {
+		return status.Errorf(codes.PermissionDenied, "role %q may not call %s", principal.Role, method)
+	}
+	return nil
+}
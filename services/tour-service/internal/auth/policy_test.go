@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func authorize(policy Policy, ctx context.Context, method string) error {
+	_, err := NewAuthorizer(policy).Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req any) (any, error) { return nil, nil })
+	return err
+}
+
+func TestAuthorizer_AllowsListedRole(t *testing.T) {
+	policy := Policy{"/svc/Create": Require(RoleGuide)}
+	ctx := NewContext(context.Background(), &Principal{UserID: "u1", Role: RoleGuide})
+
+	assert.NoError(t, authorize(policy, ctx, "/svc/Create"))
+}
+
+func TestAuthorizer_DeniesOtherRole(t *testing.T) {
+	policy := Policy{"/svc/Create": Require(RoleGuide)}
+	ctx := NewContext(context.Background(), &Principal{UserID: "u1", Role: RoleTourist})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(authorize(policy, ctx, "/svc/Create")))
+}
+
+func TestAuthorizer_AdminAllowedEverywhere(t *testing.T) {
+	policy := Policy{"/svc/Create": Require(RoleGuide)}
+	ctx := NewContext(context.Background(), &Principal{UserID: "a1", Role: RoleAdmin})
+
+	assert.NoError(t, authorize(policy, ctx, "/svc/Create"))
+}
+
+func TestAuthorizer_PublicRuleAllowsAnyRole(t *testing.T) {
+	policy := Policy{"/svc/List": Public}
+	ctx := NewContext(context.Background(), &Principal{UserID: "u1", Role: "unknown"})
+
+	assert.NoError(t, authorize(policy, ctx, "/svc/List"))
+}
+
+func TestAuthorizer_UnlistedMethodDenied(t *testing.T) {
+	ctx := NewContext(context.Background(), &Principal{UserID: "u1", Role: RoleGuide})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(authorize(Policy{}, ctx, "/svc/Secret")))
+}
+
+func TestAuthorizer_NoPrincipalDeniedRestrictedMethod(t *testing.T) {
+	policy := Policy{"/svc/Create": Require(RoleGuide)}
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(authorize(policy, context.Background(), "/svc/Create")))
+}
+
+func TestAuthorizer_NoPrincipalAllowedPublicMethod(t *testing.T) {
+	policy := Policy{"/svc/List": Public}
+
+	assert.NoError(t, authorize(policy, context.Background(), "/svc/List"))
+}
+
+func TestAuthorizer_NoPrincipalUnlistedMethodDenied(t *testing.T) {
+	assert.Equal(t, codes.PermissionDenied, status.Code(authorize(Policy{}, context.Background(), "/svc/Secret")))
+}
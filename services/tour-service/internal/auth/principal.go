@@ -0,0 +1,23 @@
+package auth
+
+import "context"
+
+// Principal is the caller identity established by the auth service.
+type Principal struct {
+	UserID   string
+	Username string
+	Role     string
+}
+
+type principalKey struct{}
+
+// NewContext returns a copy of ctx carrying the authenticated principal.
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the authenticated principal stored in ctx, if any.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
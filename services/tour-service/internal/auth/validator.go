@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	authpb "tour-service/proto/auth"
+)
+
+var (
+	// ErrInvalidToken is returned when the auth service rejects a token.
+	ErrInvalidToken = errors.New("invalid or expired token")
+	// ErrUserBlocked is returned when the token is valid but its user has been blocked.
+	ErrUserBlocked = errors.New("user is blocked")
+)
+
+// TokenValidator resolves a bearer token to the principal it was issued for.
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, token string) (*Principal, error)
+}
+
+// GrpcTokenValidator validates tokens through the auth service ValidateToken
+// RPC and then loads the user record, so the role reflects the stored user
+// rather than whatever was baked into the token when it was issued.
+type GrpcTokenValidator struct {
+	client authpb.AuthServiceClient
+}
+
+func NewGrpcTokenValidator(client authpb.AuthServiceClient) *GrpcTokenValidator {
+	return &GrpcTokenValidator{client: client}
+}
+
+func (v *GrpcTokenValidator) ValidateToken(ctx context.Context, token string) (*Principal, error) {
+	resp, err := v.client.ValidateToken(ctx, &authpb.ValidateTokenRequest{Token: token})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	userResp, err := v.client.GetUserById(ctx, &authpb.GetUserByIdRequest{UserId: resp.UserId})
+	if err != nil {
+		return nil, err
+	}
+	if !userResp.Success || userResp.User == nil {
+		return nil, ErrInvalidToken
+	}
+	if userResp.User.IsBlocked {
+		return nil, ErrUserBlocked
+	}
+
+	return &Principal{
+		UserID:   resp.UserId,
+		Username: userResp.User.Username,
+		Role:     userResp.User.Role,
+	}, nil
+}
+
+type cacheEntry struct {
+	principal *Principal
+	expiresAt time.Time
+}
+
+// CachingValidator remembers successful validations for a short TTL so that
+// every RPC does not cost a round trip to the auth service. Rejected tokens
+// are never cached. Expired entries are dropped when they are looked up and
+// by Run, which clears out tokens that are never presented again.
+type CachingValidator struct {
+	next TokenValidator
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func NewCachingValidator(next TokenValidator, ttl time.Duration) *CachingValidator {
+	return &CachingValidator{
+		next:    next,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func (c *CachingValidator) ValidateToken(ctx context.Context, token string) (*Principal, error) {
+	now := c.now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[token]
+	if ok && !now.Before(entry.expiresAt) {
+		delete(c.entries, token)
+		ok = false
+	}
+	c.mu.Unlock()
+	if ok {
+		return entry.principal, nil
+	}
+
+	principal, err := c.next.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if c.ttl <= 0 {
+		return principal, nil
+	}
+
+	c.mu.Lock()
+	c.entries[token] = cacheEntry{principal: principal, expiresAt: now.Add(c.ttl)}
+	c.mu.Unlock()
+	return principal, nil
+}
+
+// Run evicts expired entries every TTL until ctx is done.
+func (c *CachingValidator) Run(ctx context.Context) {
+	if c.ttl <= 0 {
+		return
+	}
+	ticker := time.NewTicker(c.ttl)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.evictExpired()
+		}
+	}
+}
+
+// evictExpired drops every entry whose TTL has passed.
+func (c *CachingValidator) evictExpired() {
+	now := c.now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for token, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, token)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"time"
 )
 
 type Config struct {
@@ -9,6 +11,24 @@ type Config struct {
 	DatabaseName   string
 	ServerPort     string
 	AuthServiceURL string
+
+	// AuthCacheTTL is how long a validated token is trusted before asking
+	// the auth service again.
+	AuthCacheTTL time.Duration
+
+	// LegacyErrorEnvelope keeps handlers reporting failures only through the
+	// Success/Message response fields instead of gRPC status codes.
+	LegacyErrorEnvelope bool
+
+	// PositionHistoryRetention is how long the positions recorded during
+	// executions are kept. Zero keeps them forever.
+	PositionHistoryRetention time.Duration
+
+	// ExecutionInactivityTimeout is how long an active execution may go
+	// without activity before it is abandoned; zero never abandons one.
+	// ExecutionSweepInterval is how often executions are checked.
+	ExecutionInactivityTimeout time.Duration
+	ExecutionSweepInterval     time.Duration
 }
 
 func LoadConfig() *Config {
@@ -17,6 +37,15 @@ func LoadConfig() *Config {
 		DatabaseName:   getEnv("MONGO_DATABASE", "tour-db"),
 		ServerPort:     getEnv("SERVER_PORT", "5003"),
 		AuthServiceURL: getEnv("AUTH_SERVICE_URL", "localhost:5001"),
+
+		AuthCacheTTL: getEnvDuration("AUTH_CACHE_TTL", 30*time.Second),
+
+		LegacyErrorEnvelope: getEnvBool("LEGACY_ERROR_ENVELOPE", false),
+
+		PositionHistoryRetention: getEnvDuration("POSITION_HISTORY_RETENTION", 30*24*time.Hour),
+
+		ExecutionInactivityTimeout: getEnvDuration("EXECUTION_INACTIVITY_TIMEOUT", 6*time.Hour),
+		ExecutionSweepInterval:     getEnvDuration("EXECUTION_SWEEP_INTERVAL", 5*time.Minute),
 	}
 }
 
@@ -27,3 +56,19 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
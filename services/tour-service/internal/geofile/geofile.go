@@ -0,0 +1,135 @@
+// Package geofile reads and writes the waypoints of GPX, KML and GeoJSON
+// files, the formats mapping and navigation apps exchange.
+package geofile
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Format is a supported file format.
+type Format string
+
+const (
+	GPX     Format = "gpx"
+	KML     Format = "kml"
+	GeoJSON Format = "geojson"
+)
+
+// Formats lists every supported format.
+var Formats = []Format{GPX, KML, GeoJSON}
+
+// ContentType returns the media type of files in the format.
+func (f Format) ContentType() string {
+	switch f {
+	case GPX:
+		return "application/gpx+xml"
+	case KML:
+		return "application/vnd.google-earth.kml+xml"
+	case GeoJSON:
+		return "application/geo+json"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// creator identifies this service in the files it writes.
+const creator = "tour-service"
+
+// ErrUnsupportedFormat is returned by Parse and Encode for a format not in
+// Formats.
+var ErrUnsupportedFormat = errors.New("unsupported format")
+
+// Feature is one waypoint of a file: a GPX wpt, a KML Placemark or a
+// GeoJSON Feature. Problem is set when the feature has no usable point;
+// its coordinates are then meaningless.
+type Feature struct {
+	Name        string
+	Description string
+	Latitude    float64
+	Longitude   float64
+	Problem     string
+}
+
+// Document is a named, ordered list of features to write.
+type Document struct {
+	Name        string
+	Description string
+	Features    []Feature
+}
+
+// Parse returns the features of data in file order. Features that are not
+// points are returned with a Problem rather than dropped, so callers can
+// report on every feature. An error means the file itself is unreadable.
+func Parse(format Format, data []byte) ([]Feature, error) {
+	switch format {
+	case GPX:
+		return parseGPX(data)
+	case KML:
+		return parseKML(data)
+	case GeoJSON:
+		return parseGeoJSON(data)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+// Encode writes doc in format. Features are written in order; their
+// Problem is ignored.
+func Encode(format Format, doc Document) ([]byte, error) {
+	switch format {
+	case GPX:
+		return encodeGPX(doc)
+	case KML:
+		return encodeKML(doc)
+	case GeoJSON:
+		return encodeGeoJSON(doc)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+// pointFeature builds a feature from textual coordinates, recording a
+// problem when they are not finite numbers.
+func pointFeature(name, description, latitude, longitude string) Feature {
+	feature := Feature{Name: strings.TrimSpace(name), Description: strings.TrimSpace(description)}
+	lat, latErr := parseCoordinate(latitude)
+	lng, lngErr := parseCoordinate(longitude)
+	switch {
+	case latErr != nil:
+		feature.Problem = "latitude is not a number"
+	case lngErr != nil:
+		feature.Problem = "longitude is not a number"
+	default:
+		feature.Latitude, feature.Longitude = lat, lng
+	}
+	return feature
+}
+
+func parseCoordinate(value string) (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, errors.New("coordinate is not finite")
+	}
+	return f, nil
+}
+
+func formatCoordinate(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// marshalXML writes v as an indented XML document with a declaration.
+func marshalXML(v any) ([]byte, error) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
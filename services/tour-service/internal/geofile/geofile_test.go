@@ -0,0 +1,166 @@
+package geofile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ── GPX ───────────────────────────────────────────────────────────────────────
+
+func TestParse_GPX_ReadsWaypoints(t *testing.T) {
+	data := []byte(`<?xml version="1.0"?>
+<gpx version="1.1" xmlns="http://www.topografix.com/GPX/1/1">
+  <wpt lat="44.8231" lon="20.4504"><name> Kalemegdan </name><desc>Fortress park</desc></wpt>
+  <wpt lat="44.8176" lon="20.4569"><name>Republic Square</name><cmt>Meeting point</cmt></wpt>
+  <wpt lat="north" lon="20.46"><name>Broken</name></wpt>
+  <trk><trkseg><trkpt lat="44.81" lon="20.45"/></trkseg></trk>
+</gpx>`)
+
+	features, err := Parse(GPX, data)
+
+	require.NoError(t, err)
+	require.Len(t, features, 3)
+	assert.Equal(t, Feature{Name: "Kalemegdan", Description: "Fortress park", Latitude: 44.8231, Longitude: 20.4504}, features[0])
+	assert.Equal(t, "Meeting point", features[1].Description)
+	assert.Equal(t, "latitude is not a number", features[2].Problem)
+}
+
+func TestParse_GPX_NotGPX_ReturnsError(t *testing.T) {
+	_, err := Parse(GPX, []byte(`<kml></kml>`))
+	assert.Error(t, err)
+
+	_, err = Parse(GPX, []byte(`<gpx><wpt`))
+	assert.Error(t, err)
+}
+
+// ── KML ───────────────────────────────────────────────────────────────────────
+
+func TestParse_KML_ReadsNestedPointPlacemarks(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+  <Document>
+    <Folder>
+      <Placemark>
+        <name>Kalemegdan</name>
+        <description><![CDATA[Fortress <b>park</b>]]></description>
+        <Point><coordinates> 20.4504,44.8231,117 </coordinates></Point>
+      </Placemark>
+    </Folder>
+    <Placemark>
+      <name>Walk</name>
+      <LineString><coordinates>20.45,44.82 20.46,44.81</coordinates></LineString>
+    </Placemark>
+    <Placemark>
+      <name>Odd</name>
+      <Point><coordinates>20.45</coordinates></Point>
+    </Placemark>
+  </Document>
+</kml>`)
+
+	features, err := Parse(KML, data)
+
+	require.NoError(t, err)
+	require.Len(t, features, 3)
+	assert.Equal(t, Feature{Name: "Kalemegdan", Description: "Fortress <b>park</b>", Latitude: 44.8231, Longitude: 20.4504}, features[0])
+	assert.Equal(t, "Walk", features[1].Name)
+	assert.Equal(t, "only Point placemarks can be imported", features[1].Problem)
+	assert.NotEmpty(t, features[2].Problem)
+}
+
+func TestParse_KML_NotKML_ReturnsError(t *testing.T) {
+	_, err := Parse(KML, []byte(`<gpx></gpx>`))
+	assert.Error(t, err)
+
+	_, err = Parse(KML, []byte(``))
+	assert.Error(t, err)
+}
+
+// ── GeoJSON ───────────────────────────────────────────────────────────────────
+
+func TestParse_GeoJSON_ReadsPointFeatures(t *testing.T) {
+	data := []byte(`{
+  "type": "FeatureCollection",
+  "features": [
+    {"type": "Feature", "geometry": {"type": "Point", "coordinates": [20.4504, 44.8231]},
+     "properties": {"name": "Kalemegdan", "description": "Fortress park"}},
+    {"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[20.45, 44.82], [20.46, 44.81]]},
+     "properties": {"name": "Walk"}},
+    {"type": "Feature", "geometry": {"type": "Point", "coordinates": [20.45]}, "properties": {"name": 7}}
+  ]
+}`)
+
+	features, err := Parse(GeoJSON, data)
+
+	require.NoError(t, err)
+	require.Len(t, features, 3)
+	assert.Equal(t, Feature{Name: "Kalemegdan", Description: "Fortress park", Latitude: 44.8231, Longitude: 20.4504}, features[0])
+	assert.Equal(t, "only Point features can be imported", features[1].Problem)
+	assert.Empty(t, features[2].Name)
+	assert.NotEmpty(t, features[2].Problem)
+}
+
+func TestParse_GeoJSON_SingleFeature(t *testing.T) {
+	data := []byte(`{"type": "Feature", "geometry": {"type": "Point", "coordinates": [20.4504, 44.8231, 117]}, "properties": null}`)
+
+	features, err := Parse(GeoJSON, data)
+
+	require.NoError(t, err)
+	require.Len(t, features, 1)
+	assert.Equal(t, 44.8231, features[0].Latitude)
+	assert.Empty(t, features[0].Problem)
+}
+
+func TestParse_GeoJSON_BareGeometry_ReturnsError(t *testing.T) {
+	_, err := Parse(GeoJSON, []byte(`{"type": "Point", "coordinates": [20.45, 44.82]}`))
+	assert.Error(t, err)
+}
+
+func TestParse_UnknownFormat_ReturnsErrUnsupportedFormat(t *testing.T) {
+	_, err := Parse("shp", []byte(`anything`))
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+// ── Encode ────────────────────────────────────────────────────────────────────
+
+func TestEncode_RoundTripsThroughParse(t *testing.T) {
+	doc := Document{
+		Name:        "Belgrade <walk> & more",
+		Description: "Old town",
+		Features: []Feature{
+			{Name: "Kalemegdan", Description: "Fortress <b>park</b>", Latitude: 44.8231, Longitude: 20.4504},
+			{Name: "Republic Square", Latitude: 44.8176, Longitude: 20.4569},
+		},
+	}
+
+	for _, format := range Formats {
+		t.Run(string(format), func(t *testing.T) {
+			data, err := Encode(format, doc)
+			require.NoError(t, err)
+
+			features, err := Parse(format, data)
+
+			require.NoError(t, err)
+			assert.Equal(t, doc.Features, features)
+		})
+	}
+}
+
+func TestEncode_GPX_AddsRouteThroughWaypoints(t *testing.T) {
+	data, err := Encode(GPX, Document{Name: "Walk", Features: []Feature{
+		{Name: "A", Latitude: 44.8231, Longitude: 20.4504},
+		{Name: "B", Latitude: 44.8176, Longitude: 20.4569},
+	}})
+
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `<gpx xmlns="http://www.topografix.com/GPX/1/1" version="1.1" creator="tour-service">`)
+	assert.Contains(t, string(data), `<rtept lat="44.8176" lon="20.4569">`)
+}
+
+func TestEncode_GeoJSON_EmptyDocumentKeepsFeatures(t *testing.T) {
+	data, err := Encode(GeoJSON, Document{Name: "Walk"})
+
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"features": []`)
+}
@@ -0,0 +1,104 @@
+package geofile
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type geoJSONObject struct {
+	Type       string           `json:"type"`
+	Features   []geoJSONObject  `json:"features,omitempty"`
+	Geometry   *geoJSONGeometry `json:"geometry,omitempty"`
+	Properties map[string]any   `json:"properties,omitempty"`
+}
+
+// geoJSONCollection is written instead of geoJSONObject so that an empty
+// collection keeps its required "features" member.
+type geoJSONCollection struct {
+	Type       string          `json:"type"`
+	Properties map[string]any  `json:"properties,omitempty"`
+	Features   []geoJSONObject `json:"features"`
+}
+
+type geoJSONGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// parseGeoJSON reads a FeatureCollection or a single Feature. Names and
+// descriptions come from the "name" and "description" properties.
+func parseGeoJSON(data []byte) ([]Feature, error) {
+	var object geoJSONObject
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON: %w", err)
+	}
+
+	switch object.Type {
+	case "FeatureCollection":
+		features := make([]Feature, len(object.Features))
+		for i, feature := range object.Features {
+			features[i] = geoJSONFeature(feature)
+		}
+		return features, nil
+	case "Feature":
+		return []Feature{geoJSONFeature(object)}, nil
+	default:
+		return nil, fmt.Errorf("invalid GeoJSON: expected a Feature or FeatureCollection, got %q", object.Type)
+	}
+}
+
+func geoJSONFeature(object geoJSONObject) Feature {
+	feature := Feature{
+		Name:        stringProperty(object.Properties, "name"),
+		Description: stringProperty(object.Properties, "description"),
+	}
+
+	switch {
+	case object.Type != "Feature":
+		feature.Problem = fmt.Sprintf("expected a Feature, got %q", object.Type)
+	case object.Geometry == nil || object.Geometry.Type != "Point":
+		feature.Problem = "only Point features can be imported"
+	default:
+		// GeoJSON positions are [longitude, latitude, altitude?].
+		var position []float64
+		if err := json.Unmarshal(object.Geometry.Coordinates, &position); err != nil || len(position) < 2 || len(position) > 3 {
+			feature.Problem = "point must have coordinates [longitude, latitude]"
+			break
+		}
+		feature.Longitude, feature.Latitude = position[0], position[1]
+	}
+	return feature
+}
+
+func stringProperty(properties map[string]any, key string) string {
+	value, _ := properties[key].(string)
+	return strings.TrimSpace(value)
+}
+
+// encodeGeoJSON writes a FeatureCollection of Point features carrying their
+// name, description and position in the tour as properties.
+func encodeGeoJSON(doc Document) ([]byte, error) {
+	features := make([]geoJSONObject, len(doc.Features))
+	for i, feature := range doc.Features {
+		coordinates, err := json.Marshal([]float64{feature.Longitude, feature.Latitude})
+		if err != nil {
+			return nil, err
+		}
+		features[i] = geoJSONObject{
+			Type:     "Feature",
+			Geometry: &geoJSONGeometry{Type: "Point", Coordinates: coordinates},
+			Properties: map[string]any{
+				"name":        feature.Name,
+				"description": feature.Description,
+				"order":       i + 1,
+			},
+		}
+	}
+
+	return json.MarshalIndent(geoJSONCollection{
+		Type:       "FeatureCollection",
+		Properties: map[string]any{"name": doc.Name, "description": doc.Description},
+		Features:   features,
+	}, "", "  ")
+}
@@ -0,0 +1,77 @@
+package geofile
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+)
+
+const gpxNamespace = "http://www.topografix.com/GPX/1/1"
+
+type gpxDocument struct {
+	XMLName   xml.Name      `xml:"gpx"`
+	Namespace string        `xml:"xmlns,attr,omitempty"`
+	Version   string        `xml:"version,attr,omitempty"`
+	Creator   string        `xml:"creator,attr,omitempty"`
+	Metadata  *gpxMetadata  `xml:"metadata"`
+	Waypoints []gpxWaypoint `xml:"wpt"`
+	Route     *gpxRoute     `xml:"rte"`
+}
+
+type gpxMetadata struct {
+	Name        string `xml:"name,omitempty"`
+	Description string `xml:"desc,omitempty"`
+}
+
+type gpxWaypoint struct {
+	Lat         string `xml:"lat,attr"`
+	Lon         string `xml:"lon,attr"`
+	Name        string `xml:"name,omitempty"`
+	Comment     string `xml:"cmt,omitempty"`
+	Description string `xml:"desc,omitempty"`
+}
+
+type gpxRoute struct {
+	Name   string        `xml:"name,omitempty"`
+	Points []gpxWaypoint `xml:"rtept"`
+}
+
+// parseGPX reads the waypoints of a GPX file. Routes and tracks are lines,
+// not places, and are ignored.
+func parseGPX(data []byte) ([]Feature, error) {
+	var doc gpxDocument
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid GPX: %w", err)
+	}
+
+	features := make([]Feature, len(doc.Waypoints))
+	for i, wpt := range doc.Waypoints {
+		description := wpt.Description
+		if description == "" {
+			description = wpt.Comment
+		}
+		features[i] = pointFeature(wpt.Name, description, wpt.Lat, wpt.Lon)
+	}
+	return features, nil
+}
+
+// encodeGPX writes every feature as a waypoint and, in the same order, as a
+// point of a route through all of them.
+func encodeGPX(doc Document) ([]byte, error) {
+	waypoints := make([]gpxWaypoint, len(doc.Features))
+	routePoints := make([]gpxWaypoint, len(doc.Features))
+	for i, feature := range doc.Features {
+		lat, lon := formatCoordinate(feature.Latitude), formatCoordinate(feature.Longitude)
+		waypoints[i] = gpxWaypoint{Lat: lat, Lon: lon, Name: feature.Name, Description: feature.Description}
+		routePoints[i] = gpxWaypoint{Lat: lat, Lon: lon, Name: feature.Name}
+	}
+
+	return marshalXML(gpxDocument{
+		Namespace: gpxNamespace,
+		Version:   "1.1",
+		Creator:   creator,
+		Metadata:  &gpxMetadata{Name: doc.Name, Description: doc.Description},
+		Waypoints: waypoints,
+		Route:     &gpxRoute{Name: doc.Name, Points: routePoints},
+	})
+}
@@ -0,0 +1,123 @@
+package geofile
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const kmlNamespace = "http://www.opengis.net/kml/2.2"
+
+type kmlDocument struct {
+	XMLName   xml.Name  `xml:"kml"`
+	Namespace string    `xml:"xmlns,attr,omitempty"`
+	Document  kmlFolder `xml:"Document"`
+}
+
+type kmlFolder struct {
+	Name        string         `xml:"name,omitempty"`
+	Description string         `xml:"description,omitempty"`
+	Placemarks  []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name        string    `xml:"name,omitempty"`
+	Description string    `xml:"description,omitempty"`
+	Point       *kmlPoint `xml:"Point"`
+}
+
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+// parseKML reads every Placemark of a KML file, however deeply it is nested
+// in Documents and Folders.
+func parseKML(data []byte) ([]Feature, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	features := []Feature{}
+	root := true
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid KML: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if root {
+			if start.Name.Local != "kml" {
+				return nil, fmt.Errorf("invalid KML: root element is <%s>, not <kml>", start.Name.Local)
+			}
+			root = false
+			continue
+		}
+		if start.Name.Local != "Placemark" {
+			continue
+		}
+
+		var placemark kmlPlacemark
+		if err := decoder.DecodeElement(&placemark, &start); err != nil {
+			return nil, fmt.Errorf("invalid KML: %w", err)
+		}
+		features = append(features, placemarkFeature(placemark))
+	}
+	if root {
+		return nil, errors.New("invalid KML: document is empty")
+	}
+	return features, nil
+}
+
+// placemarkFeature converts a placemark whose geometry is a single
+// "longitude,latitude[,altitude]" point.
+func placemarkFeature(placemark kmlPlacemark) Feature {
+	if placemark.Point == nil {
+		return Feature{
+			Name:    strings.TrimSpace(placemark.Name),
+			Problem: "only Point placemarks can be imported",
+		}
+	}
+
+	tuples := strings.Fields(placemark.Point.Coordinates)
+	var parts []string
+	if len(tuples) == 1 {
+		parts = strings.Split(tuples[0], ",")
+	}
+	if len(parts) < 2 || len(parts) > 3 {
+		return Feature{
+			Name:    strings.TrimSpace(placemark.Name),
+			Problem: "point must have coordinates longitude,latitude[,altitude]",
+		}
+	}
+	return pointFeature(placemark.Name, placemark.Description, parts[1], parts[0])
+}
+
+// encodeKML writes every feature as a Point placemark of one Document.
+func encodeKML(doc Document) ([]byte, error) {
+	placemarks := make([]kmlPlacemark, len(doc.Features))
+	for i, feature := range doc.Features {
+		placemarks[i] = kmlPlacemark{
+			Name:        feature.Name,
+			Description: feature.Description,
+			Point: &kmlPoint{
+				Coordinates: formatCoordinate(feature.Longitude) + "," + formatCoordinate(feature.Latitude),
+			},
+		}
+	}
+
+	return marshalXML(kmlDocument{
+		Namespace: kmlNamespace,
+		Document: kmlFolder{
+			Name:        doc.Name,
+			Description: doc.Description,
+			Placemarks:  placemarks,
+		},
+	})
+}
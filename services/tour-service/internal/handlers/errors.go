@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// fail decides whether a failure is surfaced as a gRPC status error or only
+// through the Success/Message envelope of the response. Handlers always
+// populate the envelope; in legacy mode the status error is dropped so
+// clients that still inspect Success keep working.
+func (h *TourServiceHandler) fail(err error) error {
+	if h.legacyErrorEnvelope {
+		return nil
+	}
+	return err
+}
+
+func newStatusError(code codes.Code, msg string, details ...protoadapt.MessageV1) error {
+	st := status.New(code, msg)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+func invalidArgument(field, description string) error {
+	return invalidArguments(description, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: description,
+	})
+}
+
+func invalidArguments(description string, violations ...*errdetails.BadRequest_FieldViolation) error {
+	return newStatusError(codes.InvalidArgument, description, &errdetails.BadRequest{
+		FieldViolations: violations,
+	})
+}
+
+func notFound(resourceType, resourceName string) error {
+	return newStatusError(codes.NotFound, fmt.Sprintf("%s %s not found", resourceType, resourceName), &errdetails.ResourceInfo{
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+	})
+}
+
+func permissionDenied(resourceType, resourceName, description string) error {
+	return newStatusError(codes.PermissionDenied, description, &errdetails.ResourceInfo{
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		Description:  description,
+	})
+}
+
+func failedPrecondition(violationType, subject, description string) error {
+	return failedPreconditions(description, &errdetails.PreconditionFailure_Violation{
+		Type:        violationType,
+		Subject:     subject,
+		Description: description,
+	})
+}
+
+func failedPreconditions(description string, violations ...*errdetails.PreconditionFailure_Violation) error {
+	return newStatusError(codes.FailedPrecondition, description, &errdetails.PreconditionFailure{
+		Violations: violations,
+	})
+}
+
+func aborted(description string) error {
+	return status.Error(codes.Aborted, description)
+}
+
+func alreadyExists(resourceType, resourceName, description string) error {
+	return newStatusError(codes.AlreadyExists, description, &errdetails.ResourceInfo{
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		Description:  description,
+	})
+}
+
+func internalError(description string) error {
+	return status.Error(codes.Internal, description)
+}
+
+// lookupError maps a repository lookup failure to NotFound when the document
+// is missing and to Internal for everything else.
+func lookupError(err error, resourceType, resourceName string) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return notFound(resourceType, resourceName)
+	}
+	return internalError(fmt.Sprintf("failed to load %s", resourceType))
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"slices"
+	"time"
+	"tour-service/internal/models"
+	pb "tour-service/proto"
+)
+
+// keyPointProgress counts the keypoints of the tour that execution has
+// completed. Completions of keypoints deleted since do not count.
+func keyPointProgress(execution *models.TourExecution, keypoints []*models.KeyPoint) (completed, total int) {
+	for _, kp := range keypoints {
+		if slices.ContainsFunc(execution.CompletedKeypoints, func(c models.CompletedKeypoint) bool {
+			return c.KeypointID == kp.ID
+		}) {
+			completed++
+		}
+	}
+	return completed, len(keypoints)
+}
+
+// completionPercentage returns the share of keypoints completed, counting a
+// tour without keypoints as fully walked.
+func completionPercentage(completed, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return 100 * float64(completed) / float64(total)
+}
+
+// completeExecution marks execution completed and records how much of the
+// tour it covered. The caller saves execution.
+func completeExecution(execution *models.TourExecution, keypoints []*models.KeyPoint) {
+	execution.Status = models.ExecutionStatusCompleted
+	execution.CompletedAt = time.Now()
+	execution.CompletionPercentage = completionPercentage(keyPointProgress(execution, keypoints))
+}
+
+// completionSummary describes a completed execution. The distance comes from
+// the position history and is left at 0 when that cannot be read.
+func (h *TourServiceHandler) completionSummary(ctx context.Context, execution *models.TourExecution, keypoints []*models.KeyPoint) *pb.CompletionSummary {
+	completed, total := keyPointProgress(execution, keypoints)
+
+	var distance float64
+	samples, err := h.repo.GetPositionSamples(ctx, execution.ID)
+	if err != nil {
+		log.Printf("Error getting position samples: %v", err)
+	} else {
+		distance, _ = measureTrack(samples)
+	}
+
+	return &pb.CompletionSummary{
+		CompletedKeyPoints:   int32(completed),
+		TotalKeyPoints:       int32(total),
+		CompletionPercentage: completionPercentage(completed, total),
+		DurationSeconds:      execution.CompletedAt.Sub(execution.StartedAt).Seconds(),
+		Distance:             distance,
+	}
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"tour-service/internal/models"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+var executionStatuses = []string{
+	models.ExecutionStatusActive,
+	models.ExecutionStatusCompleted,
+	models.ExecutionStatusAbandoned,
+}
+
+// ListExecutions returns a tourist's executions, most recently started
+// first, each with the name of its tour and how many of its keypoints were
+// reached.
+func (h *TourServiceHandler) ListExecutions(ctx context.Context, req *pb.ListExecutionsRequest) (*pb.ListExecutionsResponse, error) {
+	if err := checkPrincipal(ctx, "touristId", req.TouristId); err != nil {
+		return &pb.ListExecutionsResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
+	filter, page, violations := executionQueryFromProto(req)
+	if len(violations) > 0 {
+		return &pb.ListExecutionsResponse{
+			Success: false,
+			Message: "Invalid execution query",
+		}, h.fail(invalidArguments("invalid execution query", violations...))
+	}
+
+	result, err := h.repo.ListExecutions(ctx, filter, page)
+	if errors.Is(err, repository.ErrInvalidPageToken) {
+		return &pb.ListExecutionsResponse{
+			Success: false,
+			Message: "Invalid page token",
+		}, h.fail(invalidArgument("pageToken", "page token is malformed"))
+	}
+	if err != nil {
+		log.Printf("Error listing executions: %v", err)
+		return &pb.ListExecutionsResponse{
+			Success: false,
+			Message: "Failed to list executions",
+		}, h.fail(internalError("failed to list executions"))
+	}
+
+	entries := make([]*pb.ExecutionListEntry, len(result.Executions))
+	tours := make(map[primitive.ObjectID]*executionTour)
+	for i, execution := range result.Executions {
+		tour, ok := tours[execution.TourID]
+		if !ok {
+			tour, err = h.loadExecutionTour(ctx, execution.TourID)
+			if err != nil {
+				log.Printf("Error getting keypoints: %v", err)
+				return &pb.ListExecutionsResponse{
+					Success: false,
+					Message: "Failed to list executions",
+				}, h.fail(internalError("failed to get keypoints"))
+			}
+			tours[execution.TourID] = tour
+		}
+
+		completed, total := keyPointProgress(execution, tour.keypoints)
+		entries[i] = &pb.ExecutionListEntry{
+			Execution:          mapExecutionToProto(execution),
+			TourName:           tour.name,
+			CompletedKeyPoints: int32(completed),
+			TotalKeyPoints:     int32(total),
+		}
+	}
+
+	return &pb.ListExecutionsResponse{
+		Success:       true,
+		Message:       "Executions retrieved successfully",
+		Executions:    entries,
+		NextPageToken: result.NextPageToken,
+	}, nil
+}
+
+// executionTour is what a listing shows of the tour of an execution.
+type executionTour struct {
+	name      string
+	keypoints []*models.KeyPoint
+}
+
+// loadExecutionTour reads the tour an execution was on. A tour that is gone
+// has no name and no keypoints.
+func (h *TourServiceHandler) loadExecutionTour(ctx context.Context, tourID primitive.ObjectID) (*executionTour, error) {
+	keypoints, err := h.repo.GetKeyPointsByTourID(ctx, tourID)
+	if err != nil {
+		return nil, err
+	}
+
+	tour := &executionTour{keypoints: keypoints}
+	if t, err := h.repo.GetTourByID(ctx, tourID); err == nil {
+		tour.name = t.Name
+	}
+	return tour, nil
+}
+
+// executionQueryFromProto translates the filters and paging of a
+// ListExecutions request and returns every invalid field.
+func executionQueryFromProto(req *pb.ListExecutionsRequest) (repository.ExecutionFilter, repository.PageRequest, []*errdetails.BadRequest_FieldViolation) {
+	var violations []*errdetails.BadRequest_FieldViolation
+	violate := func(field, description string) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+	}
+
+	filter := repository.ExecutionFilter{
+		TouristID: req.TouristId,
+		Status:    req.Status,
+	}
+
+	if req.TouristId == "" {
+		violate("touristId", "tourist ID is required")
+	}
+	if req.Status != "" && !slices.Contains(executionStatuses, req.Status) {
+		violate("status", "status must be one of "+strings.Join(executionStatuses, ", "))
+	}
+	if req.TourId != "" {
+		tourID, err := primitive.ObjectIDFromHex(req.TourId)
+		if err != nil {
+			violate("tourId", "invalid tour ID")
+		}
+		filter.TourID = tourID
+	}
+	filter.StartedAfter = parseTimeFilter("startedAfter", req.StartedAfter, violate)
+	filter.StartedBefore = parseTimeFilter("startedBefore", req.StartedBefore, violate)
+	if !filter.StartedAfter.IsZero() && !filter.StartedBefore.IsZero() && !filter.StartedAfter.Before(filter.StartedBefore) {
+		violate("startedAfter", "must be before startedBefore")
+	}
+
+	if req.PageSize < 0 || req.PageSize > repository.MaxPageSize {
+		violate("pageSize", fmt.Sprintf("page size must be between 0 and %d", repository.MaxPageSize))
+	}
+	page := repository.PageRequest{
+		Size:  int(req.PageSize),
+		Token: req.PageToken,
+	}
+
+	return filter, page, violations
+}
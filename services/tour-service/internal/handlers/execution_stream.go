@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"time"
+	"tour-service/internal/models"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// defaultTrackStatusInterval is how often TrackExecution rereads an
+// execution while no samples arrive, so that a run completed or abandoned
+// from another client still ends the stream.
+const defaultTrackStatusInterval = 10 * time.Second
+
+// TrackExecution receives position samples for one execution and pushes a
+// KeyPointReached event whenever a sample completes a keypoint, by the same
+// rule as CheckProximity. Samples also update the tourist's position. Once
+// the execution is no longer active the stream ends with TourCompleted or
+// ExecutionExpired.
+//
+// Failures end the stream with a status error; there is no response
+// envelope to carry them in legacy mode.
+func (h *TourServiceHandler) TrackExecution(stream pb.TourService_TrackExecutionServer) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := checkPrincipal(ctx, "touristId", first.TouristId); err != nil {
+		return err
+	}
+	executionID, err := primitive.ObjectIDFromHex(first.ExecutionId)
+	if err != nil {
+		return invalidArgument("executionId", "invalid execution ID")
+	}
+	if err := checkTrackSample(first, first); err != nil {
+		return err
+	}
+
+	execution, err := h.repo.GetExecution(ctx, executionID)
+	if err != nil {
+		return lookupError(err, "execution", first.ExecutionId)
+	}
+	if !ownsResource(ctx, execution.TouristID, first.TouristId) {
+		return permissionDenied("execution", first.ExecutionId, "execution belongs to another tourist")
+	}
+
+	// Recv blocks, so it runs apart from the loop below, which does all the
+	// sending.
+	samples := make(chan *pb.TrackExecutionRequest)
+	received := make(chan error, 1)
+	go func() {
+		for {
+			sample, err := stream.Recv()
+			if err != nil {
+				received <- err
+				return
+			}
+			select {
+			case samples <- sample:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(h.trackStatusInterval)
+	defer ticker.Stop()
+
+	sample := first
+	for {
+		if sample != nil && execution.Status == models.ExecutionStatusActive {
+			if err := h.trackSample(ctx, stream, execution, sample); err != nil {
+				return err
+			}
+		}
+		// Reaching the last keypoint completes the execution, so this also
+		// runs right after a sample
+		if ended, err := h.endTracking(ctx, stream, execution); ended || err != nil {
+			return err
+		}
+
+		sample = nil
+		select {
+		case sample = <-samples:
+			if err := checkTrackSample(sample, first); err != nil {
+				return err
+			}
+		case <-ticker.C:
+		case err := <-received:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+
+		execution, err = h.repo.GetExecution(ctx, executionID)
+		if err != nil {
+			return lookupError(err, "execution", first.ExecutionId)
+		}
+	}
+}
+
+// checkTrackSample rejects a sample that names another execution or tourist
+// than the first one of the stream, or has coordinates out of range.
+func checkTrackSample(sample, first *pb.TrackExecutionRequest) error {
+	violations := coordinateViolations(sample.Latitude, sample.Longitude)
+	if sample.ExecutionId != "" && sample.ExecutionId != first.ExecutionId {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "executionId",
+			Description: "a stream tracks a single execution",
+		})
+	}
+	if sample.TouristId != "" && sample.TouristId != first.TouristId {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "touristId",
+			Description: "a stream tracks a single tourist",
+		})
+	}
+	if len(violations) > 0 {
+		return invalidArguments("invalid position sample", violations...)
+	}
+	return nil
+}
+
+// trackSample records the position of a sample and pushes KeyPointReached
+// when it completes a keypoint.
+func (h *TourServiceHandler) trackSample(ctx context.Context, stream pb.TourService_TrackExecutionServer, execution *models.TourExecution, sample *pb.TrackExecutionRequest) error {
+	position := &models.Position{
+		TouristID: execution.TouristID,
+		Latitude:  sample.Latitude,
+		Longitude: sample.Longitude,
+	}
+	if err := h.repo.UpsertPosition(ctx, position); err != nil {
+		log.Printf("Error updating position: %v", err)
+	}
+
+	keypoints, err := h.repo.GetKeyPointsByTourID(ctx, execution.TourID)
+	if err != nil {
+		log.Printf("Error getting keypoints: %v", err)
+		return internalError("failed to get keypoints")
+	}
+
+	reached, distance := reachKeyPoint(execution, keypoints, sample.Latitude, sample.Longitude)
+	next, nextDistance := nextKeyPoint(execution, keypoints, sample.Latitude, sample.Longitude)
+
+	// Saving also records the tourist's last activity
+	err = h.repo.UpdateExecution(ctx, execution)
+	if errors.Is(err, repository.ErrExecutionStatusChanged) {
+		return aborted("execution status changed concurrently")
+	}
+	if err != nil {
+		log.Printf("Error updating execution: %v", err)
+	}
+	if reached == nil {
+		return nil
+	}
+
+	return stream.Send(&pb.TrackExecutionEvent{
+		Event: &pb.TrackExecutionEvent_KeyPointReached{KeyPointReached: &pb.KeyPointReachedEvent{
+			KeyPoint:             mapKeyPointToProto(reached),
+			Distance:             distance,
+			CompletedCount:       int32(len(execution.CompletedKeypoints)),
+			TotalCount:           int32(len(keypoints)),
+			NextKeyPoint:         mapOptionalKeyPointToProto(next),
+			NextKeyPointDistance: nextDistance,
+		}},
+	})
+}
+
+// endTracking pushes the final event of the stream once the execution is no
+// longer active and reports whether it did.
+func (h *TourServiceHandler) endTracking(ctx context.Context, stream pb.TourService_TrackExecutionServer, execution *models.TourExecution) (bool, error) {
+	switch execution.Status {
+	case models.ExecutionStatusCompleted:
+		keypoints, err := h.repo.GetKeyPointsByTourID(ctx, execution.TourID)
+		if err != nil {
+			log.Printf("Error getting keypoints: %v", err)
+			return true, internalError("failed to get keypoints")
+		}
+		return true, stream.Send(&pb.TrackExecutionEvent{
+			Event: &pb.TrackExecutionEvent_TourCompleted{TourCompleted: &pb.TourCompletedEvent{
+				Execution: mapExecutionToProto(execution),
+				Summary:   h.completionSummary(ctx, execution, keypoints),
+			}},
+		})
+	case models.ExecutionStatusAbandoned:
+		return true, stream.Send(&pb.TrackExecutionEvent{
+			Event: &pb.TrackExecutionEvent_ExecutionExpired{ExecutionExpired: &pb.ExecutionExpiredEvent{
+				Execution: mapExecutionToProto(execution),
+				Reason:    "execution was abandoned",
+			}},
+		})
+	default:
+		return false, nil
+	}
+}
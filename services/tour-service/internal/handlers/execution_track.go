@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+	"tour-service/internal/models"
+	pb "tour-service/proto"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetExecutionTrack returns the path a tourist walked during an execution,
+// with the distance covered and the average speed along it.
+func (h *TourServiceHandler) GetExecutionTrack(ctx context.Context, req *pb.GetExecutionTrackRequest) (*pb.ExecutionTrackResponse, error) {
+	if err := checkPrincipal(ctx, "touristId", req.TouristId); err != nil {
+		return &pb.ExecutionTrackResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
+	executionID, err := primitive.ObjectIDFromHex(req.ExecutionId)
+	if err != nil {
+		return &pb.ExecutionTrackResponse{
+			Success: false,
+			Message: "Invalid execution ID",
+		}, h.fail(invalidArgument("executionId", "invalid execution ID"))
+	}
+
+	execution, err := h.repo.GetExecution(ctx, executionID)
+	if err != nil {
+		return &pb.ExecutionTrackResponse{
+			Success: false,
+			Message: "Execution not found",
+		}, h.fail(lookupError(err, "execution", req.ExecutionId))
+	}
+
+	if !ownsResource(ctx, execution.TouristID, req.TouristId) {
+		return &pb.ExecutionTrackResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(permissionDenied("execution", req.ExecutionId, "execution belongs to another tourist"))
+	}
+
+	samples, err := h.repo.GetPositionSamples(ctx, executionID)
+	if err != nil {
+		log.Printf("Error getting position samples: %v", err)
+		return &pb.ExecutionTrackResponse{
+			Success: false,
+			Message: "Failed to get execution track",
+		}, h.fail(internalError("failed to get position samples"))
+	}
+
+	distance, duration := measureTrack(samples)
+	var averageSpeed float64
+	if duration > 0 {
+		averageSpeed = distance / duration.Seconds()
+	}
+
+	points := make([]*pb.TrackPoint, len(samples))
+	for i, sample := range samples {
+		points[i] = &pb.TrackPoint{
+			Latitude:   sample.Latitude,
+			Longitude:  sample.Longitude,
+			RecordedAt: sample.RecordedAt.Format(time.RFC3339),
+		}
+	}
+
+	return &pb.ExecutionTrackResponse{
+		Success:         true,
+		Message:         "Execution track retrieved successfully",
+		Points:          points,
+		Distance:        distance,
+		DurationSeconds: duration.Seconds(),
+		AverageSpeed:    averageSpeed,
+	}, nil
+}
+
+// measureTrack returns the length in meters of the path through samples,
+// which are in time order, and the time it took.
+func measureTrack(samples []*models.PositionSample) (float64, time.Duration) {
+	if len(samples) < 2 {
+		return 0, 0
+	}
+
+	var distance float64
+	for i := 1; i < len(samples); i++ {
+		prev, cur := samples[i-1], samples[i]
+		distance += calculateDistance(prev.Latitude, prev.Longitude, cur.Latitude, cur.Longitude)
+	}
+	return distance, samples[len(samples)-1].RecordedAt.Sub(samples[0].RecordedAt)
+}
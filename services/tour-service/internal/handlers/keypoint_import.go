@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"tour-service/internal/geofile"
+	"tour-service/internal/models"
+	pb "tour-service/proto"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// Import limits. A file is parsed in memory, so its size is bounded before
+// anything is read.
+const (
+	maxImportBytes    = 1 << 20
+	maxImportFeatures = 500
+)
+
+// Import modes.
+const (
+	importAppend  = "append"
+	importReplace = "replace"
+)
+
+// ImportKeyPoints turns the waypoints of a GPX, KML or GeoJSON file into
+// keypoints of a tour, appended after the existing ones or replacing them.
+// Features that cannot be imported are reported back; the rest are imported
+// as long as at least one is usable.
+func (h *TourServiceHandler) ImportKeyPoints(ctx context.Context, req *pb.ImportKeyPointsRequest) (*pb.ImportKeyPointsResponse, error) {
+	if err := checkPrincipal(ctx, "guideId", req.GuideId); err != nil {
+		return &pb.ImportKeyPointsResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
+	tourID, err := primitive.ObjectIDFromHex(req.TourId)
+	if err != nil {
+		return &pb.ImportKeyPointsResponse{
+			Success: false,
+			Message: "Invalid tour ID",
+		}, h.fail(invalidArgument("tourId", "invalid tour ID"))
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	violate := func(field, description string) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+	}
+
+	format := geofile.Format(strings.ToLower(req.Format))
+	if !slices.Contains(geofile.Formats, format) {
+		violate("format", "format must be one of gpx, kml, geojson")
+	}
+	mode := req.Mode
+	if mode == "" {
+		mode = importAppend
+	}
+	if mode != importAppend && mode != importReplace {
+		violate("mode", "mode must be append or replace")
+	}
+	switch {
+	case len(req.Data) == 0:
+		violate("data", "file must not be empty")
+	case len(req.Data) > maxImportBytes:
+		violate("data", fmt.Sprintf("file must be at most %d bytes", maxImportBytes))
+	}
+	if len(violations) > 0 {
+		return &pb.ImportKeyPointsResponse{
+			Success: false,
+			Message: "Invalid import",
+		}, h.fail(invalidArguments("invalid import", violations...))
+	}
+
+	features, err := geofile.Parse(format, req.Data)
+	if err != nil {
+		return &pb.ImportKeyPointsResponse{
+			Success: false,
+			Message: "The file could not be read",
+		}, h.fail(invalidArgument("data", err.Error()))
+	}
+	switch {
+	case len(features) == 0:
+		return &pb.ImportKeyPointsResponse{
+			Success: false,
+			Message: "The file contains no waypoints",
+		}, h.fail(invalidArgument("data", "file contains no waypoints"))
+	case len(features) > maxImportFeatures:
+		return &pb.ImportKeyPointsResponse{
+			Success: false,
+			Message: "The file contains too many waypoints",
+		}, h.fail(invalidArgument("data", fmt.Sprintf("file must contain at most %d waypoints", maxImportFeatures)))
+	}
+
+	report, keypoints := importFeatures(features)
+	if len(keypoints) == 0 {
+		rejections := make([]*errdetails.BadRequest_FieldViolation, len(report))
+		for i, feature := range report {
+			rejections[i] = &errdetails.BadRequest_FieldViolation{
+				Field:       fmt.Sprintf("data.features[%d]", feature.Index),
+				Description: feature.Reason,
+			}
+		}
+		return &pb.ImportKeyPointsResponse{
+			Success:       false,
+			Message:       "None of the waypoints could be imported",
+			RejectedCount: int32(len(report)),
+			Features:      report,
+		}, h.fail(invalidArguments("no waypoint could be imported", rejections...))
+	}
+
+	tour, err := h.repo.GetTourByID(ctx, tourID)
+	if err != nil {
+		return &pb.ImportKeyPointsResponse{
+			Success: false,
+			Message: "Tour not found",
+		}, h.fail(lookupError(err, "tour", req.TourId))
+	}
+	if !ownsResource(ctx, tour.GuideID, req.GuideId) {
+		return &pb.ImportKeyPointsResponse{
+			Success: false,
+			Message: "Unauthorized: You don't own this tour",
+		}, h.fail(permissionDenied("tour", req.TourId, "tour is owned by another guide"))
+	}
+
+	err = h.repo.ImportKeyPoints(ctx, tourID, keypoints, mode == importReplace)
+	if err != nil {
+		log.Printf("Error importing keypoints: %v", err)
+		return &pb.ImportKeyPointsResponse{
+			Success: false,
+			Message: "Failed to import keypoints",
+		}, h.fail(internalError("failed to import keypoints"))
+	}
+
+	h.refreshTourMetrics(ctx, tour)
+
+	imported := 0
+	for _, feature := range report {
+		if feature.Imported {
+			feature.KeyPoint = mapKeyPointToProto(keypoints[imported])
+			imported++
+		}
+	}
+
+	return &pb.ImportKeyPointsResponse{
+		Success:       true,
+		Message:       fmt.Sprintf("Imported %d of %d waypoints", imported, len(report)),
+		ImportedCount: int32(imported),
+		RejectedCount: int32(len(report) - imported),
+		Features:      report,
+	}, nil
+}
+
+// importFeatures checks every feature of a file and returns the report on
+// each of them along with the keypoints of the usable ones, in file order.
+func importFeatures(features []geofile.Feature) ([]*pb.ImportedFeature, []*models.KeyPoint) {
+	report := make([]*pb.ImportedFeature, len(features))
+	var keypoints []*models.KeyPoint
+	for i, feature := range features {
+		report[i] = &pb.ImportedFeature{Index: int32(i), Name: feature.Name}
+
+		if feature.Problem != "" {
+			report[i].Reason = feature.Problem
+			continue
+		}
+		if violations := coordinateViolations(feature.Latitude, feature.Longitude); len(violations) > 0 {
+			reasons := make([]string, len(violations))
+			for j, violation := range violations {
+				reasons[j] = violation.Description
+			}
+			report[i].Reason = strings.Join(reasons, "; ")
+			continue
+		}
+
+		report[i].Imported = true
+		keypoints = append(keypoints, &models.KeyPoint{
+			Latitude:    feature.Latitude,
+			Longitude:   feature.Longitude,
+			Name:        feature.Name,
+			Description: feature.Description,
+		})
+	}
+	return report, keypoints
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"tour-service/internal/auth"
+	pb "tour-service/proto"
+)
+
+// Policy is the per-RPC role table enforced by auth.Authorizer. Every
+// TourService method must be listed; unlisted methods are denied.
+var Policy = auth.Policy{
+	// Tour CRUD
+	pb.TourService_CreateTour_FullMethodName:        auth.Require(auth.RoleGuide),
+	pb.TourService_GetTours_FullMethodName:          auth.Public,
+	pb.TourService_SearchToursNearby_FullMethodName: auth.Public,
+	pb.TourService_SearchTours_FullMethodName:       auth.Public,
+	pb.TourService_GetTourById_FullMethodName:       auth.Public,
+	pb.TourService_PublishTour_FullMethodName:       auth.Require(auth.RoleGuide),
+	pb.TourService_GetMyTours_FullMethodName:        auth.Require(auth.RoleGuide),
+	pb.TourService_UpdateTour_FullMethodName:        auth.Require(auth.RoleGuide),
+	pb.TourService_ArchiveTour_FullMethodName:       auth.Require(auth.RoleGuide),
+	pb.TourService_ReactivateTour_FullMethodName:    auth.Require(auth.RoleGuide),
+
+	// KeyPoint management
+	pb.TourService_AddKeyPoint_FullMethodName:      auth.Require(auth.RoleGuide),
+	pb.TourService_GetKeyPoints_FullMethodName:     auth.Public,
+	pb.TourService_UpdateKeyPoint_FullMethodName:   auth.Require(auth.RoleGuide),
+	pb.TourService_DeleteKeyPoint_FullMethodName:   auth.Require(auth.RoleGuide),
+	pb.TourService_ReorderKeyPoints_FullMethodName: auth.Require(auth.RoleGuide),
+	pb.TourService_ImportKeyPoints_FullMethodName:  auth.Require(auth.RoleGuide),
+	pb.TourService_ExportTour_FullMethodName:       auth.Public,
+
+	// Position simulator
+	pb.TourService_UpdatePosition_FullMethodName:     auth.Require(auth.RoleTourist),
+	pb.TourService_GetCurrentPosition_FullMethodName: auth.Require(auth.RoleTourist),
+
+	// Shopping cart
+	pb.TourService_AddToCart_FullMethodName:      auth.Require(auth.RoleTourist),
+	pb.TourService_RemoveFromCart_FullMethodName: auth.Require(auth.RoleTourist),
+	pb.TourService_GetCart_FullMethodName:        auth.Require(auth.RoleTourist),
+	pb.TourService_Checkout_FullMethodName:       auth.Require(auth.RoleTourist),
+
+	// Tour execution
+	pb.TourService_StartTourExecution_FullMethodName: auth.Require(auth.RoleTourist),
+	pb.TourService_CheckProximity_FullMethodName:     auth.Require(auth.RoleTourist),
+	pb.TourService_CompleteTour_FullMethodName:       auth.Require(auth.RoleTourist),
+	pb.TourService_AbandonTour_FullMethodName:        auth.Require(auth.RoleTourist),
+	pb.TourService_GetExecution_FullMethodName:       auth.Require(auth.RoleTourist),
+	pb.TourService_TrackExecution_FullMethodName:     auth.Require(auth.RoleTourist),
+	pb.TourService_GetExecutionTrack_FullMethodName:  auth.Require(auth.RoleTourist),
+	pb.TourService_ListExecutions_FullMethodName:     auth.Require(auth.RoleTourist),
+
+	// Reviews
+	pb.TourService_CreateReview_FullMethodName: auth.Require(auth.RoleTourist),
+	pb.TourService_GetReviews_FullMethodName:   auth.Public,
+	pb.TourService_UpdateReview_FullMethodName: auth.Require(auth.RoleTourist),
+}
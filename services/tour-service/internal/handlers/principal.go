@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"context"
+
+	"tour-service/internal/auth"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkPrincipal rejects a request whose body user ID differs from the
+// authenticated caller, and any request without a caller at all. Admins may
+// act on behalf of any user.
+func checkPrincipal(ctx context.Context, field, userID string) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, field+" requires an authenticated user")
+	}
+	if principal.UserID == userID || principal.Role == auth.RoleAdmin {
+		return nil
+	}
+	return newStatusError(codes.PermissionDenied, field+" does not match the authenticated user", &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: "must match the authenticated user"},
+		},
+	})
+}
+
+// ownsResource reports whether the caller may act on a resource owned by
+// ownerID. claimedID is the user ID from the request body, which
+// checkPrincipal has already matched against the caller; admins override
+// ownership. Without a caller nothing is owned.
+func ownsResource(ctx context.Context, ownerID, claimedID string) bool {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	return principal.Role == auth.RoleAdmin || ownerID == claimedID
+}
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"tour-service/internal/models"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// Review limits.
+const (
+	minRating        = 1
+	maxRating        = 5
+	maxReviewComment = 2000
+	maxReviewImages  = 10
+)
+
+// reviewActivityWindow is how recently a tourist must have been active on an
+// unfinished execution to review the tour. Completed tours can be reviewed at
+// any time.
+const reviewActivityWindow = 7 * 24 * time.Hour
+
+func (h *TourServiceHandler) CreateReview(ctx context.Context, req *pb.CreateReviewRequest) (*pb.ReviewResponse, error) {
+	if err := checkPrincipal(ctx, "touristId", req.TouristId); err != nil {
+		return &pb.ReviewResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
+	tourID, err := primitive.ObjectIDFromHex(req.TourId)
+	if err != nil {
+		return &pb.ReviewResponse{
+			Success: false,
+			Message: "Invalid tour ID",
+		}, h.fail(invalidArgument("tourId", "invalid tour ID"))
+	}
+
+	review := &models.Review{TourID: tourID, TouristID: req.TouristId}
+	if violations := applyReviewFields(review, req.Rating, req.Comment, req.VisitDate, req.Images); len(violations) > 0 {
+		return &pb.ReviewResponse{
+			Success: false,
+			Message: "Invalid review",
+		}, h.fail(invalidArguments("invalid review", violations...))
+	}
+
+	hasPurchased, err := h.repo.HasPurchased(ctx, req.TouristId, tourID)
+	if err != nil {
+		log.Printf("Error checking purchase: %v", err)
+		return &pb.ReviewResponse{
+			Success: false,
+			Message: "Failed to create review",
+		}, h.fail(internalError("failed to check purchase"))
+	}
+	if !hasPurchased {
+		return &pb.ReviewResponse{
+			Success: false,
+			Message: "Only tourists who bought the tour can review it",
+		}, h.fail(failedPrecondition("PURCHASE", "tour/"+req.TourId, "tour has not been purchased"))
+	}
+
+	reviewable, err := h.repo.HasReviewableExecution(ctx, req.TouristId, tourID, time.Now().Add(-reviewActivityWindow))
+	if err != nil {
+		log.Printf("Error checking executions: %v", err)
+		return &pb.ReviewResponse{
+			Success: false,
+			Message: "Failed to create review",
+		}, h.fail(internalError("failed to check tour executions"))
+	}
+	if !reviewable {
+		return &pb.ReviewResponse{
+			Success: false,
+			Message: "Complete the tour before reviewing it",
+		}, h.fail(failedPrecondition("EXECUTION", "tour/"+req.TourId, "tour must be completed or recently walked to be reviewed"))
+	}
+
+	err = h.repo.CreateReview(ctx, review)
+	if errors.Is(err, repository.ErrReviewExists) {
+		return &pb.ReviewResponse{
+			Success: false,
+			Message: "You have already reviewed this tour",
+		}, h.fail(alreadyExists("review", "tour/"+req.TourId, "tour has already been reviewed; update the existing review instead"))
+	}
+	if err != nil {
+		log.Printf("Error creating review: %v", err)
+		return &pb.ReviewResponse{
+			Success: false,
+			Message: "Failed to create review",
+		}, h.fail(internalError("failed to create review"))
+	}
+
+	return &pb.ReviewResponse{
+		Success: true,
+		Message: "Review created successfully",
+		Review:  mapReviewToProto(review),
+	}, nil
+}
+
+func (h *TourServiceHandler) GetReviews(ctx context.Context, req *pb.GetReviewsRequest) (*pb.ReviewsResponse, error) {
+	tourID, err := primitive.ObjectIDFromHex(req.TourId)
+	if err != nil {
+		return &pb.ReviewsResponse{
+			Success: false,
+			Message: "Invalid tour ID",
+		}, h.fail(invalidArgument("tourId", "invalid tour ID"))
+	}
+
+	reviews, err := h.repo.GetReviewsByTourID(ctx, tourID)
+	if err != nil {
+		log.Printf("Error getting reviews: %v", err)
+		return &pb.ReviewsResponse{
+			Success: false,
+			Message: "Failed to get reviews",
+		}, h.fail(internalError("failed to get reviews"))
+	}
+
+	protoReviews := make([]*pb.Review, len(reviews))
+	for i, review := range reviews {
+		protoReviews[i] = mapReviewToProto(review)
+	}
+
+	return &pb.ReviewsResponse{
+		Success: true,
+		Message: "Reviews retrieved successfully",
+		Reviews: protoReviews,
+	}, nil
+}
+
+// UpdateReview replaces the rating, comment, visit date and images of a
+// review. The tour rating follows the change.
+func (h *TourServiceHandler) UpdateReview(ctx context.Context, req *pb.UpdateReviewRequest) (*pb.ReviewResponse, error) {
+	if err := checkPrincipal(ctx, "touristId", req.TouristId); err != nil {
+		return &pb.ReviewResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
+	reviewID, err := primitive.ObjectIDFromHex(req.ReviewId)
+	if err != nil {
+		return &pb.ReviewResponse{
+			Success: false,
+			Message: "Invalid review ID",
+		}, h.fail(invalidArgument("reviewId", "invalid review ID"))
+	}
+
+	review, err := h.repo.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		return &pb.ReviewResponse{
+			Success: false,
+			Message: "Review not found",
+		}, h.fail(lookupError(err, "review", req.ReviewId))
+	}
+
+	if !ownsResource(ctx, review.TouristID, req.TouristId) {
+		return &pb.ReviewResponse{
+			Success: false,
+			Message: "Unauthorized: You didn't write this review",
+		}, h.fail(permissionDenied("review", req.ReviewId, "review was written by another tourist"))
+	}
+
+	previousRating := review.Rating
+	if violations := applyReviewFields(review, req.Rating, req.Comment, req.VisitDate, req.Images); len(violations) > 0 {
+		return &pb.ReviewResponse{
+			Success: false,
+			Message: "Invalid review",
+		}, h.fail(invalidArguments("invalid review", violations...))
+	}
+
+	err = h.repo.UpdateReview(ctx, review, previousRating)
+	if errors.Is(err, repository.ErrReviewChanged) {
+		return &pb.ReviewResponse{
+			Success: false,
+			Message: "Review was changed in the meantime, please retry",
+		}, h.fail(aborted("review was changed concurrently"))
+	}
+	if err != nil {
+		log.Printf("Error updating review: %v", err)
+		return &pb.ReviewResponse{
+			Success: false,
+			Message: "Failed to update review",
+		}, h.fail(internalError("failed to update review"))
+	}
+
+	return &pb.ReviewResponse{
+		Success: true,
+		Message: "Review updated successfully",
+		Review:  mapReviewToProto(review),
+	}, nil
+}
+
+// applyReviewFields validates the editable review fields, copies them onto
+// review and returns every problem found.
+func applyReviewFields(review *models.Review, rating int32, comment, visitDate string, images []string) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	violate := func(field, description string) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+	}
+
+	if rating < minRating || rating > maxRating {
+		violate("rating", fmt.Sprintf("rating must be between %d and %d", minRating, maxRating))
+	}
+	if utf8.RuneCountInString(comment) > maxReviewComment {
+		violate("comment", fmt.Sprintf("comment must be at most %d characters", maxReviewComment))
+	}
+
+	visited, err := time.Parse(time.DateOnly, visitDate)
+	switch {
+	case err != nil:
+		violate("visitDate", "visit date must be a date in YYYY-MM-DD format")
+	case visited.After(time.Now()):
+		violate("visitDate", "visit date must not be in the future")
+	}
+
+	if len(images) > maxReviewImages {
+		violate("images", fmt.Sprintf("at most %d images can be attached", maxReviewImages))
+	}
+	for i, image := range images {
+		if strings.TrimSpace(image) == "" {
+			violate(fmt.Sprintf("images[%d]", i), "image must not be empty")
+		}
+	}
+
+	review.Rating = rating
+	review.Comment = comment
+	review.VisitDate = visited
+	review.Images = images
+	return violations
+}
+
+func mapReviewToProto(review *models.Review) *pb.Review {
+	return &pb.Review{
+		Id:        review.ID.Hex(),
+		TourId:    review.TourID.Hex(),
+		TouristId: review.TouristID,
+		Rating:    review.Rating,
+		Comment:   review.Comment,
+		VisitDate: review.VisitDate.Format(time.DateOnly),
+		Images:    review.Images,
+		CreatedAt: review.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: review.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+	"tour-service/internal/geofile"
+	pb "tour-service/proto"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ExportTour renders the keypoints of a tour, in order, as a GPX, KML or
+// GeoJSON file for navigation apps. Like GetKeyPoints, only buyers and the
+// guide get every keypoint; anyone else gets the first one.
+func (h *TourServiceHandler) ExportTour(ctx context.Context, req *pb.ExportTourRequest) (*pb.ExportTourResponse, error) {
+	// UserId unlocks purchased keypoints, so it must belong to the caller
+	if req.UserId != "" {
+		if err := checkPrincipal(ctx, "userId", req.UserId); err != nil {
+			return &pb.ExportTourResponse{
+				Success: false,
+				Message: "Unauthorized",
+			}, h.fail(err)
+		}
+	}
+
+	tourID, err := primitive.ObjectIDFromHex(req.TourId)
+	if err != nil {
+		return &pb.ExportTourResponse{
+			Success: false,
+			Message: "Invalid tour ID",
+		}, h.fail(invalidArgument("tourId", "invalid tour ID"))
+	}
+
+	format := geofile.Format(strings.ToLower(req.Format))
+	if !slices.Contains(geofile.Formats, format) {
+		return &pb.ExportTourResponse{
+			Success: false,
+			Message: "Invalid format",
+		}, h.fail(invalidArgument("format", "format must be one of gpx, kml, geojson"))
+	}
+
+	tour, err := h.repo.GetTourByID(ctx, tourID)
+	if err != nil {
+		return &pb.ExportTourResponse{
+			Success: false,
+			Message: "Tour not found",
+		}, h.fail(lookupError(err, "tour", req.TourId))
+	}
+
+	keypoints, err := h.repo.GetKeyPointsByTourID(ctx, tourID)
+	if err != nil {
+		log.Printf("Error getting keypoints: %v", err)
+		return &pb.ExportTourResponse{
+			Success: false,
+			Message: "Failed to export tour",
+		}, h.fail(internalError("failed to get keypoints"))
+	}
+
+	isPurchased, isOwner := h.keyPointAccess(ctx, tourID, tour, req.UserId)
+	keypoints = visibleKeyPoints(orderedKeyPoints(keypoints), isPurchased || isOwner)
+
+	doc := geofile.Document{
+		Name:        tour.Name,
+		Description: tour.Description,
+		Features:    make([]geofile.Feature, len(keypoints)),
+	}
+	for i, kp := range keypoints {
+		doc.Features[i] = geofile.Feature{
+			Name:        kp.Name,
+			Description: kp.Description,
+			Latitude:    kp.Latitude,
+			Longitude:   kp.Longitude,
+		}
+	}
+
+	data, err := geofile.Encode(format, doc)
+	if err != nil {
+		log.Printf("Error encoding tour: %v", err)
+		return &pb.ExportTourResponse{
+			Success: false,
+			Message: "Failed to export tour",
+		}, h.fail(internalError("failed to encode tour"))
+	}
+
+	message := "Tour exported successfully"
+	if !isPurchased && !isOwner {
+		message = "Only the first keypoint is included until the tour is purchased"
+	}
+
+	return &pb.ExportTourResponse{
+		Success:       true,
+		Message:       message,
+		FileName:      exportFileName(tour.Name, format),
+		ContentType:   format.ContentType(),
+		Data:          data,
+		KeyPointCount: int32(len(keypoints)),
+		IsPurchased:   isPurchased,
+	}, nil
+}
+
+// exportFileName turns a tour name into a file name safe for downloads,
+// e.g. "Belgrade Fortress!" becomes "belgrade-fortress.gpx".
+func exportFileName(tourName string, format geofile.Format) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(tourName) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+
+	name := b.String()
+	if name == "" {
+		name = "tour"
+	}
+	return name + "." + string(format)
+}
@@ -2,38 +2,86 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"math"
+	"slices"
+	"strings"
 	"time"
 	"tour-service/internal/models"
 	"tour-service/internal/repository"
 	pb "tour-service/proto"
+	"unicode/utf8"
 
-	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 )
 
 type TourServiceHandler struct {
 	pb.UnimplementedTourServiceServer
-	repo repository.TourRepositoryInterface
+	repo                repository.TourRepositoryInterface
+	legacyErrorEnvelope bool
+	trackStatusInterval time.Duration
 }
 
-func NewTourServiceHandler(repo repository.TourRepositoryInterface) *TourServiceHandler {
-	return &TourServiceHandler{
-		repo: repo,
+// Option configures optional TourServiceHandler behaviour.
+type Option func(*TourServiceHandler)
+
+// WithLegacyErrorEnvelope makes handlers report failures only through the
+// Success/Message fields of the response, returning a nil error as older
+// clients expect.
+func WithLegacyErrorEnvelope(enabled bool) Option {
+	return func(h *TourServiceHandler) {
+		h.legacyErrorEnvelope = enabled
+	}
+}
+
+func NewTourServiceHandler(repo repository.TourRepositoryInterface, opts ...Option) *TourServiceHandler {
+	h := &TourServiceHandler{
+		repo:                repo,
+		trackStatusInterval: defaultTrackStatusInterval,
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
 
 // ============ Tour CRUD Operations ============
 
 func (h *TourServiceHandler) CreateTour(ctx context.Context, req *pb.CreateTourRequest) (*pb.TourResponse, error) {
+	if err := checkPrincipal(ctx, "guideId", req.GuideId); err != nil {
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
+	executionMode := effectiveExecutionMode(req.ExecutionMode)
+	violations := proximityRadiusViolations(req.ProximityRadius)
+	if !slices.Contains(validExecutionModes, executionMode) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "executionMode",
+			Description: "execution mode must be one of " + strings.Join(validExecutionModes, ", "),
+		})
+	}
+	if len(violations) > 0 {
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Invalid tour",
+		}, h.fail(invalidArguments("invalid tour", violations...))
+	}
+
 	tour := &models.Tour{
-		GuideID:     req.GuideId,
-		Name:        req.Name,
-		Description: req.Description,
-		Difficulty:  req.Difficulty,
-		Tags:        req.Tags,
+		GuideID:         req.GuideId,
+		Name:            req.Name,
+		Description:     req.Description,
+		Difficulty:      req.Difficulty,
+		Tags:            req.Tags,
+		ExecutionMode:   executionMode,
+		ProximityRadius: req.ProximityRadius,
 	}
 
 	err := h.repo.CreateTour(ctx, tour)
@@ -42,7 +90,7 @@ func (h *TourServiceHandler) CreateTour(ctx context.Context, req *pb.CreateTourR
 		return &pb.TourResponse{
 			Success: false,
 			Message: "Failed to create tour",
-		}, nil
+		}, h.fail(internalError("failed to create tour"))
 	}
 
 	return &pb.TourResponse{
@@ -53,45 +101,67 @@ func (h *TourServiceHandler) CreateTour(ctx context.Context, req *pb.CreateTourR
 }
 
 func (h *TourServiceHandler) GetTours(ctx context.Context, req *pb.GetToursRequest) (*pb.ToursResponse, error) {
-	var tours []*models.Tour
-	var err error
+	filter, page, violations := tourQueryFromProto(req)
+	if len(violations) > 0 {
+		return &pb.ToursResponse{
+			Success: false,
+			Message: "Invalid tour query",
+		}, h.fail(invalidArguments("invalid tour query", violations...))
+	}
 
-	if req.PublishedOnly {
-		tours, err = h.repo.GetPublishedTours(ctx)
-	} else if req.UserId != "" {
-		tours, err = h.repo.GetToursByGuideID(ctx, req.UserId)
-	} else {
-		tours, err = h.repo.GetPublishedTours(ctx)
+	if filter.IncludeDrafts {
+		// Drafts are visible only to their guide
+		if err := checkPrincipal(ctx, "userId", req.UserId); err != nil {
+			return &pb.ToursResponse{
+				Success: false,
+				Message: "Unauthorized",
+			}, h.fail(err)
+		}
 	}
 
+	result, err := h.repo.ListTours(ctx, filter, page)
+	if errors.Is(err, repository.ErrInvalidPageToken) {
+		return &pb.ToursResponse{
+			Success: false,
+			Message: "Invalid page token",
+		}, h.fail(invalidArgument("pageToken", "page token is malformed or was issued for another sort order"))
+	}
 	if err != nil {
 		log.Printf("Error getting tours: %v", err)
 		return &pb.ToursResponse{
 			Success: false,
 			Message: "Failed to get tours",
-		}, nil
+		}, h.fail(internalError("failed to get tours"))
 	}
 
-	protoTours := make([]*pb.Tour, len(tours))
-	for i, tour := range tours {
+	protoTours := make([]*pb.Tour, len(result.Tours))
+	for i, tour := range result.Tours {
 		protoTours[i] = mapTourToProto(tour)
 	}
 
 	return &pb.ToursResponse{
-		Success: true,
-		Message: "Tours retrieved successfully",
-		Tours:   protoTours,
+		Success:       true,
+		Message:       "Tours retrieved successfully",
+		Tours:         protoTours,
+		NextPageToken: result.NextPageToken,
 	}, nil
 }
 
 func (h *TourServiceHandler) GetMyTours(ctx context.Context, req *pb.GetMyToursRequest) (*pb.ToursResponse, error) {
+	if err := checkPrincipal(ctx, "guideId", req.GuideId); err != nil {
+		return &pb.ToursResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
 	tours, err := h.repo.GetToursByGuideID(ctx, req.GuideId)
 	if err != nil {
 		log.Printf("Error getting my tours: %v", err)
 		return &pb.ToursResponse{
 			Success: false,
 			Message: "Failed to get tours",
-		}, nil
+		}, h.fail(internalError("failed to get tours"))
 	}
 
 	protoTours := make([]*pb.Tour, len(tours))
@@ -112,7 +182,7 @@ func (h *TourServiceHandler) GetTourById(ctx context.Context, req *pb.GetTourByI
 		return &pb.TourResponse{
 			Success: false,
 			Message: "Invalid tour ID",
-		}, nil
+		}, h.fail(invalidArgument("tourId", "invalid tour ID"))
 	}
 
 	tour, err := h.repo.GetTourByID(ctx, tourID)
@@ -121,7 +191,7 @@ func (h *TourServiceHandler) GetTourById(ctx context.Context, req *pb.GetTourByI
 		return &pb.TourResponse{
 			Success: false,
 			Message: "Tour not found",
-		}, nil
+		}, h.fail(lookupError(err, "tour", req.TourId))
 	}
 
 	return &pb.TourResponse{
@@ -132,12 +202,19 @@ func (h *TourServiceHandler) GetTourById(ctx context.Context, req *pb.GetTourByI
 }
 
 func (h *TourServiceHandler) PublishTour(ctx context.Context, req *pb.PublishTourRequest) (*pb.TourResponse, error) {
+	if err := checkPrincipal(ctx, "guideId", req.GuideId); err != nil {
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
 	tourID, err := primitive.ObjectIDFromHex(req.TourId)
 	if err != nil {
 		return &pb.TourResponse{
 			Success: false,
 			Message: "Invalid tour ID",
-		}, nil
+		}, h.fail(invalidArgument("tourId", "invalid tour ID"))
 	}
 
 	tour, err := h.repo.GetTourByID(ctx, tourID)
@@ -145,15 +222,45 @@ func (h *TourServiceHandler) PublishTour(ctx context.Context, req *pb.PublishTou
 		return &pb.TourResponse{
 			Success: false,
 			Message: "Tour not found",
-		}, nil
+		}, h.fail(lookupError(err, "tour", req.TourId))
 	}
 
 	// Verify ownership
-	if tour.GuideID != req.GuideId {
+	if !ownsResource(ctx, tour.GuideID, req.GuideId) {
 		return &pb.TourResponse{
 			Success: false,
 			Message: "Unauthorized: You don't own this tour",
-		}, nil
+		}, h.fail(permissionDenied("tour", req.TourId, "tour is owned by another guide"))
+	}
+
+	switch tour.Status {
+	case models.TourStatusPublished:
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Tour is already published",
+		}, h.fail(failedPrecondition("TOUR_STATUS", "tour/"+req.TourId, "tour is already published"))
+	case models.TourStatusArchived:
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Archived tours must be reactivated instead",
+		}, h.fail(failedPrecondition("TOUR_STATUS", "tour/"+req.TourId, "archived tours are reactivated, not published"))
+	}
+
+	keyPoints, err := h.repo.GetKeyPointsByTourID(ctx, tourID)
+	if err != nil {
+		log.Printf("Error getting keypoints: %v", err)
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Failed to publish tour",
+		}, h.fail(internalError("failed to load keypoints"))
+	}
+
+	if violations := validateForPublish(tour, keyPoints, req.Price); len(violations) > 0 {
+		return &pb.TourResponse{
+			Success:    false,
+			Message:    "Tour is not ready to be published",
+			Violations: mapViolationsToProto(violations),
+		}, h.fail(failedPreconditions("tour is not ready to be published", violations...))
 	}
 
 	err = h.repo.PublishTour(ctx, tourID, req.Price)
@@ -162,7 +269,7 @@ func (h *TourServiceHandler) PublishTour(ctx context.Context, req *pb.PublishTou
 		return &pb.TourResponse{
 			Success: false,
 			Message: "Failed to publish tour",
-		}, nil
+		}, h.fail(internalError("failed to publish tour"))
 	}
 
 	// Get updated tour
@@ -175,15 +282,427 @@ func (h *TourServiceHandler) PublishTour(ctx context.Context, req *pb.PublishTou
 	}, nil
 }
 
+// validDifficulties are the accepted values of Tour.Difficulty.
+var validDifficulties = []string{"easy", "medium", "hard"}
+
+// validExecutionModes are the accepted values of Tour.ExecutionMode.
+var validExecutionModes = []string{models.ExecutionModeFreeRoam, models.ExecutionModeSequential}
+
+// effectiveExecutionMode treats an unset mode, as on tours and executions
+// from before modes existed, as free roam.
+func effectiveExecutionMode(mode string) string {
+	if mode == "" {
+		return models.ExecutionModeFreeRoam
+	}
+	return mode
+}
+
+// Minimum content a tour needs before it can be published.
+const (
+	minPublishTags      = 2
+	minPublishKeyPoints = 2
+)
+
+// validateForPublish returns every requirement the tour misses, so a guide can
+// fix them all at once instead of one failed publish at a time.
+func validateForPublish(tour *models.Tour, keyPoints []*models.KeyPoint, price float64) []*errdetails.PreconditionFailure_Violation {
+	var violations []*errdetails.PreconditionFailure_Violation
+	violate := func(field, description string) {
+		violations = append(violations, &errdetails.PreconditionFailure_Violation{
+			Type:        "PUBLISH_REQUIREMENT",
+			Subject:     field,
+			Description: description,
+		})
+	}
+
+	if strings.TrimSpace(tour.Name) == "" {
+		violate("name", "name is required")
+	}
+	if strings.TrimSpace(tour.Description) == "" {
+		violate("description", "description is required")
+	}
+	if !slices.Contains(validDifficulties, tour.Difficulty) {
+		violate("difficulty", "difficulty must be one of "+strings.Join(validDifficulties, ", "))
+	}
+	if len(tour.Tags) < minPublishTags {
+		violate("tags", fmt.Sprintf("at least %d tags are required", minPublishTags))
+	}
+	if len(keyPoints) < minPublishKeyPoints {
+		violate("keyPoints", fmt.Sprintf("at least %d keypoints are required", minPublishKeyPoints))
+	}
+	if price < 0 {
+		violate("price", "price must not be negative")
+	}
+	if len(tour.TransportTimes) == 0 {
+		violate("transportTimes", "at least one transport time estimate is required")
+	}
+	return violations
+}
+
+func (h *TourServiceHandler) UpdateTour(ctx context.Context, req *pb.UpdateTourRequest) (*pb.TourResponse, error) {
+	if err := checkPrincipal(ctx, "guideId", req.GuideId); err != nil {
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
+	tourID, err := primitive.ObjectIDFromHex(req.TourId)
+	if err != nil {
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Invalid tour ID",
+		}, h.fail(invalidArgument("tourId", "invalid tour ID"))
+	}
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Nothing to update",
+		}, h.fail(invalidArgument("updateMask", "update mask must list at least one field"))
+	}
+
+	tour, err := h.repo.GetTourByID(ctx, tourID)
+	if err != nil {
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Tour not found",
+		}, h.fail(lookupError(err, "tour", req.TourId))
+	}
+
+	if !ownsResource(ctx, tour.GuideID, req.GuideId) {
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Unauthorized: You don't own this tour",
+		}, h.fail(permissionDenied("tour", req.TourId, "tour is owned by another guide"))
+	}
+
+	// Drafts are priced when they are published
+	if tour.Status == models.TourStatusDraft && slices.Contains(paths, "price") {
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Price can only be changed on published tours",
+		}, h.fail(failedPrecondition("TOUR_STATUS", "tour/"+req.TourId, "price is set when the tour is published"))
+	}
+
+	if violations := applyTourUpdate(tour, req, paths); len(violations) > 0 {
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Invalid tour update",
+		}, h.fail(invalidArguments("invalid tour update", violations...))
+	}
+
+	// Cart items keep the price captured by AddToCart, so a price change only
+	// affects tours added to a cart from now on.
+	err = h.repo.UpdateTour(ctx, tour)
+	if err != nil {
+		log.Printf("Error updating tour: %v", err)
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Failed to update tour",
+		}, h.fail(internalError("failed to update tour"))
+	}
+
+	return &pb.TourResponse{
+		Success: true,
+		Message: "Tour updated successfully",
+		Tour:    mapTourToProto(tour),
+	}, nil
+}
+
+// applyTourUpdate copies the masked fields of req onto tour and returns every
+// validation problem found. Tours past the draft stage must keep a name,
+// description and tags since tourists have already seen them.
+func applyTourUpdate(tour *models.Tour, req *pb.UpdateTourRequest, paths []string) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	violate := func(field, description string) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+	}
+
+	for _, path := range paths {
+		switch path {
+		case "name":
+			if strings.TrimSpace(req.Name) == "" {
+				violate("name", "name must not be empty")
+			}
+			tour.Name = req.Name
+		case "description":
+			if tour.Status != models.TourStatusDraft && strings.TrimSpace(req.Description) == "" {
+				violate("description", "published tours must keep a description")
+			}
+			tour.Description = req.Description
+		case "difficulty":
+			if !slices.Contains(validDifficulties, req.Difficulty) {
+				violate("difficulty", "difficulty must be one of "+strings.Join(validDifficulties, ", "))
+			}
+			tour.Difficulty = req.Difficulty
+		case "tags":
+			if tour.Status != models.TourStatusDraft && len(req.Tags) == 0 {
+				violate("tags", "published tours must keep at least one tag")
+			}
+			tour.Tags = req.Tags
+		case "price":
+			if req.Price < 0 {
+				violate("price", "price must not be negative")
+			}
+			tour.Price = req.Price
+		case "transportTimes":
+			times, problems := transportTimesFromProto(req.TransportTimes)
+			violations = append(violations, problems...)
+			tour.TransportTimes = withSuggestedTransportTimes(times, tour.LengthKm)
+		case "executionMode":
+			// Running executions keep the mode they started with
+			if !slices.Contains(validExecutionModes, req.ExecutionMode) {
+				violate("executionMode", "execution mode must be one of "+strings.Join(validExecutionModes, ", "))
+			}
+			tour.ExecutionMode = req.ExecutionMode
+		case "proximityRadius":
+			violations = append(violations, proximityRadiusViolations(req.ProximityRadius)...)
+			tour.ProximityRadius = req.ProximityRadius
+		default:
+			violate("updateMask", fmt.Sprintf("field %q cannot be updated", path))
+		}
+	}
+	return violations
+}
+
+func (h *TourServiceHandler) ArchiveTour(ctx context.Context, req *pb.ArchiveTourRequest) (*pb.TourResponse, error) {
+	return h.transitionTour(ctx, req.TourId, req.GuideId, tourTransition{
+		from:    models.TourStatusPublished,
+		apply:   h.repo.ArchiveTour,
+		verb:    "archive",
+		success: "Tour archived successfully",
+	})
+}
+
+func (h *TourServiceHandler) ReactivateTour(ctx context.Context, req *pb.ReactivateTourRequest) (*pb.TourResponse, error) {
+	return h.transitionTour(ctx, req.TourId, req.GuideId, tourTransition{
+		from:    models.TourStatusArchived,
+		apply:   h.repo.ReactivateTour,
+		verb:    "reactivate",
+		success: "Tour reactivated successfully",
+	})
+}
+
+// tourTransition describes a lifecycle change that is only valid from a single
+// status.
+type tourTransition struct {
+	from    string
+	apply   func(ctx context.Context, tourID primitive.ObjectID) error
+	verb    string
+	success string
+}
+
+func (h *TourServiceHandler) transitionTour(ctx context.Context, tourIDHex, guideID string, t tourTransition) (*pb.TourResponse, error) {
+	if err := checkPrincipal(ctx, "guideId", guideID); err != nil {
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
+	tourID, err := primitive.ObjectIDFromHex(tourIDHex)
+	if err != nil {
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Invalid tour ID",
+		}, h.fail(invalidArgument("tourId", "invalid tour ID"))
+	}
+
+	tour, err := h.repo.GetTourByID(ctx, tourID)
+	if err != nil {
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Tour not found",
+		}, h.fail(lookupError(err, "tour", tourIDHex))
+	}
+
+	if !ownsResource(ctx, tour.GuideID, guideID) {
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Unauthorized: You don't own this tour",
+		}, h.fail(permissionDenied("tour", tourIDHex, "tour is owned by another guide"))
+	}
+
+	if tour.Status != t.from {
+		description := fmt.Sprintf("only %s tours can be %sd", t.from, t.verb)
+		return &pb.TourResponse{
+			Success: false,
+			Message: fmt.Sprintf("Cannot %s a %s tour", t.verb, tour.Status),
+		}, h.fail(failedPrecondition("TOUR_STATUS", "tour/"+tourIDHex, description))
+	}
+
+	err = t.apply(ctx, tourID)
+	if errors.Is(err, repository.ErrTourStatusChanged) {
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Tour status changed, please retry",
+		}, h.fail(aborted("tour status changed concurrently"))
+	}
+	if err != nil {
+		log.Printf("Error trying to %s tour: %v", t.verb, err)
+		return &pb.TourResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to %s tour", t.verb),
+		}, h.fail(internalError(fmt.Sprintf("failed to %s tour", t.verb)))
+	}
+
+	tour, err = h.repo.GetTourByID(ctx, tourID)
+	if err != nil {
+		return &pb.TourResponse{
+			Success: false,
+			Message: "Tour not found",
+		}, h.fail(lookupError(err, "tour", tourIDHex))
+	}
+
+	return &pb.TourResponse{
+		Success: true,
+		Message: t.success,
+		Tour:    mapTourToProto(tour),
+	}, nil
+}
+
+// maxNearbyRadiusKm bounds SearchToursNearby to a regional search.
+const maxNearbyRadiusKm = 200
+
+func (h *TourServiceHandler) SearchToursNearby(ctx context.Context, req *pb.SearchToursNearbyRequest) (*pb.NearbyToursResponse, error) {
+	violations := coordinateViolations(req.Latitude, req.Longitude)
+	if req.RadiusKm <= 0 || req.RadiusKm > maxNearbyRadiusKm {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "radiusKm",
+			Description: fmt.Sprintf("radius must be above 0 and at most %d km", maxNearbyRadiusKm),
+		})
+	}
+	if req.Limit < 0 || req.Limit > repository.MaxPageSize {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "limit",
+			Description: fmt.Sprintf("limit must be between 0 and %d", repository.MaxPageSize),
+		})
+	}
+	if len(violations) > 0 {
+		return &pb.NearbyToursResponse{
+			Success: false,
+			Message: "Invalid search",
+		}, h.fail(invalidArguments("invalid search", violations...))
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = repository.DefaultPageSize
+	}
+
+	nearby, err := h.repo.SearchToursNearby(ctx, req.Latitude, req.Longitude, req.RadiusKm*1000, limit)
+	if err != nil {
+		log.Printf("Error searching nearby tours: %v", err)
+		return &pb.NearbyToursResponse{
+			Success: false,
+			Message: "Failed to search tours",
+		}, h.fail(internalError("failed to search tours"))
+	}
+
+	protoTours := make([]*pb.NearbyTour, len(nearby))
+	for i, n := range nearby {
+		protoTours[i] = &pb.NearbyTour{
+			Tour:       mapTourToProto(n.Tour),
+			DistanceKm: n.DistanceMeters / 1000,
+		}
+	}
+
+	return &pb.NearbyToursResponse{
+		Success: true,
+		Message: "Tours retrieved successfully",
+		Tours:   protoTours,
+	}, nil
+}
+
+// maxSearchQueryLength bounds SearchTours queries, counted in characters.
+const maxSearchQueryLength = 200
+
+func (h *TourServiceHandler) SearchTours(ctx context.Context, req *pb.SearchToursRequest) (*pb.SearchToursResponse, error) {
+	query := strings.TrimSpace(req.Query)
+	var violations []*errdetails.BadRequest_FieldViolation
+	if query == "" || utf8.RuneCountInString(query) > maxSearchQueryLength {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "query",
+			Description: fmt.Sprintf("query must be between 1 and %d characters", maxSearchQueryLength),
+		})
+	}
+	if req.PageSize < 0 || req.PageSize > repository.MaxPageSize {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "pageSize",
+			Description: fmt.Sprintf("page size must be between 0 and %d", repository.MaxPageSize),
+		})
+	}
+	if len(violations) > 0 {
+		return &pb.SearchToursResponse{
+			Success: false,
+			Message: "Invalid search",
+		}, h.fail(invalidArguments("invalid search", violations...))
+	}
+
+	result, err := h.repo.SearchTours(ctx, query, repository.PageRequest{
+		Size:  int(req.PageSize),
+		Token: req.PageToken,
+	})
+	if errors.Is(err, repository.ErrInvalidPageToken) {
+		return &pb.SearchToursResponse{
+			Success: false,
+			Message: "Invalid page token",
+		}, h.fail(invalidArgument("pageToken", "page token is malformed or was issued for another query"))
+	}
+	if err != nil {
+		log.Printf("Error searching tours: %v", err)
+		return &pb.SearchToursResponse{
+			Success: false,
+			Message: "Failed to search tours",
+		}, h.fail(internalError("failed to search tours"))
+	}
+
+	results := make([]*pb.TourSearchResult, len(result.Hits))
+	for i, hit := range result.Hits {
+		results[i] = mapSearchHitToProto(hit)
+	}
+
+	return &pb.SearchToursResponse{
+		Success:       true,
+		Message:       "Tours retrieved successfully",
+		Results:       results,
+		NextPageToken: result.NextPageToken,
+	}, nil
+}
+
 // ============ KeyPoint Operations ============
 
 func (h *TourServiceHandler) AddKeyPoint(ctx context.Context, req *pb.AddKeyPointRequest) (*pb.KeyPointResponse, error) {
+	if err := checkPrincipal(ctx, "guideId", req.GuideId); err != nil {
+		return &pb.KeyPointResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
 	tourID, err := primitive.ObjectIDFromHex(req.TourId)
 	if err != nil {
 		return &pb.KeyPointResponse{
 			Success: false,
 			Message: "Invalid tour ID",
-		}, nil
+		}, h.fail(invalidArgument("tourId", "invalid tour ID"))
+	}
+
+	violations := coordinateViolations(req.Latitude, req.Longitude)
+	violations = append(violations, proximityRadiusViolations(req.ProximityRadius)...)
+	if req.Order < 0 {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "order",
+			Description: "order must not be negative",
+		})
+	}
+	if len(violations) > 0 {
+		return &pb.KeyPointResponse{
+			Success: false,
+			Message: "Invalid keypoint",
+		}, h.fail(invalidArguments("invalid keypoint", violations...))
 	}
 
 	// Verify tour exists and user owns it
@@ -192,24 +711,25 @@ func (h *TourServiceHandler) AddKeyPoint(ctx context.Context, req *pb.AddKeyPoin
 		return &pb.KeyPointResponse{
 			Success: false,
 			Message: "Tour not found",
-		}, nil
+		}, h.fail(lookupError(err, "tour", req.TourId))
 	}
 
-	if tour.GuideID != req.GuideId {
+	if !ownsResource(ctx, tour.GuideID, req.GuideId) {
 		return &pb.KeyPointResponse{
 			Success: false,
 			Message: "Unauthorized: You don't own this tour",
-		}, nil
+		}, h.fail(permissionDenied("tour", req.TourId, "tour is owned by another guide"))
 	}
 
 	keypoint := &models.KeyPoint{
-		TourID:      tourID,
-		Latitude:    req.Latitude,
-		Longitude:   req.Longitude,
-		Name:        req.Name,
-		Description: req.Description,
-		Image:       req.Image,
-		Order:       req.Order,
+		TourID:          tourID,
+		Latitude:        req.Latitude,
+		Longitude:       req.Longitude,
+		Name:            req.Name,
+		Description:     req.Description,
+		Image:           req.Image,
+		Order:           req.Order,
+		ProximityRadius: req.ProximityRadius,
 	}
 
 	err = h.repo.CreateKeyPoint(ctx, keypoint)
@@ -218,9 +738,11 @@ func (h *TourServiceHandler) AddKeyPoint(ctx context.Context, req *pb.AddKeyPoin
 		return &pb.KeyPointResponse{
 			Success: false,
 			Message: "Failed to create keypoint",
-		}, nil
+		}, h.fail(internalError("failed to create keypoint"))
 	}
 
+	h.refreshTourMetrics(ctx, tour)
+
 	return &pb.KeyPointResponse{
 		Success:  true,
 		Message:  "Keypoint added successfully",
@@ -229,12 +751,22 @@ func (h *TourServiceHandler) AddKeyPoint(ctx context.Context, req *pb.AddKeyPoin
 }
 
 func (h *TourServiceHandler) GetKeyPoints(ctx context.Context, req *pb.GetKeyPointsRequest) (*pb.KeyPointsResponse, error) {
+	// UserId unlocks purchased keypoints, so it must belong to the caller
+	if req.UserId != "" {
+		if err := checkPrincipal(ctx, "userId", req.UserId); err != nil {
+			return &pb.KeyPointsResponse{
+				Success: false,
+				Message: "Unauthorized",
+			}, h.fail(err)
+		}
+	}
+
 	tourID, err := primitive.ObjectIDFromHex(req.TourId)
 	if err != nil {
 		return &pb.KeyPointsResponse{
 			Success: false,
 			Message: "Invalid tour ID",
-		}, nil
+		}, h.fail(invalidArgument("tourId", "invalid tour ID"))
 	}
 
 	keypoints, err := h.repo.GetKeyPointsByTourID(ctx, tourID)
@@ -243,30 +775,29 @@ func (h *TourServiceHandler) GetKeyPoints(ctx context.Context, req *pb.GetKeyPoi
 		return &pb.KeyPointsResponse{
 			Success: false,
 			Message: "Failed to get keypoints",
-		}, nil
+		}, h.fail(internalError("failed to get keypoints"))
 	}
 
 	// Get the tour to check if user is the guide
 	tour, _ := h.repo.GetTourByID(ctx, tourID)
+	isPurchased, isOwner := h.keyPointAccess(ctx, tourID, tour, req.UserId)
 
-	// Check if user has purchased the tour OR is the guide
-	isPurchased := false
-	isOwner := false
+	var tourRadius float64
+	if tour != nil {
+		tourRadius = tour.ProximityRadius
+	}
+	// An active execution keeps checking against the radius it started with
 	if req.UserId != "" {
-		isPurchased, _ = h.repo.HasPurchased(ctx, req.UserId, tourID)
-		if tour != nil {
-			isOwner = tour.GuideID == req.UserId
+		if execution, err := h.repo.GetActiveExecution(ctx, req.UserId, tourID); err == nil && execution != nil {
+			tourRadius = execution.ProximityRadius
 		}
 	}
 
-	// If not purchased AND not the owner, only return first keypoint
 	protoKeypoints := make([]*pb.KeyPoint, 0)
-	if !isPurchased && !isOwner && len(keypoints) > 0 {
-		protoKeypoints = append(protoKeypoints, mapKeyPointToProto(keypoints[0]))
-	} else {
-		for _, kp := range keypoints {
-			protoKeypoints = append(protoKeypoints, mapKeyPointToProto(kp))
-		}
+	for _, kp := range visibleKeyPoints(keypoints, isPurchased || isOwner) {
+		protoKeypoint := mapKeyPointToProto(kp)
+		protoKeypoint.EffectiveProximityRadius = proximityRadius(kp, tourRadius)
+		protoKeypoints = append(protoKeypoints, protoKeypoint)
 	}
 
 	return &pb.KeyPointsResponse{
@@ -277,13 +808,42 @@ func (h *TourServiceHandler) GetKeyPoints(ctx context.Context, req *pb.GetKeyPoi
 	}, nil
 }
 
+// keyPointAccess reports whether userID has purchased the tour and whether
+// it is the caller's own tour. Tour may be nil when it could not be loaded.
+func (h *TourServiceHandler) keyPointAccess(ctx context.Context, tourID primitive.ObjectID, tour *models.Tour, userID string) (isPurchased, isOwner bool) {
+	if userID == "" {
+		return false, false
+	}
+	isPurchased, _ = h.repo.HasPurchased(ctx, userID, tourID)
+	if tour != nil {
+		isOwner = ownsResource(ctx, tour.GuideID, userID)
+	}
+	return isPurchased, isOwner
+}
+
+// visibleKeyPoints returns the keypoints a caller may see: all of them with
+// full access, otherwise only the first one as a preview.
+func visibleKeyPoints(keypoints []*models.KeyPoint, fullAccess bool) []*models.KeyPoint {
+	if fullAccess || len(keypoints) <= 1 {
+		return keypoints
+	}
+	return keypoints[:1]
+}
+
 func (h *TourServiceHandler) UpdateKeyPoint(ctx context.Context, req *pb.UpdateKeyPointRequest) (*pb.KeyPointResponse, error) {
+	if err := checkPrincipal(ctx, "guideId", req.GuideId); err != nil {
+		return &pb.KeyPointResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
 	keypointID, err := primitive.ObjectIDFromHex(req.KeyPointId)
 	if err != nil {
 		return &pb.KeyPointResponse{
 			Success: false,
 			Message: "Invalid keypoint ID",
-		}, nil
+		}, h.fail(invalidArgument("keyPointId", "invalid keypoint ID"))
 	}
 
 	tourID, err := primitive.ObjectIDFromHex(req.TourId)
@@ -291,37 +851,60 @@ func (h *TourServiceHandler) UpdateKeyPoint(ctx context.Context, req *pb.UpdateK
 		return &pb.KeyPointResponse{
 			Success: false,
 			Message: "Invalid tour ID",
-		}, nil
+		}, h.fail(invalidArgument("tourId", "invalid tour ID"))
+	}
+
+	violations := coordinateViolations(req.Latitude, req.Longitude)
+	violations = append(violations, proximityRadiusViolations(req.ProximityRadius)...)
+	if len(violations) > 0 {
+		return &pb.KeyPointResponse{
+			Success: false,
+			Message: "Invalid keypoint",
+		}, h.fail(invalidArguments("invalid keypoint", violations...))
 	}
 
 	// Verify ownership
 	tour, err := h.repo.GetTourByID(ctx, tourID)
-	if err != nil || tour.GuideID != req.GuideId {
+	if err != nil {
 		return &pb.KeyPointResponse{
 			Success: false,
 			Message: "Unauthorized",
-		}, nil
+		}, h.fail(lookupError(err, "tour", req.TourId))
+	}
+	if !ownsResource(ctx, tour.GuideID, req.GuideId) {
+		return &pb.KeyPointResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(permissionDenied("tour", req.TourId, "tour is owned by another guide"))
 	}
 
 	keypoint := &models.KeyPoint{
-		ID:          keypointID,
-		TourID:      tourID,
-		Latitude:    req.Latitude,
-		Longitude:   req.Longitude,
-		Name:        req.Name,
-		Description: req.Description,
-		Image:       req.Image,
+		ID:              keypointID,
+		TourID:          tourID,
+		Latitude:        req.Latitude,
+		Longitude:       req.Longitude,
+		Name:            req.Name,
+		Description:     req.Description,
+		Image:           req.Image,
+		ProximityRadius: req.ProximityRadius,
 	}
 
 	err = h.repo.UpdateKeyPoint(ctx, keypoint)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &pb.KeyPointResponse{
+			Success: false,
+			Message: "Keypoint not found",
+		}, h.fail(notFound("keypoint", req.KeyPointId))
+	}
 	if err != nil {
 		log.Printf("Error updating keypoint: %v", err)
 		return &pb.KeyPointResponse{
 			Success: false,
 			Message: "Failed to update keypoint",
-		}, nil
+		}, h.fail(internalError("failed to update keypoint"))
 	}
 
+	h.refreshTourMetrics(ctx, tour)
 	return &pb.KeyPointResponse{
 		Success:  true,
 		Message:  "Keypoint updated successfully",
@@ -330,12 +913,19 @@ func (h *TourServiceHandler) UpdateKeyPoint(ctx context.Context, req *pb.UpdateK
 }
 
 func (h *TourServiceHandler) DeleteKeyPoint(ctx context.Context, req *pb.DeleteKeyPointRequest) (*pb.DeleteKeyPointResponse, error) {
+	if err := checkPrincipal(ctx, "guideId", req.GuideId); err != nil {
+		return &pb.DeleteKeyPointResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
 	keypointID, err := primitive.ObjectIDFromHex(req.KeyPointId)
 	if err != nil {
 		return &pb.DeleteKeyPointResponse{
 			Success: false,
 			Message: "Invalid keypoint ID",
-		}, nil
+		}, h.fail(invalidArgument("keyPointId", "invalid keypoint ID"))
 	}
 
 	tourID, err := primitive.ObjectIDFromHex(req.TourId)
@@ -343,36 +933,148 @@ func (h *TourServiceHandler) DeleteKeyPoint(ctx context.Context, req *pb.DeleteK
 		return &pb.DeleteKeyPointResponse{
 			Success: false,
 			Message: "Invalid tour ID",
-		}, nil
+		}, h.fail(invalidArgument("tourId", "invalid tour ID"))
 	}
 
 	// Verify ownership
 	tour, err := h.repo.GetTourByID(ctx, tourID)
-	if err != nil || tour.GuideID != req.GuideId {
+	if err != nil {
+		return &pb.DeleteKeyPointResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(lookupError(err, "tour", req.TourId))
+	}
+	if !ownsResource(ctx, tour.GuideID, req.GuideId) {
 		return &pb.DeleteKeyPointResponse{
 			Success: false,
-			Message: "Unauthorized",
-		}, nil
+			Message: "Unauthorized",
+		}, h.fail(permissionDenied("tour", req.TourId, "tour is owned by another guide"))
+	}
+
+	err = h.repo.DeleteKeyPoint(ctx, tourID, keypointID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &pb.DeleteKeyPointResponse{
+			Success: false,
+			Message: "Keypoint not found",
+		}, h.fail(notFound("keypoint", req.KeyPointId))
+	}
+	if err != nil {
+		log.Printf("Error deleting keypoint: %v", err)
+		return &pb.DeleteKeyPointResponse{
+			Success: false,
+			Message: "Failed to delete keypoint",
+		}, h.fail(internalError("failed to delete keypoint"))
+	}
+
+	h.refreshTourMetrics(ctx, tour)
+	return &pb.DeleteKeyPointResponse{
+		Success: true,
+		Message: "Keypoint deleted successfully",
+	}, nil
+}
+
+// ReorderKeyPoints sets the order of all keypoints of a tour at once.
+func (h *TourServiceHandler) ReorderKeyPoints(ctx context.Context, req *pb.ReorderKeyPointsRequest) (*pb.KeyPointsResponse, error) {
+	if err := checkPrincipal(ctx, "guideId", req.GuideId); err != nil {
+		return &pb.KeyPointsResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
+	tourID, err := primitive.ObjectIDFromHex(req.TourId)
+	if err != nil {
+		return &pb.KeyPointsResponse{
+			Success: false,
+			Message: "Invalid tour ID",
+		}, h.fail(invalidArgument("tourId", "invalid tour ID"))
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if len(req.KeyPointIds) == 0 {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "keyPointIds",
+			Description: "keypoint sequence must not be empty",
+		})
+	}
+	keyPointIDs := make([]primitive.ObjectID, len(req.KeyPointIds))
+	for i, id := range req.KeyPointIds {
+		if keyPointIDs[i], err = primitive.ObjectIDFromHex(id); err != nil {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       fmt.Sprintf("keyPointIds[%d]", i),
+				Description: "invalid keypoint ID",
+			})
+		}
+	}
+	if len(violations) > 0 {
+		return &pb.KeyPointsResponse{
+			Success: false,
+			Message: "Invalid keypoint sequence",
+		}, h.fail(invalidArguments("invalid keypoint sequence", violations...))
+	}
+
+	tour, err := h.repo.GetTourByID(ctx, tourID)
+	if err != nil {
+		return &pb.KeyPointsResponse{
+			Success: false,
+			Message: "Tour not found",
+		}, h.fail(lookupError(err, "tour", req.TourId))
+	}
+	if !ownsResource(ctx, tour.GuideID, req.GuideId) {
+		return &pb.KeyPointsResponse{
+			Success: false,
+			Message: "Unauthorized: You don't own this tour",
+		}, h.fail(permissionDenied("tour", req.TourId, "tour is owned by another guide"))
+	}
+
+	err = h.repo.ReorderKeyPoints(ctx, tourID, keyPointIDs)
+	if errors.Is(err, repository.ErrKeyPointsMismatch) {
+		return &pb.KeyPointsResponse{
+			Success: false,
+			Message: "The sequence must list every keypoint of the tour once",
+		}, h.fail(invalidArgument("keyPointIds", "sequence must list every keypoint of the tour exactly once"))
+	}
+	if err != nil {
+		log.Printf("Error reordering keypoints: %v", err)
+		return &pb.KeyPointsResponse{
+			Success: false,
+			Message: "Failed to reorder keypoints",
+		}, h.fail(internalError("failed to reorder keypoints"))
 	}
 
-	err = h.repo.DeleteKeyPoint(ctx, keypointID)
+	h.refreshTourMetrics(ctx, tour)
+
+	keypoints, err := h.repo.GetKeyPointsByTourID(ctx, tourID)
 	if err != nil {
-		log.Printf("Error deleting keypoint: %v", err)
-		return &pb.DeleteKeyPointResponse{
+		log.Printf("Error getting keypoints: %v", err)
+		return &pb.KeyPointsResponse{
 			Success: false,
-			Message: "Failed to delete keypoint",
-		}, nil
+			Message: "Failed to get keypoints",
+		}, h.fail(internalError("failed to get keypoints"))
 	}
 
-	return &pb.DeleteKeyPointResponse{
-		Success: true,
-		Message: "Keypoint deleted successfully",
+	protoKeypoints := make([]*pb.KeyPoint, len(keypoints))
+	for i, kp := range keypoints {
+		protoKeypoints[i] = mapKeyPointToProto(kp)
+	}
+
+	return &pb.KeyPointsResponse{
+		Success:   true,
+		Message:   "Keypoints reordered successfully",
+		KeyPoints: protoKeypoints,
 	}, nil
 }
 
 // ============ Position Simulator ============
 
 func (h *TourServiceHandler) UpdatePosition(ctx context.Context, req *pb.UpdatePositionRequest) (*pb.PositionResponse, error) {
+	if err := checkPrincipal(ctx, "touristId", req.TouristId); err != nil {
+		return &pb.PositionResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
 	position := &models.Position{
 		TouristID: req.TouristId,
 		Latitude:  req.Latitude,
@@ -385,7 +1087,7 @@ func (h *TourServiceHandler) UpdatePosition(ctx context.Context, req *pb.UpdateP
 		return &pb.PositionResponse{
 			Success: false,
 			Message: "Failed to update position",
-		}, nil
+		}, h.fail(internalError("failed to update position"))
 	}
 
 	return &pb.PositionResponse{
@@ -401,13 +1103,20 @@ func (h *TourServiceHandler) UpdatePosition(ctx context.Context, req *pb.UpdateP
 }
 
 func (h *TourServiceHandler) GetCurrentPosition(ctx context.Context, req *pb.GetPositionRequest) (*pb.PositionResponse, error) {
+	if err := checkPrincipal(ctx, "touristId", req.TouristId); err != nil {
+		return &pb.PositionResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
 	position, err := h.repo.GetPosition(ctx, req.TouristId)
 	if err != nil {
 		log.Printf("Error getting position: %v", err)
 		return &pb.PositionResponse{
 			Success: false,
 			Message: "Position not found",
-		}, nil
+		}, h.fail(lookupError(err, "position", req.TouristId))
 	}
 
 	return &pb.PositionResponse{
@@ -425,12 +1134,19 @@ func (h *TourServiceHandler) GetCurrentPosition(ctx context.Context, req *pb.Get
 // ============ Shopping Cart ============
 
 func (h *TourServiceHandler) AddToCart(ctx context.Context, req *pb.AddToCartRequest) (*pb.CartResponse, error) {
+	if err := checkPrincipal(ctx, "touristId", req.TouristId); err != nil {
+		return &pb.CartResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
 	tourID, err := primitive.ObjectIDFromHex(req.TourId)
 	if err != nil {
 		return &pb.CartResponse{
 			Success: false,
 			Message: "Invalid tour ID",
-		}, nil
+		}, h.fail(invalidArgument("tourId", "invalid tour ID"))
 	}
 
 	// Get tour details
@@ -439,14 +1155,29 @@ func (h *TourServiceHandler) AddToCart(ctx context.Context, req *pb.AddToCartReq
 		return &pb.CartResponse{
 			Success: false,
 			Message: "Tour not found",
-		}, nil
+		}, h.fail(lookupError(err, "tour", req.TourId))
 	}
 
 	if !tour.IsPublished {
 		return &pb.CartResponse{
 			Success: false,
 			Message: "Cannot add unpublished tour to cart",
-		}, nil
+		}, h.fail(failedPrecondition("TOUR_STATUS", "tour/"+req.TourId, "tour is not published"))
+	}
+
+	purchased, err := h.repo.HasPurchased(ctx, req.TouristId, tourID)
+	if err != nil {
+		log.Printf("Error checking purchase: %v", err)
+		return &pb.CartResponse{
+			Success: false,
+			Message: "Failed to access cart",
+		}, h.fail(internalError("failed to check purchase"))
+	}
+	if purchased {
+		return &pb.CartResponse{
+			Success: false,
+			Message: "Tour already purchased",
+		}, h.fail(alreadyExists("purchaseToken", req.TourId, "tour has already been purchased"))
 	}
 
 	// Get or create cart
@@ -456,7 +1187,7 @@ func (h *TourServiceHandler) AddToCart(ctx context.Context, req *pb.AddToCartReq
 		return &pb.CartResponse{
 			Success: false,
 			Message: "Failed to access cart",
-		}, nil
+		}, h.fail(internalError("failed to access cart"))
 	}
 
 	// Check if tour already in cart
@@ -465,7 +1196,7 @@ func (h *TourServiceHandler) AddToCart(ctx context.Context, req *pb.AddToCartReq
 			return &pb.CartResponse{
 				Success: false,
 				Message: "Tour already in cart",
-			}, nil
+			}, h.fail(alreadyExists("cartItem", req.TourId, "tour is already in cart"))
 		}
 	}
 
@@ -483,7 +1214,7 @@ func (h *TourServiceHandler) AddToCart(ctx context.Context, req *pb.AddToCartReq
 		return &pb.CartResponse{
 			Success: false,
 			Message: "Failed to update cart",
-		}, nil
+		}, h.fail(internalError("failed to update cart"))
 	}
 
 	return &pb.CartResponse{
@@ -494,12 +1225,19 @@ func (h *TourServiceHandler) AddToCart(ctx context.Context, req *pb.AddToCartReq
 }
 
 func (h *TourServiceHandler) RemoveFromCart(ctx context.Context, req *pb.RemoveFromCartRequest) (*pb.CartResponse, error) {
+	if err := checkPrincipal(ctx, "touristId", req.TouristId); err != nil {
+		return &pb.CartResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
 	tourID, err := primitive.ObjectIDFromHex(req.TourId)
 	if err != nil {
 		return &pb.CartResponse{
 			Success: false,
 			Message: "Invalid tour ID",
-		}, nil
+		}, h.fail(invalidArgument("tourId", "invalid tour ID"))
 	}
 
 	cart, err := h.repo.GetOrCreateCart(ctx, req.TouristId)
@@ -507,7 +1245,7 @@ func (h *TourServiceHandler) RemoveFromCart(ctx context.Context, req *pb.RemoveF
 		return &pb.CartResponse{
 			Success: false,
 			Message: "Failed to access cart",
-		}, nil
+		}, h.fail(internalError("failed to access cart"))
 	}
 
 	// Remove item and recalculate total
@@ -528,7 +1266,7 @@ func (h *TourServiceHandler) RemoveFromCart(ctx context.Context, req *pb.RemoveF
 		return &pb.CartResponse{
 			Success: false,
 			Message: "Failed to update cart",
-		}, nil
+		}, h.fail(internalError("failed to update cart"))
 	}
 
 	return &pb.CartResponse{
@@ -539,12 +1277,19 @@ func (h *TourServiceHandler) RemoveFromCart(ctx context.Context, req *pb.RemoveF
 }
 
 func (h *TourServiceHandler) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.CartResponse, error) {
+	if err := checkPrincipal(ctx, "touristId", req.TouristId); err != nil {
+		return &pb.CartResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
 	cart, err := h.repo.GetOrCreateCart(ctx, req.TouristId)
 	if err != nil {
 		return &pb.CartResponse{
 			Success: false,
 			Message: "Failed to get cart",
-		}, nil
+		}, h.fail(internalError("failed to get cart"))
 	}
 
 	return &pb.CartResponse{
@@ -555,74 +1300,116 @@ func (h *TourServiceHandler) GetCart(ctx context.Context, req *pb.GetCartRequest
 }
 
 func (h *TourServiceHandler) Checkout(ctx context.Context, req *pb.CheckoutRequest) (*pb.CheckoutResponse, error) {
-	cart, err := h.repo.GetOrCreateCart(ctx, req.TouristId)
-	if err != nil {
+	if err := checkPrincipal(ctx, "touristId", req.TouristId); err != nil {
 		return &pb.CheckoutResponse{
 			Success: false,
-			Message: "Failed to get cart",
-		}, nil
+			Message: "Unauthorized",
+		}, h.fail(err)
 	}
 
-	if len(cart.Items) == 0 {
-		return &pb.CheckoutResponse{
-			Success: false,
-			Message: "Cart is empty",
-		}, nil
+	result, err := h.repo.CheckoutCart(ctx, req.TouristId, req.IdempotencyKey)
+	if err != nil {
+		return h.checkoutFailure(req.TouristId, err)
 	}
 
-	// Create purchase tokens for each item
-	tokens := []*pb.PurchaseToken{}
-	for _, item := range cart.Items {
-		token := &models.PurchaseToken{
-			TouristID: req.TouristId,
-			TourID:    item.TourID,
-			Token:     uuid.New().String(),
-		}
-
-		err := h.repo.CreatePurchaseToken(ctx, token)
-		if err != nil {
-			log.Printf("Error creating purchase token: %v", err)
-			continue
-		}
-
-		tokens = append(tokens, &pb.PurchaseToken{
-			TourId:      item.TourID.Hex(),
+	tokens := make([]*pb.PurchaseToken, len(result.Tokens))
+	for i, token := range result.Tokens {
+		tokens[i] = &pb.PurchaseToken{
+			TourId:      token.TourID.Hex(),
 			Token:       token.Token,
 			PurchasedAt: token.PurchasedAt.Format(time.RFC3339),
-		})
-	}
-
-	// Clear cart
-	err = h.repo.ClearCart(ctx, req.TouristId)
-	if err != nil {
-		log.Printf("Error clearing cart: %v", err)
+		}
 	}
 
 	return &pb.CheckoutResponse{
-		Success: true,
-		Message: fmt.Sprintf("Successfully purchased %d tours", len(tokens)),
-		Tokens:  tokens,
+		Success:  true,
+		Message:  fmt.Sprintf("Successfully purchased %d tours", len(tokens)),
+		Tokens:   tokens,
+		Replayed: result.Replayed,
 	}, nil
 }
 
+func (h *TourServiceHandler) checkoutFailure(touristID string, err error) (*pb.CheckoutResponse, error) {
+	var alreadyPurchased *repository.AlreadyPurchasedError
+	var unavailable *repository.UnavailableToursError
+	switch {
+	case errors.Is(err, repository.ErrCartEmpty):
+		return &pb.CheckoutResponse{
+			Success: false,
+			Message: "Cart is empty",
+		}, h.fail(failedPrecondition("CART_EMPTY", "cart/"+touristID, "cart is empty"))
+	case errors.As(err, &alreadyPurchased):
+		violations := make([]*errdetails.PreconditionFailure_Violation, len(alreadyPurchased.TourIDs))
+		for i, tourID := range alreadyPurchased.TourIDs {
+			violations[i] = &errdetails.PreconditionFailure_Violation{
+				Type:        "ALREADY_PURCHASED",
+				Subject:     "tour/" + tourID.Hex(),
+				Description: "tour has already been purchased",
+			}
+		}
+		return &pb.CheckoutResponse{
+			Success: false,
+			Message: "Cart contains tours you already own. Remove them and try again.",
+		}, h.fail(failedPreconditions("cart contains tours that were already purchased", violations...))
+	case errors.As(err, &unavailable):
+		violations := make([]*errdetails.PreconditionFailure_Violation, len(unavailable.TourIDs))
+		for i, tourID := range unavailable.TourIDs {
+			violations[i] = &errdetails.PreconditionFailure_Violation{
+				Type:        "TOUR_STATUS",
+				Subject:     "tour/" + tourID.Hex(),
+				Description: "tour is not published",
+			}
+		}
+		return &pb.CheckoutResponse{
+			Success: false,
+			Message: "Cart contains tours that are no longer available. Remove them and try again.",
+		}, h.fail(failedPreconditions("cart contains tours that are no longer published", violations...))
+	case errors.Is(err, repository.ErrCartChanged):
+		return &pb.CheckoutResponse{
+			Success: false,
+			Message: "Cart changed during checkout. Please try again.",
+		}, h.fail(aborted("cart changed during checkout"))
+	default:
+		log.Printf("Error checking out cart: %v", err)
+		return &pb.CheckoutResponse{
+			Success: false,
+			Message: "Failed to checkout",
+		}, h.fail(internalError("failed to checkout"))
+	}
+}
+
 // ============ Tour Execution ============
 
 func (h *TourServiceHandler) StartTourExecution(ctx context.Context, req *pb.StartExecutionRequest) (*pb.ExecutionResponse, error) {
+	if err := checkPrincipal(ctx, "touristId", req.TouristId); err != nil {
+		return &pb.ExecutionResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
 	tourID, err := primitive.ObjectIDFromHex(req.TourId)
 	if err != nil {
 		return &pb.ExecutionResponse{
 			Success: false,
 			Message: "Invalid tour ID",
-		}, nil
+		}, h.fail(invalidArgument("tourId", "invalid tour ID"))
 	}
 
 	// Check if user has purchased the tour
 	hasPurchased, err := h.repo.HasPurchased(ctx, req.TouristId, tourID)
-	if err != nil || !hasPurchased {
+	if err != nil {
+		log.Printf("Error checking purchase: %v", err)
 		return &pb.ExecutionResponse{
 			Success: false,
 			Message: "Tour not purchased. Please buy the tour first.",
-		}, nil
+		}, h.fail(internalError("failed to check purchase"))
+	}
+	if !hasPurchased {
+		return &pb.ExecutionResponse{
+			Success: false,
+			Message: "Tour not purchased. Please buy the tour first.",
+		}, h.fail(failedPrecondition("PURCHASE", "tour/"+req.TourId, "tour has not been purchased"))
 	}
 
 	// Check if there's already an active execution for this tour
@@ -637,12 +1424,22 @@ func (h *TourServiceHandler) StartTourExecution(ctx context.Context, req *pb.Sta
 		}, nil
 	}
 
+	tour, err := h.repo.GetTourByID(ctx, tourID)
+	if err != nil {
+		return &pb.ExecutionResponse{
+			Success: false,
+			Message: "Tour not found",
+		}, h.fail(lookupError(err, "tour", req.TourId))
+	}
+
 	// Create new execution
 	execution := &models.TourExecution{
-		TouristID:      req.TouristId,
-		TourID:         tourID,
-		StartLatitude:  req.StartLatitude,
-		StartLongitude: req.StartLongitude,
+		TouristID:       req.TouristId,
+		TourID:          tourID,
+		Mode:            effectiveExecutionMode(tour.ExecutionMode),
+		ProximityRadius: tour.ProximityRadius,
+		StartLatitude:   req.StartLatitude,
+		StartLongitude:  req.StartLongitude,
 	}
 
 	err = h.repo.CreateExecution(ctx, execution)
@@ -651,7 +1448,7 @@ func (h *TourServiceHandler) StartTourExecution(ctx context.Context, req *pb.Sta
 		return &pb.ExecutionResponse{
 			Success: false,
 			Message: "Failed to start tour execution",
-		}, nil
+		}, h.fail(internalError("failed to start tour execution"))
 	}
 
 	return &pb.ExecutionResponse{
@@ -662,12 +1459,19 @@ func (h *TourServiceHandler) StartTourExecution(ctx context.Context, req *pb.Sta
 }
 
 func (h *TourServiceHandler) CheckProximity(ctx context.Context, req *pb.CheckProximityRequest) (*pb.ProximityResponse, error) {
+	if err := checkPrincipal(ctx, "touristId", req.TouristId); err != nil {
+		return &pb.ProximityResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
 	executionID, err := primitive.ObjectIDFromHex(req.ExecutionId)
 	if err != nil {
 		return &pb.ProximityResponse{
 			Success: false,
 			Message: "Invalid execution ID",
-		}, nil
+		}, h.fail(invalidArgument("executionId", "invalid execution ID"))
 	}
 
 	execution, err := h.repo.GetExecution(ctx, executionID)
@@ -675,14 +1479,21 @@ func (h *TourServiceHandler) CheckProximity(ctx context.Context, req *pb.CheckPr
 		return &pb.ProximityResponse{
 			Success: false,
 			Message: "Execution not found",
-		}, nil
+		}, h.fail(lookupError(err, "execution", req.ExecutionId))
 	}
 
-	if execution.TouristID != req.TouristId {
+	if !ownsResource(ctx, execution.TouristID, req.TouristId) {
 		return &pb.ProximityResponse{
 			Success: false,
 			Message: "Unauthorized",
-		}, nil
+		}, h.fail(permissionDenied("execution", req.ExecutionId, "execution belongs to another tourist"))
+	}
+
+	if err := checkExecutionActive(execution, req.ExecutionId); err != nil {
+		return &pb.ProximityResponse{
+			Success: false,
+			Message: "Execution is already " + execution.Status,
+		}, h.fail(err)
 	}
 
 	// Get all keypoints for this tour
@@ -691,71 +1502,176 @@ func (h *TourServiceHandler) CheckProximity(ctx context.Context, req *pb.CheckPr
 		return &pb.ProximityResponse{
 			Success: false,
 			Message: "Failed to get keypoints",
+		}, h.fail(internalError("failed to get keypoints"))
+	}
+
+	nearby, distance := reachKeyPoint(execution, keypoints, req.CurrentLatitude, req.CurrentLongitude)
+	next, nextDistance := nextKeyPoint(execution, keypoints, req.CurrentLatitude, req.CurrentLongitude)
+
+	// Saving also records the tourist's last activity
+	err = h.repo.UpdateExecution(ctx, execution)
+	if errors.Is(err, repository.ErrExecutionStatusChanged) {
+		return &pb.ProximityResponse{
+			Success: false,
+			Message: "Execution is no longer active",
+		}, h.fail(aborted("execution status changed concurrently"))
+	}
+	if err != nil {
+		log.Printf("Error updating execution: %v", err)
+	}
+
+	if nearby != nil && execution.Status == models.ExecutionStatusCompleted {
+		return &pb.ProximityResponse{
+			Success:        true,
+			Message:        "All keypoints reached, tour completed",
+			NearKeyPoint:   true,
+			NearbyKeyPoint: mapKeyPointToProto(nearby),
+			Distance:       distance,
+			TourCompleted:  true,
+			Summary:        h.completionSummary(ctx, execution, keypoints),
 		}, nil
 	}
 
-	// Check proximity to each uncompleted keypoint
-	for _, kp := range keypoints {
-		// Check if already completed
-		alreadyCompleted := false
-		for _, completed := range execution.CompletedKeypoints {
-			if completed.KeypointID == kp.ID {
-				alreadyCompleted = true
-				break
-			}
-		}
+	if nearby != nil {
+		return &pb.ProximityResponse{
+			Success:              true,
+			Message:              "Near keypoint",
+			NearKeyPoint:         true,
+			NearbyKeyPoint:       mapKeyPointToProto(nearby),
+			Distance:             distance,
+			NextKeyPoint:         mapOptionalKeyPointToProto(next),
+			NextKeyPointDistance: nextDistance,
+		}, nil
+	}
 
-		if alreadyCompleted {
-			continue
-		}
+	return &pb.ProximityResponse{
+		Success:              true,
+		Message:              "No nearby keypoints",
+		NearKeyPoint:         false,
+		NextKeyPoint:         mapOptionalKeyPointToProto(next),
+		NextKeyPointDistance: nextDistance,
+	}, nil
+}
 
+// reachKeyPoint marks the first uncompleted keypoint whose proximity radius
+// covers the position as completed on execution and returns it with its
+// distance. In sequential mode only the next keypoint by Order counts.
+// Reaching the last keypoint completes the execution. It returns nil when no
+// keypoint is in range. The caller saves execution.
+func reachKeyPoint(execution *models.TourExecution, keypoints []*models.KeyPoint, latitude, longitude float64) (*models.KeyPoint, float64) {
+	for _, kp := range pendingKeyPoints(execution, keypoints) {
 		// Calculate distance
-		distance := calculateDistance(req.CurrentLatitude, req.CurrentLongitude, kp.Latitude, kp.Longitude)
+		distance := calculateDistance(latitude, longitude, kp.Latitude, kp.Longitude)
 		log.Printf("Distance check - Tourist: (%.6f, %.6f), Keypoint '%s': (%.6f, %.6f), Distance: %.2f meters",
-			req.CurrentLatitude, req.CurrentLongitude, kp.Name, kp.Latitude, kp.Longitude, distance)
+			latitude, longitude, kp.Name, kp.Latitude, kp.Longitude, distance)
 
-		// If within 50 meters
-		if distance <= 50 {
-			// Mark as completed
+		if distance <= proximityRadius(kp, execution.ProximityRadius) {
 			execution.CompletedKeypoints = append(execution.CompletedKeypoints, models.CompletedKeypoint{
 				KeypointID:  kp.ID,
 				CompletedAt: time.Now(),
 			})
-			err = h.repo.UpdateExecution(ctx, execution)
-			if err != nil {
-				log.Printf("Error updating execution: %v", err)
+			if len(pendingKeyPoints(execution, keypoints)) == 0 {
+				completeExecution(execution, keypoints)
 			}
+			return kp, distance
+		}
+	}
+	return nil, 0
+}
+
+// Proximity radii in meters. Keypoints without a radius of their own use
+// their tour's, and tours without one use defaultProximityRadius.
+const (
+	defaultProximityRadius = 50
+	minProximityRadius     = 5
+	maxProximityRadius     = 2000
+)
+
+// proximityRadius returns the distance within which kp counts as reached on
+// a tour whose own radius is tourRadius.
+func proximityRadius(kp *models.KeyPoint, tourRadius float64) float64 {
+	if kp.ProximityRadius > 0 {
+		return kp.ProximityRadius
+	}
+	return tourProximityRadius(tourRadius)
+}
+
+func tourProximityRadius(radius float64) float64 {
+	if radius > 0 {
+		return radius
+	}
+	return defaultProximityRadius
+}
+
+// proximityRadiusViolations rejects a radius outside the accepted range.
+// Zero is accepted and means the radius is inherited.
+func proximityRadiusViolations(radius float64) []*errdetails.BadRequest_FieldViolation {
+	if radius == 0 || (radius >= minProximityRadius && radius <= maxProximityRadius) {
+		return nil
+	}
+	return []*errdetails.BadRequest_FieldViolation{{
+		Field:       "proximityRadius",
+		Description: fmt.Sprintf("proximity radius must be 0 or between %d and %d meters", minProximityRadius, maxProximityRadius),
+	}}
+}
 
-			return &pb.ProximityResponse{
-				Success:        true,
-				Message:        "Near keypoint",
-				NearKeyPoint:   true,
-				NearbyKeyPoint: mapKeyPointToProto(kp),
-				Distance:       distance,
-			}, nil
+// nextKeyPoint returns the keypoint the tourist should head for and its
+// distance: the next one by Order in sequential mode, else the nearest
+// uncompleted one. It returns nil once every keypoint is completed.
+func nextKeyPoint(execution *models.TourExecution, keypoints []*models.KeyPoint, latitude, longitude float64) (*models.KeyPoint, float64) {
+	var next *models.KeyPoint
+	var nextDistance float64
+	for _, kp := range pendingKeyPoints(execution, keypoints) {
+		distance := calculateDistance(latitude, longitude, kp.Latitude, kp.Longitude)
+		if next == nil || distance < nextDistance {
+			next, nextDistance = kp, distance
 		}
 	}
+	return next, nextDistance
+}
 
-	// Update last activity
-	err = h.repo.UpdateExecution(ctx, execution)
-	if err != nil {
-		log.Printf("Error updating execution: %v", err)
+// checkExecutionActive rejects changes to an execution that has completed
+// or been abandoned; neither can be resumed.
+func checkExecutionActive(execution *models.TourExecution, executionID string) error {
+	if execution.Status == models.ExecutionStatusActive {
+		return nil
 	}
+	return failedPrecondition("EXECUTION_STATUS", "execution/"+executionID, "execution is already "+execution.Status)
+}
 
-	return &pb.ProximityResponse{
-		Success:      true,
-		Message:      "No nearby keypoints",
-		NearKeyPoint: false,
-	}, nil
+// pendingKeyPoints returns, in Order, the keypoints execution can complete
+// now: every uncompleted one in free roam, only the first in sequential mode.
+func pendingKeyPoints(execution *models.TourExecution, keypoints []*models.KeyPoint) []*models.KeyPoint {
+	var pending []*models.KeyPoint
+	for _, kp := range orderedKeyPoints(keypoints) {
+		completed := slices.ContainsFunc(execution.CompletedKeypoints, func(c models.CompletedKeypoint) bool {
+			return c.KeypointID == kp.ID
+		})
+		if completed {
+			continue
+		}
+		pending = append(pending, kp)
+		if execution.Mode == models.ExecutionModeSequential {
+			break
+		}
+	}
+	return pending
 }
 
 func (h *TourServiceHandler) CompleteTour(ctx context.Context, req *pb.CompleteExecutionRequest) (*pb.ExecutionResponse, error) {
+	if err := checkPrincipal(ctx, "touristId", req.TouristId); err != nil {
+		return &pb.ExecutionResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
 	executionID, err := primitive.ObjectIDFromHex(req.ExecutionId)
 	if err != nil {
 		return &pb.ExecutionResponse{
 			Success: false,
 			Message: "Invalid execution ID",
-		}, nil
+		}, h.fail(invalidArgument("executionId", "invalid execution ID"))
 	}
 
 	execution, err := h.repo.GetExecution(ctx, executionID)
@@ -763,41 +1679,79 @@ func (h *TourServiceHandler) CompleteTour(ctx context.Context, req *pb.CompleteE
 		return &pb.ExecutionResponse{
 			Success: false,
 			Message: "Execution not found",
-		}, nil
+		}, h.fail(lookupError(err, "execution", req.ExecutionId))
 	}
 
-	if execution.TouristID != req.TouristId {
+	if !ownsResource(ctx, execution.TouristID, req.TouristId) {
 		return &pb.ExecutionResponse{
 			Success: false,
 			Message: "Unauthorized",
-		}, nil
+		}, h.fail(permissionDenied("execution", req.ExecutionId, "execution belongs to another tourist"))
 	}
 
-	execution.Status = "completed"
-	execution.CompletedAt = time.Now()
+	if err := checkExecutionActive(execution, req.ExecutionId); err != nil {
+		return &pb.ExecutionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Cannot complete a %s execution", execution.Status),
+		}, h.fail(err)
+	}
+
+	keypoints, err := h.repo.GetKeyPointsByTourID(ctx, execution.TourID)
+	if err != nil {
+		log.Printf("Error getting keypoints: %v", err)
+		return &pb.ExecutionResponse{
+			Success: false,
+			Message: "Failed to get keypoints",
+		}, h.fail(internalError("failed to get keypoints"))
+	}
+
+	if completed, total := keyPointProgress(execution, keypoints); completed < total && !req.Partial {
+		return &pb.ExecutionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Only %d of %d keypoints reached", completed, total),
+		}, h.fail(failedPrecondition("KEYPOINTS", "execution/"+req.ExecutionId,
+			fmt.Sprintf("%d of %d keypoints reached; set partial to complete the tour anyway", completed, total)))
+	}
+
+	completeExecution(execution, keypoints)
 
 	err = h.repo.UpdateExecution(ctx, execution)
+	if errors.Is(err, repository.ErrExecutionStatusChanged) {
+		return &pb.ExecutionResponse{
+			Success: false,
+			Message: "Execution status changed, please retry",
+		}, h.fail(aborted("execution status changed concurrently"))
+	}
 	if err != nil {
+		log.Printf("Error trying to complete execution: %v", err)
 		return &pb.ExecutionResponse{
 			Success: false,
 			Message: "Failed to complete tour",
-		}, nil
+		}, h.fail(internalError("failed to complete tour"))
 	}
 
 	return &pb.ExecutionResponse{
 		Success:   true,
 		Message:   "Tour completed successfully",
 		Execution: mapExecutionToProto(execution),
+		Summary:   h.completionSummary(ctx, execution, keypoints),
 	}, nil
 }
 
 func (h *TourServiceHandler) AbandonTour(ctx context.Context, req *pb.AbandonExecutionRequest) (*pb.ExecutionResponse, error) {
+	if err := checkPrincipal(ctx, "touristId", req.TouristId); err != nil {
+		return &pb.ExecutionResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
 	executionID, err := primitive.ObjectIDFromHex(req.ExecutionId)
 	if err != nil {
 		return &pb.ExecutionResponse{
 			Success: false,
 			Message: "Invalid execution ID",
-		}, nil
+		}, h.fail(invalidArgument("executionId", "invalid execution ID"))
 	}
 
 	execution, err := h.repo.GetExecution(ctx, executionID)
@@ -805,25 +1759,39 @@ func (h *TourServiceHandler) AbandonTour(ctx context.Context, req *pb.AbandonExe
 		return &pb.ExecutionResponse{
 			Success: false,
 			Message: "Execution not found",
-		}, nil
+		}, h.fail(lookupError(err, "execution", req.ExecutionId))
 	}
 
-	if execution.TouristID != req.TouristId {
+	if !ownsResource(ctx, execution.TouristID, req.TouristId) {
 		return &pb.ExecutionResponse{
 			Success: false,
 			Message: "Unauthorized",
-		}, nil
+		}, h.fail(permissionDenied("execution", req.ExecutionId, "execution belongs to another tourist"))
+	}
+
+	if err := checkExecutionActive(execution, req.ExecutionId); err != nil {
+		return &pb.ExecutionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Cannot abandon a %s execution", execution.Status),
+		}, h.fail(err)
 	}
 
-	execution.Status = "abandoned"
+	execution.Status = models.ExecutionStatusAbandoned
 	execution.CompletedAt = time.Now()
 
 	err = h.repo.UpdateExecution(ctx, execution)
+	if errors.Is(err, repository.ErrExecutionStatusChanged) {
+		return &pb.ExecutionResponse{
+			Success: false,
+			Message: "Execution status changed, please retry",
+		}, h.fail(aborted("execution status changed concurrently"))
+	}
 	if err != nil {
+		log.Printf("Error trying to abandon execution: %v", err)
 		return &pb.ExecutionResponse{
 			Success: false,
 			Message: "Failed to abandon tour",
-		}, nil
+		}, h.fail(internalError("failed to abandon tour"))
 	}
 
 	return &pb.ExecutionResponse{
@@ -834,12 +1802,19 @@ func (h *TourServiceHandler) AbandonTour(ctx context.Context, req *pb.AbandonExe
 }
 
 func (h *TourServiceHandler) GetExecution(ctx context.Context, req *pb.GetExecutionRequest) (*pb.ExecutionResponse, error) {
+	if err := checkPrincipal(ctx, "touristId", req.TouristId); err != nil {
+		return &pb.ExecutionResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, h.fail(err)
+	}
+
 	executionID, err := primitive.ObjectIDFromHex(req.ExecutionId)
 	if err != nil {
 		return &pb.ExecutionResponse{
 			Success: false,
 			Message: "Invalid execution ID",
-		}, nil
+		}, h.fail(invalidArgument("executionId", "invalid execution ID"))
 	}
 
 	execution, err := h.repo.GetExecution(ctx, executionID)
@@ -847,14 +1822,14 @@ func (h *TourServiceHandler) GetExecution(ctx context.Context, req *pb.GetExecut
 		return &pb.ExecutionResponse{
 			Success: false,
 			Message: "Execution not found",
-		}, nil
+		}, h.fail(lookupError(err, "execution", req.ExecutionId))
 	}
 
-	if execution.TouristID != req.TouristId {
+	if !ownsResource(ctx, execution.TouristID, req.TouristId) {
 		return &pb.ExecutionResponse{
 			Success: false,
 			Message: "Unauthorized",
-		}, nil
+		}, h.fail(permissionDenied("execution", req.ExecutionId, "execution belongs to another tourist"))
 	}
 
 	return &pb.ExecutionResponse{
@@ -868,31 +1843,74 @@ func (h *TourServiceHandler) GetExecution(ctx context.Context, req *pb.GetExecut
 
 func mapTourToProto(tour *models.Tour) *pb.Tour {
 	return &pb.Tour{
-		Id:          tour.ID.Hex(),
-		GuideId:     tour.GuideID,
-		Name:        tour.Name,
-		Description: tour.Description,
-		Difficulty:  tour.Difficulty,
-		Tags:        tour.Tags,
-		Status:      tour.Status,
-		Price:       tour.Price,
-		IsPublished: tour.IsPublished,
-		PublishedAt: tour.PublishedAt.Format(time.RFC3339),
-		CreatedAt:   tour.CreatedAt.Format(time.RFC3339),
+		Id:              tour.ID.Hex(),
+		GuideId:         tour.GuideID,
+		Name:            tour.Name,
+		Description:     tour.Description,
+		Difficulty:      tour.Difficulty,
+		Tags:            tour.Tags,
+		Status:          tour.Status,
+		Price:           tour.Price,
+		IsPublished:     tour.IsPublished,
+		PublishedAt:     tour.PublishedAt.Format(time.RFC3339),
+		CreatedAt:       tour.CreatedAt.Format(time.RFC3339),
+		ArchivedAt:      tour.ArchivedAt.Format(time.RFC3339),
+		LengthKm:        tour.LengthKm,
+		TransportTimes:  mapTransportTimesToProto(tour.TransportTimes),
+		AverageRating:   tour.AverageRating,
+		ReviewCount:     tour.ReviewCount,
+		ExecutionMode:   effectiveExecutionMode(tour.ExecutionMode),
+		ProximityRadius: tourProximityRadius(tour.ProximityRadius),
+	}
+}
+
+func mapSearchHitToProto(hit *repository.TourSearchHit) *pb.TourSearchResult {
+	snippets := make([]*pb.Snippet, len(hit.Snippets))
+	for i, s := range hit.Snippets {
+		highlights := make([]*pb.Highlight, len(s.Highlights))
+		for j, h := range s.Highlights {
+			highlights[j] = &pb.Highlight{Start: int32(h.Start), End: int32(h.End)}
+		}
+		snippets[i] = &pb.Snippet{Field: s.Field, Text: s.Text, Highlights: highlights}
+	}
+	return &pb.TourSearchResult{
+		Tour:     mapTourToProto(hit.Tour),
+		Score:    hit.Score,
+		Snippets: snippets,
+	}
+}
+
+func mapViolationsToProto(violations []*errdetails.PreconditionFailure_Violation) []*pb.Violation {
+	result := make([]*pb.Violation, len(violations))
+	for i, v := range violations {
+		result[i] = &pb.Violation{
+			Field:       v.Subject,
+			Description: v.Description,
+		}
 	}
+	return result
 }
 
 func mapKeyPointToProto(kp *models.KeyPoint) *pb.KeyPoint {
 	return &pb.KeyPoint{
-		Id:          kp.ID.Hex(),
-		TourId:      kp.TourID.Hex(),
-		Latitude:    kp.Latitude,
-		Longitude:   kp.Longitude,
-		Name:        kp.Name,
-		Description: kp.Description,
-		Image:       kp.Image,
-		Order:       kp.Order,
+		Id:              kp.ID.Hex(),
+		TourId:          kp.TourID.Hex(),
+		Latitude:        kp.Latitude,
+		Longitude:       kp.Longitude,
+		Name:            kp.Name,
+		Description:     kp.Description,
+		Image:           kp.Image,
+		Order:           kp.Order,
+		ProximityRadius: kp.ProximityRadius,
+	}
+}
+
+// mapOptionalKeyPointToProto maps kp, leaving the message unset for nil.
+func mapOptionalKeyPointToProto(kp *models.KeyPoint) *pb.KeyPoint {
+	if kp == nil {
+		return nil
 	}
+	return mapKeyPointToProto(kp)
 }
 
 func mapCartToProto(cart *models.ShoppingCart) *pb.ShoppingCart {
@@ -934,8 +1952,29 @@ func mapExecutionToProto(exec *models.TourExecution) *pb.TourExecution {
 			Latitude:  exec.StartLatitude,
 			Longitude: exec.StartLongitude,
 		},
-		CompletedKeypoints: completedKps,
+		CompletedKeypoints:   completedKps,
+		Mode:                 effectiveExecutionMode(exec.Mode),
+		CompletionPercentage: exec.CompletionPercentage,
+	}
+}
+
+// coordinateViolations reports latitude and longitude outside their ranges,
+// which Mongo would refuse to index.
+func coordinateViolations(latitude, longitude float64) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if latitude < -90 || latitude > 90 {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "latitude",
+			Description: "latitude must be between -90 and 90",
+		})
+	}
+	if longitude < -180 || longitude > 180 {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "longitude",
+			Description: "longitude must be between -180 and 180",
+		})
 	}
+	return violations
 }
 
 // Haversine formula to calculate distance between two coordinates in meters
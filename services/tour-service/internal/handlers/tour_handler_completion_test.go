@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+	"tour-service/internal/auth"
+	"tour-service/internal/models"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+)
+
+// ── Automatic completion ──────────────────────────────────────────────────────
+
+func TestCheckProximity_LastKeyPoint_CompletesExecution(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution, first, second := executionWithTwoKeyPoints(mockRepo, models.ExecutionModeSequential)
+	execution.StartedAt = time.Now().Add(-time.Hour)
+	execution.CompletedKeypoints = []models.CompletedKeypoint{{KeypointID: first.ID}}
+	mockRepo.On("GetPositionSamples", mock.Anything, execution.ID).
+		Return([]*models.PositionSample{
+			{Latitude: first.Latitude, Longitude: first.Longitude},
+			{Latitude: second.Latitude, Longitude: second.Longitude},
+		}, nil)
+
+	result, err := handler.CheckProximity(contextAs("tourist123", auth.RoleTourist), &pb.CheckProximityRequest{
+		ExecutionId:      execution.ID.Hex(),
+		TouristId:        "tourist123",
+		CurrentLatitude:  second.Latitude,
+		CurrentLongitude: second.Longitude,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.TourCompleted)
+	assert.Nil(t, result.NextKeyPoint)
+	assert.Equal(t, "completed", execution.Status)
+	assert.Equal(t, 100.0, execution.CompletionPercentage)
+
+	require.NotNil(t, result.Summary)
+	assert.Equal(t, int32(2), result.Summary.CompletedKeyPoints)
+	assert.Equal(t, int32(2), result.Summary.TotalKeyPoints)
+	assert.InDelta(t, 800, result.Summary.Distance, 20)
+	assert.InDelta(t, 3600, result.Summary.DurationSeconds, 5)
+}
+
+func TestCheckProximity_KeyPointsLeft_StaysActive(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution, first, _ := executionWithTwoKeyPoints(mockRepo, models.ExecutionModeFreeRoam)
+
+	result, err := handler.CheckProximity(contextAs("tourist123", auth.RoleTourist), &pb.CheckProximityRequest{
+		ExecutionId:      execution.ID.Hex(),
+		TouristId:        "tourist123",
+		CurrentLatitude:  first.Latitude,
+		CurrentLongitude: first.Longitude,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.TourCompleted)
+	assert.Nil(t, result.Summary)
+	assert.Equal(t, "active", execution.Status)
+}
+
+// ── CompleteTour ──────────────────────────────────────────────────────────────
+
+func TestCompleteTour_KeyPointsLeft_ReturnsFailedPrecondition(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution, first, _ := executionWithTwoKeyPoints(mockRepo, models.ExecutionModeFreeRoam)
+	execution.CompletedKeypoints = []models.CompletedKeypoint{{KeypointID: first.ID}}
+
+	_, err := handler.CompleteTour(contextAs("tourist123", auth.RoleTourist), &pb.CompleteExecutionRequest{
+		ExecutionId: execution.ID.Hex(),
+		TouristId:   "tourist123",
+	})
+
+	requireStatusCode(t, err, codes.FailedPrecondition)
+	mockRepo.AssertNotCalled(t, "UpdateExecution", mock.Anything, mock.Anything)
+}
+
+func TestCompleteTour_Partial_RecordsPercentage(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution, first, _ := executionWithTwoKeyPoints(mockRepo, models.ExecutionModeFreeRoam)
+	execution.StartedAt = time.Now().Add(-30 * time.Minute)
+	execution.CompletedKeypoints = []models.CompletedKeypoint{{KeypointID: first.ID}}
+	mockRepo.On("GetPositionSamples", mock.Anything, execution.ID).
+		Return([]*models.PositionSample{}, nil)
+
+	result, err := handler.CompleteTour(contextAs("tourist123", auth.RoleTourist), &pb.CompleteExecutionRequest{
+		ExecutionId: execution.ID.Hex(),
+		TouristId:   "tourist123",
+		Partial:     true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "completed", result.Execution.Status)
+	assert.Equal(t, 50.0, result.Execution.CompletionPercentage)
+	require.NotNil(t, result.Summary)
+	assert.Equal(t, int32(1), result.Summary.CompletedKeyPoints)
+	assert.Equal(t, int32(2), result.Summary.TotalKeyPoints)
+	assert.InDelta(t, 1800, result.Summary.DurationSeconds, 5)
+}
+
+func TestKeyPointProgress_IgnoresDeletedKeyPoints(t *testing.T) {
+	tourID := primitive.NewObjectID()
+	kp := createTestKeyPoint(tourID)
+	execution := &models.TourExecution{CompletedKeypoints: []models.CompletedKeypoint{
+		{KeypointID: kp.ID},
+		{KeypointID: primitive.NewObjectID()},
+	}}
+
+	completed, total := keyPointProgress(execution, []*models.KeyPoint{kp, createTestKeyPoint(tourID)})
+
+	assert.Equal(t, 1, completed)
+	assert.Equal(t, 2, total)
+}
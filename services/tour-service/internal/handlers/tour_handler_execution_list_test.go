@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+	"tour-service/internal/auth"
+	"tour-service/internal/models"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+// ── ListExecutions ────────────────────────────────────────────────────────────
+
+func TestListExecutions_AddsTourNameAndProgress(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	first := createTestKeyPoint(tour.ID)
+	second := createTestKeyPoint(tour.ID)
+	second.Order = 2
+	walked := &models.TourExecution{
+		ID:                 primitive.NewObjectID(),
+		TouristID:          "tourist123",
+		TourID:             tour.ID,
+		Status:             models.ExecutionStatusCompleted,
+		CompletedKeypoints: []models.CompletedKeypoint{{KeypointID: first.ID}, {KeypointID: second.ID}},
+	}
+	started := &models.TourExecution{
+		ID:                 primitive.NewObjectID(),
+		TouristID:          "tourist123",
+		TourID:             tour.ID,
+		Status:             models.ExecutionStatusAbandoned,
+		CompletedKeypoints: []models.CompletedKeypoint{{KeypointID: first.ID}},
+	}
+	gone := &models.TourExecution{
+		ID:        primitive.NewObjectID(),
+		TouristID: "tourist123",
+		TourID:    primitive.NewObjectID(),
+		Status:    models.ExecutionStatusAbandoned,
+	}
+
+	mockRepo.On("ListExecutions", mock.Anything, repository.ExecutionFilter{TouristID: "tourist123"}, repository.PageRequest{}).
+		Return(&repository.ExecutionPage{
+			Executions:    []*models.TourExecution{walked, started, gone},
+			NextPageToken: "next",
+		}, nil)
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, tour.ID).
+		Return([]*models.KeyPoint{first, second}, nil).Once()
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil).Once()
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, gone.TourID).
+		Return([]*models.KeyPoint{}, nil)
+	mockRepo.On("GetTourByID", mock.Anything, gone.TourID).
+		Return(nil, mongo.ErrNoDocuments)
+
+	result, err := handler.ListExecutions(contextAs("tourist123", auth.RoleTourist), &pb.ListExecutionsRequest{
+		TouristId: "tourist123",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Executions, 3)
+	assert.Equal(t, "next", result.NextPageToken)
+
+	assert.Equal(t, tour.Name, result.Executions[0].TourName)
+	assert.Equal(t, int32(2), result.Executions[0].CompletedKeyPoints)
+	assert.Equal(t, int32(2), result.Executions[0].TotalKeyPoints)
+	assert.Equal(t, int32(1), result.Executions[1].CompletedKeyPoints)
+	assert.Empty(t, result.Executions[2].TourName)
+	assert.Zero(t, result.Executions[2].TotalKeyPoints)
+}
+
+func TestListExecutions_PassesFilters(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tourID := primitive.NewObjectID()
+	mockRepo.On("ListExecutions", mock.Anything, repository.ExecutionFilter{
+		TouristID:     "tourist123",
+		Status:        models.ExecutionStatusCompleted,
+		TourID:        tourID,
+		StartedAfter:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		StartedBefore: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+	}, repository.PageRequest{Size: 10, Token: "abc"}).
+		Return(&repository.ExecutionPage{}, nil)
+
+	result, err := handler.ListExecutions(contextAs("tourist123", auth.RoleTourist), &pb.ListExecutionsRequest{
+		TouristId:     "tourist123",
+		Status:        models.ExecutionStatusCompleted,
+		TourId:        tourID.Hex(),
+		StartedAfter:  "2026-01-01T00:00:00Z",
+		StartedBefore: "2026-02-01T00:00:00Z",
+		PageSize:      10,
+		PageToken:     "abc",
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Executions)
+}
+
+func TestListExecutions_InvalidFilters_ReturnsEveryViolation(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.ListExecutions(contextAs("tourist123", auth.RoleTourist), &pb.ListExecutionsRequest{
+		TouristId:    "tourist123",
+		Status:       "paused",
+		TourId:       "not-an-id",
+		StartedAfter: "last week",
+		PageSize:     500,
+	})
+
+	st := requireStatusCode(t, err, codes.InvalidArgument)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	var fields []string
+	for _, v := range badRequest.FieldViolations {
+		fields = append(fields, v.Field)
+	}
+	assert.ElementsMatch(t, []string{"status", "tourId", "startedAfter", "pageSize"}, fields)
+	mockRepo.AssertNotCalled(t, "ListExecutions", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestListExecutions_InvalidPageToken_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	mockRepo.On("ListExecutions", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, repository.ErrInvalidPageToken)
+
+	_, err := handler.ListExecutions(contextAs("tourist123", auth.RoleTourist), &pb.ListExecutionsRequest{
+		TouristId: "tourist123",
+		PageToken: "garbage",
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"testing"
+	"tour-service/internal/auth"
+	"tour-service/internal/models"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+)
+
+// finishedExecution returns an execution of tourist123 in status.
+func finishedExecution(mockRepo *repository.MockTourRepository, status string) *models.TourExecution {
+	execution := &models.TourExecution{
+		ID:        primitive.NewObjectID(),
+		TouristID: "tourist123",
+		TourID:    primitive.NewObjectID(),
+		Status:    status,
+	}
+	mockRepo.On("GetExecution", mock.Anything, execution.ID).
+		Return(execution, nil)
+	return execution
+}
+
+// ── Execution state transitions ───────────────────────────────────────────────
+
+func TestCompleteTour_AbandonedExecution_ReturnsFailedPrecondition(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution := finishedExecution(mockRepo, models.ExecutionStatusAbandoned)
+
+	_, err := handler.CompleteTour(contextAs("tourist123", auth.RoleTourist), &pb.CompleteExecutionRequest{
+		ExecutionId: execution.ID.Hex(),
+		TouristId:   "tourist123",
+		Partial:     true,
+	})
+
+	requireStatusCode(t, err, codes.FailedPrecondition)
+	mockRepo.AssertNotCalled(t, "UpdateExecution", mock.Anything, mock.Anything)
+}
+
+func TestAbandonTour_CompletedExecution_ReturnsFailedPrecondition(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution := finishedExecution(mockRepo, models.ExecutionStatusCompleted)
+
+	_, err := handler.AbandonTour(contextAs("tourist123", auth.RoleTourist), &pb.AbandonExecutionRequest{
+		ExecutionId: execution.ID.Hex(),
+		TouristId:   "tourist123",
+	})
+
+	requireStatusCode(t, err, codes.FailedPrecondition)
+	mockRepo.AssertNotCalled(t, "UpdateExecution", mock.Anything, mock.Anything)
+}
+
+func TestCheckProximity_FinishedExecution_ReturnsFailedPrecondition(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution := finishedExecution(mockRepo, models.ExecutionStatusAbandoned)
+
+	_, err := handler.CheckProximity(contextAs("tourist123", auth.RoleTourist), &pb.CheckProximityRequest{
+		ExecutionId:      execution.ID.Hex(),
+		TouristId:        "tourist123",
+		CurrentLatitude:  44.8176,
+		CurrentLongitude: 20.4569,
+	})
+
+	requireStatusCode(t, err, codes.FailedPrecondition)
+	mockRepo.AssertNotCalled(t, "GetKeyPointsByTourID", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "UpdateExecution", mock.Anything, mock.Anything)
+}
+
+func TestAbandonTour_SweptMeanwhile_ReturnsAborted(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution := finishedExecution(mockRepo, models.ExecutionStatusActive)
+	mockRepo.On("UpdateExecution", mock.Anything, execution).
+		Return(repository.ErrExecutionStatusChanged)
+
+	_, err := handler.AbandonTour(contextAs("tourist123", auth.RoleTourist), &pb.AbandonExecutionRequest{
+		ExecutionId: execution.ID.Hex(),
+		TouristId:   "tourist123",
+	})
+
+	requireStatusCode(t, err, codes.Aborted)
+}
+
+func TestCheckProximity_FinishedMeanwhile_ReturnsAborted(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution := finishedExecution(mockRepo, models.ExecutionStatusActive)
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, execution.TourID).
+		Return([]*models.KeyPoint{}, nil)
+	mockRepo.On("UpdateExecution", mock.Anything, execution).
+		Return(repository.ErrExecutionStatusChanged)
+
+	_, err := handler.CheckProximity(contextAs("tourist123", auth.RoleTourist), &pb.CheckProximityRequest{
+		ExecutionId: execution.ID.Hex(),
+		TouristId:   "tourist123",
+	})
+
+	requireStatusCode(t, err, codes.Aborted)
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"tour-service/internal/auth"
+	"tour-service/internal/geofile"
+	"tour-service/internal/models"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+)
+
+// exportableTour returns a tour of guide123 with two keypoints, stored out of
+// order.
+func exportableTour(mockRepo *repository.MockTourRepository) *models.Tour {
+	tour := createTestTour("guide123")
+	tour.Name = "Belgrade Fortress!"
+	first := createTestKeyPoint(tour.ID)
+	first.Name = "Gate"
+	second := createTestKeyPoint(tour.ID)
+	second.Name, second.Order = "Tower", 2
+	second.Latitude, second.Longitude = 44.8231, 20.4504
+
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, tour.ID).
+		Return([]*models.KeyPoint{second, first}, nil)
+	return tour
+}
+
+// ── ExportTour ────────────────────────────────────────────────────────────────
+
+func TestExportTour_Purchased_ExportsAllKeyPointsInOrder(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := exportableTour(mockRepo)
+	mockRepo.On("HasPurchased", mock.Anything, "tourist123", tour.ID).
+		Return(true, nil)
+
+	result, err := handler.ExportTour(contextAs("tourist123", auth.RoleTourist), &pb.ExportTourRequest{
+		TourId: tour.ID.Hex(),
+		UserId: "tourist123",
+		Format: "gpx",
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.IsPurchased)
+	assert.Equal(t, int32(2), result.KeyPointCount)
+	assert.Equal(t, "belgrade-fortress.gpx", result.FileName)
+	assert.Equal(t, "application/gpx+xml", result.ContentType)
+
+	features, err := geofile.Parse(geofile.GPX, result.Data)
+	require.NoError(t, err)
+	require.Len(t, features, 2)
+	assert.Equal(t, "Gate", features[0].Name)
+	assert.Equal(t, "Tower", features[1].Name)
+}
+
+func TestExportTour_NotPurchased_ExportsFirstKeyPointOnly(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := exportableTour(mockRepo)
+	mockRepo.On("HasPurchased", mock.Anything, "tourist123", tour.ID).
+		Return(false, nil)
+
+	result, err := handler.ExportTour(contextAs("tourist123", auth.RoleTourist), &pb.ExportTourRequest{
+		TourId: tour.ID.Hex(),
+		UserId: "tourist123",
+		Format: "geojson",
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.IsPurchased)
+	assert.Equal(t, int32(1), result.KeyPointCount)
+
+	features, err := geofile.Parse(geofile.GeoJSON, result.Data)
+	require.NoError(t, err)
+	require.Len(t, features, 1)
+	assert.Equal(t, "Gate", features[0].Name)
+}
+
+func TestExportTour_Anonymous_ExportsFirstKeyPointOnly(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := exportableTour(mockRepo)
+
+	result, err := handler.ExportTour(context.Background(), &pb.ExportTourRequest{
+		TourId: tour.ID.Hex(),
+		Format: "kml",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), result.KeyPointCount)
+	mockRepo.AssertNotCalled(t, "HasPurchased", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExportTour_Owner_ExportsAllKeyPoints(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := exportableTour(mockRepo)
+	mockRepo.On("HasPurchased", mock.Anything, "guide123", tour.ID).
+		Return(false, nil)
+
+	result, err := handler.ExportTour(contextAs("guide123", auth.RoleGuide), &pb.ExportTourRequest{
+		TourId: tour.ID.Hex(),
+		UserId: "guide123",
+		Format: "KML",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), result.KeyPointCount)
+	assert.Equal(t, "belgrade-fortress.kml", result.FileName)
+}
+
+func TestExportTour_UnknownFormat_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.ExportTour(context.Background(), &pb.ExportTourRequest{
+		TourId: primitive.NewObjectID().Hex(),
+		Format: "shp",
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+	mockRepo.AssertNotCalled(t, "GetTourByID", mock.Anything, mock.Anything)
+}
+
+func TestExportFileName(t *testing.T) {
+	assert.Equal(t, "belgrade-fortress-2.geojson", exportFileName("  Belgrade  Fortress #2 ", geofile.GeoJSON))
+	assert.Equal(t, "tour.gpx", exportFileName("Ђердап", geofile.GPX))
+}
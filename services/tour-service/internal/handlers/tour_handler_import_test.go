@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"testing"
+	"tour-service/internal/auth"
+	"tour-service/internal/models"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+const importGeoJSON = `{
+  "type": "FeatureCollection",
+  "features": [
+    {"type": "Feature", "geometry": {"type": "Point", "coordinates": [20.4504, 44.8231]},
+     "properties": {"name": "Kalemegdan", "description": "Fortress park"}},
+    {"type": "Feature", "geometry": {"type": "Point", "coordinates": [20.45, 95]},
+     "properties": {"name": "Off the map"}},
+    {"type": "Feature", "geometry": {"type": "Point", "coordinates": [20.4569, 44.8176]},
+     "properties": {"name": "Republic Square"}}
+  ]
+}`
+
+// ── ImportKeyPoints ───────────────────────────────────────────────────────────
+
+func TestImportKeyPoints_ImportsValidFeaturesAndReportsTheRest(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("ImportKeyPoints", mock.Anything, tour.ID, mock.MatchedBy(func(kps []*models.KeyPoint) bool {
+		return len(kps) == 2 && kps[0].Name == "Kalemegdan" && kps[1].Name == "Republic Square"
+	}), false).Run(func(args mock.Arguments) {
+		for i, kp := range args.Get(2).([]*models.KeyPoint) {
+			kp.ID = primitive.NewObjectID()
+			kp.Order = int32(i + 4)
+		}
+	}).Return(nil)
+	expectTourMetricsRefresh(mockRepo, tour.ID)
+
+	result, err := handler.ImportKeyPoints(contextAs("guide123", auth.RoleGuide), &pb.ImportKeyPointsRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+		Format:  "geojson",
+		Data:    []byte(importGeoJSON),
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, int32(2), result.ImportedCount)
+	assert.Equal(t, int32(1), result.RejectedCount)
+	require.Len(t, result.Features, 3)
+	assert.Equal(t, "Fortress park", result.Features[0].KeyPoint.Description)
+	assert.False(t, result.Features[1].Imported)
+	assert.Equal(t, "latitude must be between -90 and 90", result.Features[1].Reason)
+	assert.Nil(t, result.Features[1].KeyPoint)
+	assert.Equal(t, int32(5), result.Features[2].KeyPoint.Order)
+}
+
+func TestImportKeyPoints_ReplaceMode_ReplacesKeyPoints(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("ImportKeyPoints", mock.Anything, tour.ID, mock.Anything, true).
+		Return(nil)
+	expectTourMetricsRefresh(mockRepo, tour.ID)
+
+	result, err := handler.ImportKeyPoints(contextAs("guide123", auth.RoleGuide), &pb.ImportKeyPointsRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+		Format:  "GPX",
+		Data:    []byte(`<gpx><wpt lat="44.8231" lon="20.4504"><name>Kalemegdan</name></wpt></gpx>`),
+		Mode:    "replace",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), result.ImportedCount)
+	mockRepo.AssertCalled(t, "ImportKeyPoints", mock.Anything, tour.ID, mock.Anything, true)
+}
+
+func TestImportKeyPoints_InvalidRequest_ReportsEveryViolation(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.ImportKeyPoints(contextAs("guide123", auth.RoleGuide), &pb.ImportKeyPointsRequest{
+		TourId:  primitive.NewObjectID().Hex(),
+		GuideId: "guide123",
+		Format:  "shp",
+		Mode:    "merge",
+	})
+
+	st := requireStatusCode(t, err, codes.InvalidArgument)
+	badRequest := st.Details()[0].(*errdetails.BadRequest)
+	assert.Len(t, badRequest.FieldViolations, 3)
+	mockRepo.AssertNotCalled(t, "GetTourByID", mock.Anything, mock.Anything)
+}
+
+func TestImportKeyPoints_UnreadableFile_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.ImportKeyPoints(contextAs("guide123", auth.RoleGuide), &pb.ImportKeyPointsRequest{
+		TourId:  primitive.NewObjectID().Hex(),
+		GuideId: "guide123",
+		Format:  "kml",
+		Data:    []byte(`<kml><Placemark>`),
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+	mockRepo.AssertNotCalled(t, "ImportKeyPoints", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportKeyPoints_NothingImportable_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.ImportKeyPoints(contextAs("guide123", auth.RoleGuide), &pb.ImportKeyPointsRequest{
+		TourId:  primitive.NewObjectID().Hex(),
+		GuideId: "guide123",
+		Format:  "gpx",
+		Data:    []byte(`<gpx><wpt lat="91" lon="20.45"/><wpt lat="x" lon="20.45"/></gpx>`),
+		Mode:    "replace",
+	})
+
+	st := requireStatusCode(t, err, codes.InvalidArgument)
+	badRequest := st.Details()[0].(*errdetails.BadRequest)
+	require.Len(t, badRequest.FieldViolations, 2)
+	assert.Equal(t, "data.features[1]", badRequest.FieldViolations[1].Field)
+	mockRepo.AssertNotCalled(t, "ImportKeyPoints", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportKeyPoints_OtherGuide_ReturnsPermissionDenied(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide456")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+
+	_, err := handler.ImportKeyPoints(contextAs("guide123", auth.RoleGuide), &pb.ImportKeyPointsRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+		Format:  "geojson",
+		Data:    []byte(importGeoJSON),
+	})
+
+	requireStatusCode(t, err, codes.PermissionDenied)
+	mockRepo.AssertNotCalled(t, "ImportKeyPoints", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"testing"
+	"tour-service/internal/auth"
+	"tour-service/internal/models"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+)
+
+// ── AddKeyPoint ordering ──────────────────────────────────────────────────────
+
+func TestAddKeyPoint_OmittedOrder_ReturnsAssignedOrder(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("CreateKeyPoint", mock.Anything, mock.MatchedBy(func(kp *models.KeyPoint) bool {
+		return kp.Order == 0
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.KeyPoint).Order = 3
+	}).Return(nil)
+	expectTourMetricsRefresh(mockRepo, tour.ID)
+
+	result, err := handler.AddKeyPoint(contextAs("guide123", auth.RoleGuide), &pb.AddKeyPointRequest{
+		TourId:    tour.ID.Hex(),
+		GuideId:   "guide123",
+		Latitude:  44.8176,
+		Longitude: 20.4569,
+		Name:      "Fortress gate",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), result.KeyPoint.Order)
+}
+
+func TestAddKeyPoint_NegativeOrder_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.AddKeyPoint(contextAs("guide123", auth.RoleGuide), &pb.AddKeyPointRequest{
+		TourId:    primitive.NewObjectID().Hex(),
+		GuideId:   "guide123",
+		Latitude:  44.8176,
+		Longitude: 20.4569,
+		Order:     -1,
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+	mockRepo.AssertNotCalled(t, "CreateKeyPoint", mock.Anything, mock.Anything)
+}
+
+// ── UpdateKeyPoint ordering ───────────────────────────────────────────────────
+
+func TestUpdateKeyPoint_KeepsStoredOrder(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	keypointID := primitive.NewObjectID()
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("UpdateKeyPoint", mock.Anything, mock.MatchedBy(func(kp *models.KeyPoint) bool {
+		return kp.ID == keypointID && kp.Name == "Renamed"
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.KeyPoint).Order = 2
+	}).Return(nil)
+	expectTourMetricsRefresh(mockRepo, tour.ID)
+
+	result, err := handler.UpdateKeyPoint(contextAs("guide123", auth.RoleGuide), &pb.UpdateKeyPointRequest{
+		KeyPointId: keypointID.Hex(),
+		TourId:     tour.ID.Hex(),
+		GuideId:    "guide123",
+		Latitude:   44.8176,
+		Longitude:  20.4569,
+		Name:       "Renamed",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), result.KeyPoint.Order)
+}
+
+func TestUpdateKeyPoint_Missing_ReturnsNotFound(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("UpdateKeyPoint", mock.Anything, mock.Anything).
+		Return(mongo.ErrNoDocuments)
+
+	_, err := handler.UpdateKeyPoint(contextAs("guide123", auth.RoleGuide), &pb.UpdateKeyPointRequest{
+		KeyPointId: primitive.NewObjectID().Hex(),
+		TourId:     tour.ID.Hex(),
+		GuideId:    "guide123",
+		Latitude:   44.8176,
+		Longitude:  20.4569,
+	})
+
+	requireStatusCode(t, err, codes.NotFound)
+	mockRepo.AssertNotCalled(t, "SetTourMetrics", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ── ReorderKeyPoints ──────────────────────────────────────────────────────────
+
+func TestReorderKeyPoints_ReturnsKeyPointsInNewOrder(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	first := createTestKeyPoint(tour.ID)
+	second := createTestKeyPoint(tour.ID)
+	second.Order = 2
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("ReorderKeyPoints", mock.Anything, tour.ID, []primitive.ObjectID{first.ID, second.ID}).
+		Return(nil)
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, tour.ID).
+		Return([]*models.KeyPoint{first, second}, nil)
+	mockRepo.On("SetTourMetrics", mock.Anything, tour.ID, mock.Anything).
+		Return(nil)
+
+	result, err := handler.ReorderKeyPoints(contextAs("guide123", auth.RoleGuide), &pb.ReorderKeyPointsRequest{
+		TourId:      tour.ID.Hex(),
+		GuideId:     "guide123",
+		KeyPointIds: []string{first.ID.Hex(), second.ID.Hex()},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.KeyPoints, 2)
+	assert.Equal(t, first.ID.Hex(), result.KeyPoints[0].Id)
+	assert.Equal(t, int32(2), result.KeyPoints[1].Order)
+	mockRepo.AssertCalled(t, "SetTourMetrics", mock.Anything, tour.ID, mock.Anything)
+}
+
+func TestReorderKeyPoints_IncompleteSequence_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("ReorderKeyPoints", mock.Anything, tour.ID, mock.Anything).
+		Return(repository.ErrKeyPointsMismatch)
+
+	_, err := handler.ReorderKeyPoints(contextAs("guide123", auth.RoleGuide), &pb.ReorderKeyPointsRequest{
+		TourId:      tour.ID.Hex(),
+		GuideId:     "guide123",
+		KeyPointIds: []string{primitive.NewObjectID().Hex()},
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+	mockRepo.AssertNotCalled(t, "SetTourMetrics", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReorderKeyPoints_MalformedIds_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.ReorderKeyPoints(contextAs("guide123", auth.RoleGuide), &pb.ReorderKeyPointsRequest{
+		TourId:      primitive.NewObjectID().Hex(),
+		GuideId:     "guide123",
+		KeyPointIds: []string{"not-an-id"},
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+	mockRepo.AssertNotCalled(t, "ReorderKeyPoints", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReorderKeyPoints_OtherGuide_ReturnsPermissionDenied(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide456")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+
+	_, err := handler.ReorderKeyPoints(contextAs("guide123", auth.RoleGuide), &pb.ReorderKeyPointsRequest{
+		TourId:      tour.ID.Hex(),
+		GuideId:     "guide123",
+		KeyPointIds: []string{primitive.NewObjectID().Hex()},
+	})
+
+	requireStatusCode(t, err, codes.PermissionDenied)
+	mockRepo.AssertNotCalled(t, "ReorderKeyPoints", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+	"tour-service/internal/auth"
+	"tour-service/internal/models"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func createArchivedTestTour(guideID string) *models.Tour {
+	tour := createTestTour(guideID)
+	tour.Status = models.TourStatusArchived
+	tour.Price = 20.0
+	tour.ArchivedAt = time.Now()
+	return tour
+}
+
+// ── ArchiveTour ───────────────────────────────────────────────────────────────
+
+func TestArchiveTour_PublishedTour_ReturnsArchivedTour(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	tour.Status = models.TourStatusPublished
+	tour.IsPublished = true
+	archived := createArchivedTestTour("guide123")
+	archived.ID = tour.ID
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil).Once()
+	mockRepo.On("ArchiveTour", mock.Anything, tour.ID).
+		Return(nil)
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(archived, nil).Once()
+
+	result, err := handler.ArchiveTour(contextAs("guide123", auth.RoleGuide), &pb.ArchiveTourRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, models.TourStatusArchived, result.Tour.Status)
+	assert.False(t, result.Tour.IsPublished)
+}
+
+func TestArchiveTour_Draft_ReturnsFailedPrecondition(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+
+	_, err := handler.ArchiveTour(contextAs("guide123", auth.RoleGuide), &pb.ArchiveTourRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+	})
+
+	requireStatusCode(t, err, codes.FailedPrecondition)
+	mockRepo.AssertNotCalled(t, "ArchiveTour", mock.Anything, mock.Anything)
+}
+
+func TestArchiveTour_WrongGuide_ReturnsPermissionDenied(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	tour.Status = models.TourStatusPublished
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+
+	_, err := handler.ArchiveTour(contextAs("wrongguide", auth.RoleGuide), &pb.ArchiveTourRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "wrongguide",
+	})
+
+	requireStatusCode(t, err, codes.PermissionDenied)
+}
+
+func TestArchiveTour_StatusChangedConcurrently_ReturnsAborted(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	tour.Status = models.TourStatusPublished
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("ArchiveTour", mock.Anything, tour.ID).
+		Return(repository.ErrTourStatusChanged)
+
+	_, err := handler.ArchiveTour(contextAs("guide123", auth.RoleGuide), &pb.ArchiveTourRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+	})
+
+	requireStatusCode(t, err, codes.Aborted)
+}
+
+// ── ReactivateTour ────────────────────────────────────────────────────────────
+
+func TestReactivateTour_ArchivedTour_Succeeds(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createArchivedTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("ReactivateTour", mock.Anything, tour.ID).
+		Return(nil)
+
+	result, err := handler.ReactivateTour(contextAs("guide123", auth.RoleGuide), &pb.ReactivateTourRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+}
+
+func TestReactivateTour_PublishedTour_ReturnsFailedPrecondition(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	tour.Status = models.TourStatusPublished
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+
+	_, err := handler.ReactivateTour(contextAs("guide123", auth.RoleGuide), &pb.ReactivateTourRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+	})
+
+	requireStatusCode(t, err, codes.FailedPrecondition)
+	mockRepo.AssertNotCalled(t, "ReactivateTour", mock.Anything, mock.Anything)
+}
+
+// ── Archived tours elsewhere ──────────────────────────────────────────────────
+
+func TestPublishTour_ArchivedTour_ReturnsFailedPrecondition(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createArchivedTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+
+	_, err := handler.PublishTour(contextAs("guide123", auth.RoleGuide), &pb.PublishTourRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+		Price:   30.0,
+	})
+
+	requireStatusCode(t, err, codes.FailedPrecondition)
+	mockRepo.AssertNotCalled(t, "PublishTour", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAddToCart_ArchivedTour_ReturnsFailedPrecondition(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createArchivedTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+
+	_, err := handler.AddToCart(contextAs("tourist123", auth.RoleTourist), &pb.AddToCartRequest{
+		TouristId: "tourist123",
+		TourId:    tour.ID.Hex(),
+	})
+
+	requireStatusCode(t, err, codes.FailedPrecondition)
+	mockRepo.AssertNotCalled(t, "UpdateCart", mock.Anything, mock.Anything)
+}
+
+func TestStartTourExecution_ArchivedTourWithPurchase_Succeeds(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	tour.Status = models.TourStatusArchived
+	tourID := tour.ID
+	mockRepo.On("GetTourByID", mock.Anything, tourID).
+		Return(tour, nil)
+	mockRepo.On("HasPurchased", mock.Anything, "tourist123", tourID).
+		Return(true, nil)
+	mockRepo.On("GetActiveExecution", mock.Anything, "tourist123", tourID).
+		Return(nil, nil)
+	mockRepo.On("CreateExecution", mock.Anything, mock.AnythingOfType("*models.TourExecution")).
+		Return(nil)
+
+	result, err := handler.StartTourExecution(contextAs("tourist123", auth.RoleTourist), &pb.StartExecutionRequest{
+		TouristId: "tourist123",
+		TourId:    tourID.Hex(),
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+	"tour-service/internal/auth"
+	"tour-service/internal/models"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+// ── Listing ───────────────────────────────────────────────────────────────────
+
+func TestGetTours_FiltersAndPaging_ArePassedToRepository(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	minPrice, maxPrice := 0.0, 40.0
+	filter := repository.TourFilter{
+		Difficulty:    "medium",
+		GuideID:       "guide123",
+		Tags:          []string{"nature", "history"},
+		MatchAllTags:  true,
+		MinPrice:      &minPrice,
+		MaxPrice:      &maxPrice,
+		CreatedAfter:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedBefore: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+	page := repository.PageRequest{Sort: repository.SortPriceDesc, Size: 10, Token: "token-1"}
+	mockRepo.On("ListTours", mock.Anything, filter, page).
+		Return(&repository.TourPage{Tours: []*models.Tour{createTestTour("guide123")}, NextPageToken: "token-2"}, nil)
+
+	result, err := handler.GetTours(contextAs("guide123", auth.RoleGuide), &pb.GetToursRequest{
+		PublishedOnly: true,
+		PageSize:      10,
+		PageToken:     "token-1",
+		Sort:          pb.TourSort_TOUR_SORT_PRICE_DESC,
+		Difficulty:    "medium",
+		Tags:          []string{"nature", "history"},
+		MatchAllTags:  true,
+		MinPrice:      &minPrice,
+		MaxPrice:      &maxPrice,
+		GuideId:       "guide123",
+		CreatedAfter:  "2025-01-01T00:00:00Z",
+		CreatedBefore: "2025-07-01T00:00:00Z",
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, result.Tours, 1)
+	assert.Equal(t, "token-2", result.NextPageToken)
+}
+
+func TestGetTours_GuideListing_AppliesFiltersAndPaging(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	maxPrice := 40.0
+	filter := repository.TourFilter{
+		Difficulty:    "easy",
+		GuideID:       "guide123",
+		IncludeDrafts: true,
+		Tags:          []string{"nature"},
+		MaxPrice:      &maxPrice,
+		CreatedAfter:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	page := repository.PageRequest{Sort: repository.SortName, Size: 5, Token: "token-1"}
+	draft := createTestTour("guide123")
+	mockRepo.On("ListTours", mock.Anything, filter, page).
+		Return(&repository.TourPage{Tours: []*models.Tour{draft}, NextPageToken: "token-2"}, nil)
+
+	result, err := handler.GetTours(contextAs("guide123", auth.RoleGuide), &pb.GetToursRequest{
+		UserId:       "guide123",
+		PageSize:     5,
+		PageToken:    "token-1",
+		Sort:         pb.TourSort_TOUR_SORT_NAME,
+		Difficulty:   "easy",
+		Tags:         []string{"nature"},
+		MaxPrice:     &maxPrice,
+		CreatedAfter: "2025-01-01T00:00:00Z",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Tours, 1)
+	assert.Equal(t, draft.ID.Hex(), result.Tours[0].Id)
+	assert.Equal(t, "token-2", result.NextPageToken)
+	mockRepo.AssertNotCalled(t, "GetToursByGuideID", mock.Anything, mock.Anything)
+}
+
+func TestGetTours_GuideListing_OtherGuideID_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.GetTours(contextAs("guide123", auth.RoleGuide), &pb.GetToursRequest{
+		UserId:  "guide123",
+		GuideId: "guide456",
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+	mockRepo.AssertNotCalled(t, "ListTours", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetTours_GuideListing_OtherUser_ReturnsPermissionDenied(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.GetTours(contextAs("guide456", auth.RoleGuide), &pb.GetToursRequest{UserId: "guide123"})
+
+	requireStatusCode(t, err, codes.PermissionDenied)
+	mockRepo.AssertNotCalled(t, "ListTours", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetTours_InvalidQuery_ListsEveryViolation(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	minPrice, maxPrice := 50.0, 10.0
+	_, err := handler.GetTours(context.Background(), &pb.GetToursRequest{
+		PublishedOnly: true,
+		PageSize:      -1,
+		Difficulty:    "extreme",
+		MinPrice:      &minPrice,
+		MaxPrice:      &maxPrice,
+		CreatedAfter:  "yesterday",
+	})
+
+	st := requireStatusCode(t, err, codes.InvalidArgument)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	var fields []string
+	for _, v := range badRequest.FieldViolations {
+		fields = append(fields, v.Field)
+	}
+	assert.ElementsMatch(t, []string{"pageSize", "difficulty", "minPrice", "createdAfter"}, fields)
+	mockRepo.AssertNotCalled(t, "ListTours", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetTours_PageSizeAboveLimit_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.GetTours(context.Background(), &pb.GetToursRequest{
+		PublishedOnly: true,
+		PageSize:      repository.MaxPageSize + 1,
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+	mockRepo.AssertNotCalled(t, "ListTours", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetTours_InvalidPageToken_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	page := repository.PageRequest{Token: "stale"}
+	mockRepo.On("ListTours", mock.Anything, repository.TourFilter{}, page).
+		Return(nil, repository.ErrInvalidPageToken)
+
+	_, err := handler.GetTours(context.Background(), &pb.GetToursRequest{PublishedOnly: true, PageToken: "stale"})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"tour-service/internal/auth"
+	"tour-service/internal/models"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ── Helpers ───────────────────────────────────────────────────────────────────
+
+func contextAs(userID, role string) context.Context {
+	return auth.NewContext(context.Background(), &auth.Principal{UserID: userID, Role: role})
+}
+
+// authorizeCall runs the service policy for method and, if allowed, invokes call.
+func authorizeCall(ctx context.Context, method string, call func(ctx context.Context) (any, error)) (any, error) {
+	interceptor := auth.NewAuthorizer(Policy).Unary()
+	return interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, _ any) (any, error) { return call(ctx) })
+}
+
+// ── Policy table ──────────────────────────────────────────────────────────────
+
+func TestPolicy_CoversEveryTourServiceMethod(t *testing.T) {
+	desc := pb.TourService_ServiceDesc
+	for _, m := range desc.Methods {
+		_, ok := Policy["/"+desc.ServiceName+"/"+m.MethodName]
+		assert.True(t, ok, "missing policy for %s", m.MethodName)
+	}
+	for _, s := range desc.Streams {
+		_, ok := Policy["/"+desc.ServiceName+"/"+s.StreamName]
+		assert.True(t, ok, "missing policy for %s", s.StreamName)
+	}
+}
+
+func TestPolicy_TouristCannotCreateTour(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := authorizeCall(contextAs("tourist123", auth.RoleTourist), pb.TourService_CreateTour_FullMethodName,
+		func(ctx context.Context) (any, error) {
+			return handler.CreateTour(ctx, &pb.CreateTourRequest{GuideId: "tourist123", Name: "Fake"})
+		})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	mockRepo.AssertNotCalled(t, "CreateTour", mock.Anything, mock.Anything)
+}
+
+func TestPolicy_GuideCannotCheckout(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := authorizeCall(contextAs("guide123", auth.RoleGuide), pb.TourService_Checkout_FullMethodName,
+		func(ctx context.Context) (any, error) {
+			return handler.Checkout(ctx, &pb.CheckoutRequest{TouristId: "guide123"})
+		})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	mockRepo.AssertNotCalled(t, "GetOrCreateCart", mock.Anything, mock.Anything)
+}
+
+func TestPolicy_AnonymousCannotCreateTour(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := authorizeCall(context.Background(), pb.TourService_CreateTour_FullMethodName,
+		func(ctx context.Context) (any, error) {
+			return handler.CreateTour(ctx, &pb.CreateTourRequest{GuideId: "guide123", Name: "Fake"})
+		})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	mockRepo.AssertNotCalled(t, "CreateTour", mock.Anything, mock.Anything)
+}
+
+func TestPolicy_AnonymousCanListTours(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	mockRepo.On("ListTours", mock.Anything, mock.Anything, mock.Anything).
+		Return(&repository.TourPage{}, nil)
+
+	_, err := authorizeCall(context.Background(), pb.TourService_GetTours_FullMethodName,
+		func(ctx context.Context) (any, error) {
+			return handler.GetTours(ctx, &pb.GetToursRequest{PublishedOnly: true})
+		})
+
+	assert.NoError(t, err)
+}
+
+func TestPolicy_GuideCanCreateTour(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	mockRepo.On("CreateTour", mock.Anything, mock.AnythingOfType("*models.Tour")).
+		Return(nil)
+
+	result, err := authorizeCall(contextAs("guide123", auth.RoleGuide), pb.TourService_CreateTour_FullMethodName,
+		func(ctx context.Context) (any, error) {
+			return handler.CreateTour(ctx, &pb.CreateTourRequest{GuideId: "guide123", Name: "Fortress walk"})
+		})
+
+	require.NoError(t, err)
+	assert.True(t, result.(*pb.TourResponse).Success)
+}
+
+func TestPolicy_AnyoneCanBrowseTours(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	mockRepo.On("ListTours", mock.Anything, repository.TourFilter{}, repository.PageRequest{}).
+		Return(&repository.TourPage{}, nil)
+
+	_, err := authorizeCall(contextAs("tourist123", auth.RoleTourist), pb.TourService_GetTours_FullMethodName,
+		func(ctx context.Context) (any, error) {
+			return handler.GetTours(ctx, &pb.GetToursRequest{PublishedOnly: true})
+		})
+
+	assert.NoError(t, err)
+}
+
+// ── Admin override ────────────────────────────────────────────────────────────
+
+func TestPublishTour_AdminOverridesOwnership(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, tour.ID).
+		Return([]*models.KeyPoint{createTestKeyPoint(tour.ID), createTestKeyPoint(tour.ID)}, nil)
+	mockRepo.On("PublishTour", mock.Anything, tour.ID, 30.0).
+		Return(nil)
+
+	result, err := authorizeCall(contextAs("admin1", auth.RoleAdmin), pb.TourService_PublishTour_FullMethodName,
+		func(ctx context.Context) (any, error) {
+			return handler.PublishTour(ctx, &pb.PublishTourRequest{TourId: tour.ID.Hex(), GuideId: "guide123", Price: 30.0})
+		})
+
+	require.NoError(t, err)
+	assert.True(t, result.(*pb.TourResponse).Success)
+}
+
+func TestDeleteKeyPoint_OtherGuide_ReturnsPermissionDenied(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+
+	_, err := authorizeCall(contextAs("guide999", auth.RoleGuide), pb.TourService_DeleteKeyPoint_FullMethodName,
+		func(ctx context.Context) (any, error) {
+			return handler.DeleteKeyPoint(ctx, &pb.DeleteKeyPointRequest{
+				KeyPointId: createTestKeyPoint(tour.ID).ID.Hex(),
+				TourId:     tour.ID.Hex(),
+				GuideId:    "guide999",
+			})
+		})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	mockRepo.AssertNotCalled(t, "DeleteKeyPoint", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetExecution_AdminCanInspectAnyExecution(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution := &models.TourExecution{ID: primitive.NewObjectID(), TouristID: "tourist123", Status: "active"}
+	mockRepo.On("GetExecution", mock.Anything, execution.ID).
+		Return(execution, nil)
+
+	result, err := authorizeCall(contextAs("admin1", auth.RoleAdmin), pb.TourService_GetExecution_FullMethodName,
+		func(ctx context.Context) (any, error) {
+			return handler.GetExecution(ctx, &pb.GetExecutionRequest{ExecutionId: execution.ID.Hex(), TouristId: "admin1"})
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, "tourist123", result.(*pb.ExecutionResponse).Execution.TouristId)
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+	"tour-service/internal/auth"
+	"tour-service/internal/models"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+// ── Publish validation ────────────────────────────────────────────────────────
+
+func TestPublishTour_IncompleteTour_ListsEveryViolation(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	tour.Description = ""
+	tour.Difficulty = "extreme"
+	tour.Tags = []string{"nature"}
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, tour.ID).
+		Return([]*models.KeyPoint{createTestKeyPoint(tour.ID)}, nil)
+
+	result, err := handler.PublishTour(contextAs("guide123", auth.RoleGuide), &pb.PublishTourRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+		Price:   -5.0,
+	})
+
+	st := requireStatusCode(t, err, codes.FailedPrecondition)
+	require.Len(t, st.Details(), 1)
+	failure, ok := st.Details()[0].(*errdetails.PreconditionFailure)
+	require.True(t, ok)
+
+	var subjects []string
+	for _, v := range failure.Violations {
+		subjects = append(subjects, v.Subject)
+	}
+	assert.ElementsMatch(t, []string{"description", "difficulty", "tags", "keyPoints", "price"}, subjects)
+	assert.Len(t, result.Violations, 5)
+	mockRepo.AssertNotCalled(t, "PublishTour", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPublishTour_IncompleteTour_LegacyEnvelopeCarriesViolations(t *testing.T) {
+	handler, mockRepo := newTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, tour.ID).
+		Return([]*models.KeyPoint{}, nil)
+
+	result, err := handler.PublishTour(contextAs("guide123", auth.RoleGuide), &pb.PublishTourRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+		Price:   25.0,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, "keyPoints", result.Violations[0].Field)
+}
+
+func TestPublishTour_FreeTour_IsAllowed(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, tour.ID).
+		Return([]*models.KeyPoint{createTestKeyPoint(tour.ID), createTestKeyPoint(tour.ID)}, nil)
+	mockRepo.On("PublishTour", mock.Anything, tour.ID, 0.0).
+		Return(nil)
+
+	result, err := handler.PublishTour(contextAs("guide123", auth.RoleGuide), &pb.PublishTourRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+}
+
+func TestPublishTour_KeyPointsUnavailable_ReturnsInternal(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, tour.ID).
+		Return(nil, errors.New("database error"))
+
+	_, err := handler.PublishTour(contextAs("guide123", auth.RoleGuide), &pb.PublishTourRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+		Price:   25.0,
+	})
+
+	requireStatusCode(t, err, codes.Internal)
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"testing"
+	"tour-service/internal/auth"
+	"tour-service/internal/models"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// ── Proximity radius ──────────────────────────────────────────────────────────
+
+func TestCheckProximity_WithinTourRadius_CompletesKeyPoint(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	// The second keypoint is about 800 m from the first
+	execution, first, _ := executionWithTwoKeyPoints(mockRepo, models.ExecutionModeFreeRoam)
+	execution.ProximityRadius = 1000
+
+	result, err := handler.CheckProximity(contextAs("tourist123", auth.RoleTourist), &pb.CheckProximityRequest{
+		ExecutionId:      execution.ID.Hex(),
+		TouristId:        "tourist123",
+		CurrentLatitude:  44.8206,
+		CurrentLongitude: 20.4569,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.NearKeyPoint)
+	assert.Equal(t, first.ID.Hex(), result.NearbyKeyPoint.Id)
+}
+
+func TestCheckProximity_KeyPointRadiusOverridesTour(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution, first, second := executionWithTwoKeyPoints(mockRepo, models.ExecutionModeFreeRoam)
+	execution.ProximityRadius = 1000
+	first.ProximityRadius = 10
+	second.ProximityRadius = 10
+
+	// About 330 m from the first keypoint
+	result, err := handler.CheckProximity(contextAs("tourist123", auth.RoleTourist), &pb.CheckProximityRequest{
+		ExecutionId:      execution.ID.Hex(),
+		TouristId:        "tourist123",
+		CurrentLatitude:  44.8206,
+		CurrentLongitude: 20.4569,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.NearKeyPoint)
+	assert.Empty(t, execution.CompletedKeypoints)
+}
+
+func TestStartTourExecution_KeepsRadiusOfTour(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	tour.ProximityRadius = 120
+	mockRepo.On("HasPurchased", mock.Anything, "tourist123", tour.ID).
+		Return(true, nil)
+	mockRepo.On("GetActiveExecution", mock.Anything, "tourist123", tour.ID).
+		Return(nil, nil)
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("CreateExecution", mock.Anything, mock.MatchedBy(func(execution *models.TourExecution) bool {
+		return execution.ProximityRadius == 120
+	})).Return(nil)
+
+	_, err := handler.StartTourExecution(contextAs("tourist123", auth.RoleTourist), &pb.StartExecutionRequest{
+		TouristId: "tourist123",
+		TourId:    tour.ID.Hex(),
+	})
+
+	require.NoError(t, err)
+}
+
+func TestGetKeyPoints_SetsEffectiveRadius(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	tour.ProximityRadius = 80
+	inherited := createTestKeyPoint(tour.ID)
+	overridden := createTestKeyPoint(tour.ID)
+	overridden.Order, overridden.ProximityRadius = 2, 15
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, tour.ID).
+		Return([]*models.KeyPoint{inherited, overridden}, nil)
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("HasPurchased", mock.Anything, "guide123", tour.ID).
+		Return(false, nil)
+	mockRepo.On("GetActiveExecution", mock.Anything, "guide123", tour.ID).
+		Return(nil, mongo.ErrNoDocuments)
+
+	result, err := handler.GetKeyPoints(contextAs("guide123", auth.RoleGuide), &pb.GetKeyPointsRequest{
+		TourId: tour.ID.Hex(),
+		UserId: "guide123",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.KeyPoints, 2)
+	assert.Zero(t, result.KeyPoints[0].ProximityRadius)
+	assert.Equal(t, 80.0, result.KeyPoints[0].EffectiveProximityRadius)
+	assert.Equal(t, 15.0, result.KeyPoints[1].ProximityRadius)
+	assert.Equal(t, 15.0, result.KeyPoints[1].EffectiveProximityRadius)
+}
+
+func TestGetKeyPoints_ActiveExecution_ReportsStartingRadius(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	tour.ProximityRadius = 80
+	inherited := createTestKeyPoint(tour.ID)
+	overridden := createTestKeyPoint(tour.ID)
+	overridden.Order, overridden.ProximityRadius = 2, 15
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, tour.ID).
+		Return([]*models.KeyPoint{inherited, overridden}, nil)
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("HasPurchased", mock.Anything, "tourist123", tour.ID).
+		Return(true, nil)
+	mockRepo.On("GetActiveExecution", mock.Anything, "tourist123", tour.ID).
+		Return(&models.TourExecution{TouristID: "tourist123", TourID: tour.ID, ProximityRadius: 120}, nil)
+
+	result, err := handler.GetKeyPoints(contextAs("tourist123", auth.RoleTourist), &pb.GetKeyPointsRequest{
+		TourId: tour.ID.Hex(),
+		UserId: "tourist123",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.KeyPoints, 2)
+	assert.Equal(t, 120.0, result.KeyPoints[0].EffectiveProximityRadius)
+	assert.Equal(t, 15.0, result.KeyPoints[1].EffectiveProximityRadius)
+}
+
+func TestCreateTour_NoRadius_ReturnsDefault(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	mockRepo.On("CreateTour", mock.Anything, mock.AnythingOfType("*models.Tour")).
+		Return(nil)
+
+	result, err := handler.CreateTour(contextAs("guide123", auth.RoleGuide), &pb.CreateTourRequest{
+		GuideId: "guide123",
+		Name:    "Test Tour",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, float64(defaultProximityRadius), result.Tour.ProximityRadius)
+}
+
+func TestCreateTour_RadiusOutOfRange_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.CreateTour(contextAs("guide123", auth.RoleGuide), &pb.CreateTourRequest{
+		GuideId:         "guide123",
+		Name:            "Test Tour",
+		ProximityRadius: 2,
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+	mockRepo.AssertNotCalled(t, "CreateTour", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTour_ProximityRadius(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("UpdateTour", mock.Anything, tour).
+		Return(nil)
+
+	result, err := handler.UpdateTour(contextAs("guide123", auth.RoleGuide), &pb.UpdateTourRequest{
+		TourId:          tour.ID.Hex(),
+		GuideId:         "guide123",
+		ProximityRadius: 200,
+		UpdateMask:      &fieldmaskpb.FieldMask{Paths: []string{"proximityRadius"}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 200.0, result.Tour.ProximityRadius)
+}
+
+func TestAddKeyPoint_NegativeRadius_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.AddKeyPoint(contextAs("guide123", auth.RoleGuide), &pb.AddKeyPointRequest{
+		TourId:          primitive.NewObjectID().Hex(),
+		GuideId:         "guide123",
+		Latitude:        44.8176,
+		Longitude:       20.4569,
+		ProximityRadius: -20,
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+	mockRepo.AssertNotCalled(t, "CreateKeyPoint", mock.Anything, mock.Anything)
+}
+
+func TestUpdateKeyPoint_RadiusTooLarge_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.UpdateKeyPoint(contextAs("guide123", auth.RoleGuide), &pb.UpdateKeyPointRequest{
+		KeyPointId:      primitive.NewObjectID().Hex(),
+		TourId:          primitive.NewObjectID().Hex(),
+		GuideId:         "guide123",
+		Latitude:        44.8176,
+		Longitude:       20.4569,
+		ProximityRadius: 5000,
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+	mockRepo.AssertNotCalled(t, "UpdateKeyPoint", mock.Anything, mock.Anything)
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"testing"
 	"time"
+	"tour-service/internal/auth"
 	"tour-service/internal/models"
+	"tour-service/internal/repository"
 	pb "tour-service/proto"
 
 	"github.com/stretchr/testify/assert"
@@ -69,7 +71,7 @@ func TestGetMyTours_ValidGuide_ReturnsTours(t *testing.T) {
 		Return(tours, nil)
 
 	req := &pb.GetMyToursRequest{GuideId: "guide123"}
-	result, err := handler.GetMyTours(context.Background(), req)
+	result, err := handler.GetMyTours(contextAs("guide123", auth.RoleGuide), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -83,7 +85,7 @@ func TestGetMyTours_RepositoryError_ReturnsFailure(t *testing.T) {
 		Return(nil, errors.New("database error"))
 
 	req := &pb.GetMyToursRequest{GuideId: "guide123"}
-	result, err := handler.GetMyTours(context.Background(), req)
+	result, err := handler.GetMyTours(contextAs("guide123", auth.RoleGuide), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -103,7 +105,7 @@ func TestUpdatePosition_ValidRequest_ReturnsSuccess(t *testing.T) {
 		Longitude: 20.4569,
 	}
 
-	result, err := handler.UpdatePosition(context.Background(), req)
+	result, err := handler.UpdatePosition(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -123,7 +125,7 @@ func TestUpdatePosition_RepositoryError_ReturnsFailure(t *testing.T) {
 		Longitude: 20.4569,
 	}
 
-	result, err := handler.UpdatePosition(context.Background(), req)
+	result, err := handler.UpdatePosition(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -145,7 +147,7 @@ func TestGetCurrentPosition_ValidRequest_ReturnsSuccess(t *testing.T) {
 		Return(position, nil)
 
 	req := &pb.GetPositionRequest{TouristId: "tourist123"}
-	result, err := handler.GetCurrentPosition(context.Background(), req)
+	result, err := handler.GetCurrentPosition(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -159,7 +161,7 @@ func TestGetCurrentPosition_NotFound_ReturnsFailure(t *testing.T) {
 		Return(nil, errors.New("not found"))
 
 	req := &pb.GetPositionRequest{TouristId: "tourist123"}
-	result, err := handler.GetCurrentPosition(context.Background(), req)
+	result, err := handler.GetCurrentPosition(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -172,43 +174,30 @@ func TestCheckout_ValidCart_ReturnsSuccess(t *testing.T) {
 	handler, mockRepo := newTestHandler()
 
 	tourID := primitive.NewObjectID()
-	cart := &models.ShoppingCart{
-		TouristID: "tourist123",
-		Items: []models.CartItem{
-			{TourID: tourID, TourName: "Test Tour", Price: 25.0},
-		},
-		TotalPrice: 25.0,
+	tokens := []*models.PurchaseToken{
+		{ID: primitive.NewObjectID(), TouristID: "tourist123", TourID: tourID, Token: "token-1", PurchasedAt: time.Now()},
 	}
 
-	mockRepo.On("GetOrCreateCart", mock.Anything, "tourist123").
-		Return(cart, nil)
-	mockRepo.On("CreatePurchaseToken", mock.Anything, mock.AnythingOfType("*models.PurchaseToken")).
-		Return(nil)
-	mockRepo.On("ClearCart", mock.Anything, "tourist123").
-		Return(nil)
+	mockRepo.On("CheckoutCart", mock.Anything, "tourist123", "").
+		Return(&repository.CheckoutResult{Tokens: tokens}, nil)
 
 	req := &pb.CheckoutRequest{TouristId: "tourist123"}
-	result, err := handler.Checkout(context.Background(), req)
+	result, err := handler.Checkout(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
 	assert.Len(t, result.Tokens, 1)
+	assert.Equal(t, tourID.Hex(), result.Tokens[0].TourId)
 }
 
 func TestCheckout_EmptyCart_ReturnsFailure(t *testing.T) {
 	handler, mockRepo := newTestHandler()
 
-	cart := &models.ShoppingCart{
-		TouristID:  "tourist123",
-		Items:      []models.CartItem{},
-		TotalPrice: 0,
-	}
-
-	mockRepo.On("GetOrCreateCart", mock.Anything, "tourist123").
-		Return(cart, nil)
+	mockRepo.On("CheckoutCart", mock.Anything, "tourist123", "").
+		Return(nil, repository.ErrCartEmpty)
 
 	req := &pb.CheckoutRequest{TouristId: "tourist123"}
-	result, err := handler.Checkout(context.Background(), req)
+	result, err := handler.Checkout(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -218,11 +207,11 @@ func TestCheckout_EmptyCart_ReturnsFailure(t *testing.T) {
 func TestCheckout_RepositoryError_ReturnsFailure(t *testing.T) {
 	handler, mockRepo := newTestHandler()
 
-	mockRepo.On("GetOrCreateCart", mock.Anything, "tourist123").
+	mockRepo.On("CheckoutCart", mock.Anything, "tourist123", "").
 		Return(nil, errors.New("database error"))
 
 	req := &pb.CheckoutRequest{TouristId: "tourist123"}
-	result, err := handler.Checkout(context.Background(), req)
+	result, err := handler.Checkout(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -243,15 +232,19 @@ func TestCompleteTour_ValidRequest_ReturnsSuccess(t *testing.T) {
 
 	mockRepo.On("GetExecution", mock.Anything, executionID).
 		Return(execution, nil)
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, execution.TourID).
+		Return([]*models.KeyPoint{}, nil)
 	mockRepo.On("UpdateExecution", mock.Anything, mock.AnythingOfType("*models.TourExecution")).
 		Return(nil)
+	mockRepo.On("GetPositionSamples", mock.Anything, executionID).
+		Return([]*models.PositionSample{}, nil)
 
 	req := &pb.CompleteExecutionRequest{
 		ExecutionId: executionID.Hex(),
 		TouristId:   "tourist123",
 	}
 
-	result, err := handler.CompleteTour(context.Background(), req)
+	result, err := handler.CompleteTour(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -268,7 +261,7 @@ func TestCompleteTour_ExecutionNotFound_ReturnsFailure(t *testing.T) {
 		TouristId:   "tourist123",
 	}
 
-	result, err := handler.CompleteTour(context.Background(), req)
+	result, err := handler.CompleteTour(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -292,7 +285,7 @@ func TestCompleteTour_WrongTourist_ReturnsUnauthorized(t *testing.T) {
 		TouristId:   "differenttourist",
 	}
 
-	result, err := handler.CompleteTour(context.Background(), req)
+	result, err := handler.CompleteTour(contextAs("differenttourist", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -322,7 +315,7 @@ func TestAbandonTour_ValidRequest_ReturnsSuccess(t *testing.T) {
 		TouristId:   "tourist123",
 	}
 
-	result, err := handler.AbandonTour(context.Background(), req)
+	result, err := handler.AbandonTour(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -346,7 +339,7 @@ func TestAbandonTour_WrongTourist_ReturnsUnauthorized(t *testing.T) {
 		TouristId:   "differenttourist",
 	}
 
-	result, err := handler.AbandonTour(context.Background(), req)
+	result, err := handler.AbandonTour(contextAs("differenttourist", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -386,6 +379,8 @@ func TestCheckProximity_NearKeypoint_ReturnsNear(t *testing.T) {
 		Return(keypoints, nil)
 	mockRepo.On("UpdateExecution", mock.Anything, mock.AnythingOfType("*models.TourExecution")).
 		Return(nil)
+	mockRepo.On("GetPositionSamples", mock.Anything, executionID).
+		Return([]*models.PositionSample{}, nil)
 
 	req := &pb.CheckProximityRequest{
 		ExecutionId:      executionID.Hex(),
@@ -394,7 +389,7 @@ func TestCheckProximity_NearKeypoint_ReturnsNear(t *testing.T) {
 		CurrentLongitude: 20.4569,
 	}
 
-	result, err := handler.CheckProximity(context.Background(), req)
+	result, err := handler.CheckProximity(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -440,7 +435,7 @@ func TestCheckProximity_FarFromKeypoint_ReturnsNotNear(t *testing.T) {
 		CurrentLongitude: 19.8335,
 	}
 
-	result, err := handler.CheckProximity(context.Background(), req)
+	result, err := handler.CheckProximity(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -460,7 +455,7 @@ func TestCheckProximity_ExecutionNotFound_ReturnsFailure(t *testing.T) {
 		CurrentLongitude: 20.4569,
 	}
 
-	result, err := handler.CheckProximity(context.Background(), req)
+	result, err := handler.CheckProximity(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -486,7 +481,7 @@ func TestCheckProximity_WrongTourist_ReturnsUnauthorized(t *testing.T) {
 		CurrentLongitude: 20.4569,
 	}
 
-	result, err := handler.CheckProximity(context.Background(), req)
+	result, err := handler.CheckProximity(contextAs("differenttourist", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -517,7 +512,7 @@ func TestRemoveFromCart_ValidRequest_ReturnsSuccess(t *testing.T) {
 		TourId:    tourID.Hex(),
 	}
 
-	result, err := handler.RemoveFromCart(context.Background(), req)
+	result, err := handler.RemoveFromCart(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -531,7 +526,7 @@ func TestRemoveFromCart_InvalidTourId_ReturnsFailure(t *testing.T) {
 		TourId:    "invalid-id",
 	}
 
-	result, err := handler.RemoveFromCart(context.Background(), req)
+	result, err := handler.RemoveFromCart(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -552,7 +547,7 @@ func TestGetCart_ValidRequest_ReturnsSuccess(t *testing.T) {
 		Return(cart, nil)
 
 	req := &pb.GetCartRequest{TouristId: "tourist123"}
-	result, err := handler.GetCart(context.Background(), req)
+	result, err := handler.GetCart(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -565,7 +560,7 @@ func TestGetCart_RepositoryError_ReturnsFailure(t *testing.T) {
 		Return(nil, errors.New("database error"))
 
 	req := &pb.GetCartRequest{TouristId: "tourist123"}
-	result, err := handler.GetCart(context.Background(), req)
+	result, err := handler.GetCart(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -583,8 +578,9 @@ func TestDeleteKeyPoint_ValidRequest_ReturnsSuccess(t *testing.T) {
 
 	mockRepo.On("GetTourByID", mock.Anything, tourID).
 		Return(tour, nil)
-	mockRepo.On("DeleteKeyPoint", mock.Anything, keypointID).
+	mockRepo.On("DeleteKeyPoint", mock.Anything, tourID, keypointID).
 		Return(nil)
+	expectTourMetricsRefresh(mockRepo, tourID)
 
 	req := &pb.DeleteKeyPointRequest{
 		TourId:     tourID.Hex(),
@@ -592,7 +588,7 @@ func TestDeleteKeyPoint_ValidRequest_ReturnsSuccess(t *testing.T) {
 		GuideId:    "guide123",
 	}
 
-	result, err := handler.DeleteKeyPoint(context.Background(), req)
+	result, err := handler.DeleteKeyPoint(contextAs("guide123", auth.RoleGuide), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -614,7 +610,7 @@ func TestDeleteKeyPoint_WrongGuide_ReturnsUnauthorized(t *testing.T) {
 		GuideId:    "differentguide",
 	}
 
-	result, err := handler.DeleteKeyPoint(context.Background(), req)
+	result, err := handler.DeleteKeyPoint(contextAs("differentguide", auth.RoleGuide), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
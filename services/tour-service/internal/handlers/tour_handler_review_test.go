@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+	"tour-service/internal/auth"
+	"tour-service/internal/models"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+func createTestReview(touristID string) *models.Review {
+	return &models.Review{
+		ID:        primitive.NewObjectID(),
+		TourID:    primitive.NewObjectID(),
+		TouristID: touristID,
+		Rating:    3,
+		Comment:   "Nice walk",
+		VisitDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func validCreateReviewRequest(tourID primitive.ObjectID) *pb.CreateReviewRequest {
+	return &pb.CreateReviewRequest{
+		TourId:    tourID.Hex(),
+		TouristId: "tourist123",
+		Rating:    4,
+		Comment:   "Great views from the fortress",
+		VisitDate: "2026-05-01",
+		Images:    []string{"https://example.com/fortress.jpg"},
+	}
+}
+
+// ── CreateReview ──────────────────────────────────────────────────────────────
+
+func TestCreateReview_CompletedTour_CreatesReview(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tourID := primitive.NewObjectID()
+	mockRepo.On("HasPurchased", mock.Anything, "tourist123", tourID).
+		Return(true, nil)
+	mockRepo.On("HasReviewableExecution", mock.Anything, "tourist123", tourID, mock.AnythingOfType("time.Time")).
+		Return(true, nil)
+	mockRepo.On("CreateReview", mock.Anything, mock.MatchedBy(func(r *models.Review) bool {
+		return r.TourID == tourID && r.TouristID == "tourist123" && r.Rating == 4
+	})).Return(nil)
+
+	result, err := handler.CreateReview(contextAs("tourist123", auth.RoleTourist), validCreateReviewRequest(tourID))
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, int32(4), result.Review.Rating)
+	assert.Equal(t, "2026-05-01", result.Review.VisitDate)
+	assert.Len(t, result.Review.Images, 1)
+}
+
+func TestCreateReview_NotPurchased_ReturnsFailedPrecondition(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tourID := primitive.NewObjectID()
+	mockRepo.On("HasPurchased", mock.Anything, "tourist123", tourID).
+		Return(false, nil)
+
+	_, err := handler.CreateReview(contextAs("tourist123", auth.RoleTourist), validCreateReviewRequest(tourID))
+
+	requireStatusCode(t, err, codes.FailedPrecondition)
+	mockRepo.AssertNotCalled(t, "CreateReview", mock.Anything, mock.Anything)
+}
+
+func TestCreateReview_NoRecentExecution_ReturnsFailedPrecondition(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tourID := primitive.NewObjectID()
+	mockRepo.On("HasPurchased", mock.Anything, "tourist123", tourID).
+		Return(true, nil)
+	mockRepo.On("HasReviewableExecution", mock.Anything, "tourist123", tourID, mock.MatchedBy(func(since time.Time) bool {
+		return time.Since(since) >= reviewActivityWindow
+	})).Return(false, nil)
+
+	_, err := handler.CreateReview(contextAs("tourist123", auth.RoleTourist), validCreateReviewRequest(tourID))
+
+	st := requireStatusCode(t, err, codes.FailedPrecondition)
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, "EXECUTION", st.Details()[0].(*errdetails.PreconditionFailure).Violations[0].Type)
+	mockRepo.AssertNotCalled(t, "CreateReview", mock.Anything, mock.Anything)
+}
+
+func TestCreateReview_InvalidFields_ReportsEveryViolation(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	req := validCreateReviewRequest(primitive.NewObjectID())
+	req.Rating = 6
+	req.VisitDate = time.Now().AddDate(0, 0, 2).Format(time.DateOnly)
+	req.Images = []string{" "}
+
+	_, err := handler.CreateReview(contextAs("tourist123", auth.RoleTourist), req)
+
+	st := requireStatusCode(t, err, codes.InvalidArgument)
+	badRequest := st.Details()[0].(*errdetails.BadRequest)
+	assert.Len(t, badRequest.FieldViolations, 3)
+	mockRepo.AssertNotCalled(t, "HasPurchased", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateReview_AlreadyReviewed_ReturnsAlreadyExists(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tourID := primitive.NewObjectID()
+	mockRepo.On("HasPurchased", mock.Anything, "tourist123", tourID).
+		Return(true, nil)
+	mockRepo.On("HasReviewableExecution", mock.Anything, "tourist123", tourID, mock.Anything).
+		Return(true, nil)
+	mockRepo.On("CreateReview", mock.Anything, mock.Anything).
+		Return(repository.ErrReviewExists)
+
+	_, err := handler.CreateReview(contextAs("tourist123", auth.RoleTourist), validCreateReviewRequest(tourID))
+
+	requireStatusCode(t, err, codes.AlreadyExists)
+}
+
+// ── GetReviews ────────────────────────────────────────────────────────────────
+
+func TestGetReviews_ReturnsReviewsOfTour(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	review := createTestReview("tourist123")
+	mockRepo.On("GetReviewsByTourID", mock.Anything, review.TourID).
+		Return([]*models.Review{review}, nil)
+
+	result, err := handler.GetReviews(context.Background(), &pb.GetReviewsRequest{TourId: review.TourID.Hex()})
+
+	require.NoError(t, err)
+	require.Len(t, result.Reviews, 1)
+	assert.Equal(t, review.ID.Hex(), result.Reviews[0].Id)
+	assert.Equal(t, "Nice walk", result.Reviews[0].Comment)
+}
+
+// ── UpdateReview ──────────────────────────────────────────────────────────────
+
+func TestUpdateReview_PassesPreviousRating(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	review := createTestReview("tourist123")
+	mockRepo.On("GetReviewByID", mock.Anything, review.ID).
+		Return(review, nil)
+	mockRepo.On("UpdateReview", mock.Anything, mock.MatchedBy(func(r *models.Review) bool {
+		return r.Rating == 5 && r.Comment == "Even better the second time"
+	}), int32(3)).Return(nil)
+
+	result, err := handler.UpdateReview(contextAs("tourist123", auth.RoleTourist), &pb.UpdateReviewRequest{
+		ReviewId:  review.ID.Hex(),
+		TouristId: "tourist123",
+		Rating:    5,
+		Comment:   "Even better the second time",
+		VisitDate: "2026-05-01",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), result.Review.Rating)
+}
+
+func TestUpdateReview_OtherTourist_ReturnsPermissionDenied(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	review := createTestReview("tourist456")
+	mockRepo.On("GetReviewByID", mock.Anything, review.ID).
+		Return(review, nil)
+
+	_, err := handler.UpdateReview(contextAs("tourist123", auth.RoleTourist), &pb.UpdateReviewRequest{
+		ReviewId:  review.ID.Hex(),
+		TouristId: "tourist123",
+		Rating:    1,
+		VisitDate: "2026-05-01",
+	})
+
+	requireStatusCode(t, err, codes.PermissionDenied)
+	mockRepo.AssertNotCalled(t, "UpdateReview", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateReview_ConcurrentEdit_ReturnsAborted(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	review := createTestReview("tourist123")
+	mockRepo.On("GetReviewByID", mock.Anything, review.ID).
+		Return(review, nil)
+	mockRepo.On("UpdateReview", mock.Anything, mock.Anything, int32(3)).
+		Return(repository.ErrReviewChanged)
+
+	_, err := handler.UpdateReview(contextAs("tourist123", auth.RoleTourist), &pb.UpdateReviewRequest{
+		ReviewId:  review.ID.Hex(),
+		TouristId: "tourist123",
+		Rating:    2,
+		VisitDate: "2026-05-01",
+	})
+
+	requireStatusCode(t, err, codes.Aborted)
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"tour-service/internal/auth"
+	"tour-service/internal/models"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// ── SearchToursNearby ─────────────────────────────────────────────────────────
+
+func TestSearchToursNearby_ReturnsToursWithDistanceInKm(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	near := createTestTour("guide123")
+	far := createTestTour("guide456")
+	mockRepo.On("SearchToursNearby", mock.Anything, 44.8176, 20.4569, 25000.0, repository.DefaultPageSize).
+		Return([]*repository.NearbyTour{
+			{Tour: near, DistanceMeters: 1500},
+			{Tour: far, DistanceMeters: 18000},
+		}, nil)
+
+	result, err := handler.SearchToursNearby(context.Background(), &pb.SearchToursNearbyRequest{
+		Latitude:  44.8176,
+		Longitude: 20.4569,
+		RadiusKm:  25,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Tours, 2)
+	assert.Equal(t, near.ID.Hex(), result.Tours[0].Tour.Id)
+	assert.Equal(t, 1.5, result.Tours[0].DistanceKm)
+	assert.Equal(t, 18.0, result.Tours[1].DistanceKm)
+}
+
+func TestSearchToursNearby_InvalidSearch_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.SearchToursNearby(context.Background(), &pb.SearchToursNearbyRequest{
+		Latitude:  95,
+		Longitude: 20.4569,
+		RadiusKm:  0,
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+	mockRepo.AssertNotCalled(t, "SearchToursNearby", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAddKeyPoint_CoordinatesOutOfRange_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+
+	_, err := handler.AddKeyPoint(contextAs("guide123", auth.RoleGuide), &pb.AddKeyPointRequest{
+		TourId:    tour.ID.Hex(),
+		GuideId:   "guide123",
+		Latitude:  44.8176,
+		Longitude: 200,
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+	mockRepo.AssertNotCalled(t, "CreateKeyPoint", mock.Anything, mock.Anything)
+}
+
+// ── SearchTours ───────────────────────────────────────────────────────────────
+
+func TestSearchTours_ReturnsHitsWithSnippets(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("SearchTours", mock.Anything, "fortress", repository.PageRequest{Size: 5, Token: "tok"}).
+		Return(&repository.TourSearchPage{
+			Hits: []*repository.TourSearchHit{{
+				Tour:  tour,
+				Score: 7.5,
+				Snippets: []repository.Snippet{{
+					Field:      repository.SnippetName,
+					Text:       "Belgrade Fortress",
+					Highlights: []repository.Highlight{{Start: 9, End: 17}},
+				}},
+			}},
+			NextPageToken: "next",
+		}, nil)
+
+	result, err := handler.SearchTours(context.Background(), &pb.SearchToursRequest{
+		Query:     "  fortress ",
+		PageSize:  5,
+		PageToken: "tok",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Results, 1)
+	assert.Equal(t, tour.ID.Hex(), result.Results[0].Tour.Id)
+	assert.Equal(t, 7.5, result.Results[0].Score)
+	require.Len(t, result.Results[0].Snippets, 1)
+	assert.Equal(t, "name", result.Results[0].Snippets[0].Field)
+	assert.Equal(t, int32(9), result.Results[0].Snippets[0].Highlights[0].Start)
+	assert.Equal(t, "next", result.NextPageToken)
+}
+
+func TestSearchTours_MatchesPublishedToursAcrossPages(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	walk := createTestTour("guide123")
+	walk.Name, walk.IsPublished = "Fortress walk", true
+	ride := createTestTour("guide123")
+	ride.Name, ride.IsPublished = "Danube ride", true
+	ride.Description = "Ends below the fortress"
+	draft := createTestTour("guide123")
+	draft.Name = "Fortress draft"
+	tours := []*models.Tour{walk, ride, draft}
+	mockRepo.On("SearchTours", mock.Anything, "fortress", mock.Anything).
+		Return(tours, nil)
+
+	first, err := handler.SearchTours(context.Background(), &pb.SearchToursRequest{Query: "fortress", PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, first.Results, 1)
+	assert.Equal(t, walk.ID.Hex(), first.Results[0].Tour.Id)
+	require.NotEmpty(t, first.NextPageToken)
+
+	second, err := handler.SearchTours(context.Background(), &pb.SearchToursRequest{
+		Query:     "fortress",
+		PageSize:  1,
+		PageToken: first.NextPageToken,
+	})
+	require.NoError(t, err)
+	require.Len(t, second.Results, 1)
+	assert.Equal(t, ride.ID.Hex(), second.Results[0].Tour.Id)
+	assert.Equal(t, "description", second.Results[0].Snippets[0].Field)
+	assert.Empty(t, second.NextPageToken)
+}
+
+func TestSearchTours_InvalidQuery_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	for _, req := range []*pb.SearchToursRequest{
+		{Query: "   "},
+		{Query: strings.Repeat("a", maxSearchQueryLength+1)},
+		{Query: "fortress", PageSize: repository.MaxPageSize + 1},
+	} {
+		_, err := handler.SearchTours(context.Background(), req)
+
+		requireStatusCode(t, err, codes.InvalidArgument)
+	}
+	mockRepo.AssertNotCalled(t, "SearchTours", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSearchTours_InvalidPageToken_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	mockRepo.On("SearchTours", mock.Anything, "fortress", mock.Anything).
+		Return(nil, repository.ErrInvalidPageToken)
+
+	_, err := handler.SearchTours(context.Background(), &pb.SearchToursRequest{
+		Query:     "fortress",
+		PageToken: "issued-for-another-query",
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+}
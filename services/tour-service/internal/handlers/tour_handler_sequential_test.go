@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"testing"
+	"tour-service/internal/auth"
+	"tour-service/internal/models"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// executionWithTwoKeyPoints returns an active execution in mode on a tour
+// whose keypoints are about 800 m apart, stored out of order.
+func executionWithTwoKeyPoints(mockRepo *repository.MockTourRepository, mode string) (*models.TourExecution, *models.KeyPoint, *models.KeyPoint) {
+	execution := &models.TourExecution{
+		ID:                 primitive.NewObjectID(),
+		TouristID:          "tourist123",
+		TourID:             primitive.NewObjectID(),
+		Status:             "active",
+		Mode:               mode,
+		CompletedKeypoints: []models.CompletedKeypoint{},
+	}
+	first := createTestKeyPoint(execution.TourID)
+	first.Name = "Gate"
+	second := createTestKeyPoint(execution.TourID)
+	second.Name, second.Order = "Tower", 2
+	second.Latitude, second.Longitude = 44.8231, 20.4504
+
+	mockRepo.On("GetExecution", mock.Anything, execution.ID).
+		Return(execution, nil)
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, execution.TourID).
+		Return([]*models.KeyPoint{second, first}, nil)
+	mockRepo.On("UpdateExecution", mock.Anything, execution).
+		Return(nil)
+	return execution, first, second
+}
+
+// ── Sequential mode ───────────────────────────────────────────────────────────
+
+func TestCheckProximity_Sequential_AtLaterKeyPoint_PointsToNext(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution, first, second := executionWithTwoKeyPoints(mockRepo, models.ExecutionModeSequential)
+
+	result, err := handler.CheckProximity(contextAs("tourist123", auth.RoleTourist), &pb.CheckProximityRequest{
+		ExecutionId:      execution.ID.Hex(),
+		TouristId:        "tourist123",
+		CurrentLatitude:  second.Latitude,
+		CurrentLongitude: second.Longitude,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.NearKeyPoint)
+	assert.Empty(t, execution.CompletedKeypoints)
+	require.NotNil(t, result.NextKeyPoint)
+	assert.Equal(t, first.ID.Hex(), result.NextKeyPoint.Id)
+	assert.InDelta(t, 800, result.NextKeyPointDistance, 20)
+}
+
+func TestCheckProximity_Sequential_AtNextKeyPoint_CompletesIt(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution, first, second := executionWithTwoKeyPoints(mockRepo, models.ExecutionModeSequential)
+
+	result, err := handler.CheckProximity(contextAs("tourist123", auth.RoleTourist), &pb.CheckProximityRequest{
+		ExecutionId:      execution.ID.Hex(),
+		TouristId:        "tourist123",
+		CurrentLatitude:  first.Latitude,
+		CurrentLongitude: first.Longitude,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.NearKeyPoint)
+	assert.Equal(t, first.ID.Hex(), result.NearbyKeyPoint.Id)
+	require.NotNil(t, result.NextKeyPoint)
+	assert.Equal(t, second.ID.Hex(), result.NextKeyPoint.Id)
+}
+
+func TestCheckProximity_FreeRoam_CompletesAnyKeyPoint(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	// Executions from before modes existed have none
+	execution, first, second := executionWithTwoKeyPoints(mockRepo, "")
+
+	result, err := handler.CheckProximity(contextAs("tourist123", auth.RoleTourist), &pb.CheckProximityRequest{
+		ExecutionId:      execution.ID.Hex(),
+		TouristId:        "tourist123",
+		CurrentLatitude:  second.Latitude,
+		CurrentLongitude: second.Longitude,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.NearKeyPoint)
+	assert.Equal(t, second.ID.Hex(), result.NearbyKeyPoint.Id)
+	require.NotNil(t, result.NextKeyPoint)
+	assert.Equal(t, first.ID.Hex(), result.NextKeyPoint.Id)
+}
+
+func TestCheckProximity_AllCompleted_HasNoNextKeyPoint(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution, first, second := executionWithTwoKeyPoints(mockRepo, models.ExecutionModeSequential)
+	execution.CompletedKeypoints = []models.CompletedKeypoint{{KeypointID: first.ID}, {KeypointID: second.ID}}
+
+	result, err := handler.CheckProximity(contextAs("tourist123", auth.RoleTourist), &pb.CheckProximityRequest{
+		ExecutionId:      execution.ID.Hex(),
+		TouristId:        "tourist123",
+		CurrentLatitude:  first.Latitude,
+		CurrentLongitude: first.Longitude,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.NearKeyPoint)
+	assert.Nil(t, result.NextKeyPoint)
+}
+
+func TestStartTourExecution_KeepsModeOfTour(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	tour.ExecutionMode = models.ExecutionModeSequential
+	mockRepo.On("HasPurchased", mock.Anything, "tourist123", tour.ID).
+		Return(true, nil)
+	mockRepo.On("GetActiveExecution", mock.Anything, "tourist123", tour.ID).
+		Return(nil, nil)
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("CreateExecution", mock.Anything, mock.AnythingOfType("*models.TourExecution")).
+		Return(nil)
+
+	result, err := handler.StartTourExecution(contextAs("tourist123", auth.RoleTourist), &pb.StartExecutionRequest{
+		TouristId: "tourist123",
+		TourId:    tour.ID.Hex(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionModeSequential, result.Execution.Mode)
+}
+
+func TestCreateTour_DefaultsToFreeRoam(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	mockRepo.On("CreateTour", mock.Anything, mock.AnythingOfType("*models.Tour")).
+		Return(nil)
+
+	result, err := handler.CreateTour(contextAs("guide123", auth.RoleGuide), &pb.CreateTourRequest{
+		GuideId: "guide123",
+		Name:    "Test Tour",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionModeFreeRoam, result.Tour.ExecutionMode)
+}
+
+func TestCreateTour_UnknownExecutionMode_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.CreateTour(contextAs("guide123", auth.RoleGuide), &pb.CreateTourRequest{
+		GuideId:       "guide123",
+		Name:          "Test Tour",
+		ExecutionMode: "shuffled",
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+	mockRepo.AssertNotCalled(t, "CreateTour", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTour_ExecutionMode(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("UpdateTour", mock.Anything, tour).
+		Return(nil)
+
+	result, err := handler.UpdateTour(contextAs("guide123", auth.RoleGuide), &pb.UpdateTourRequest{
+		TourId:        tour.ID.Hex(),
+		GuideId:       "guide123",
+		ExecutionMode: models.ExecutionModeSequential,
+		UpdateMask:    &fieldmaskpb.FieldMask{Paths: []string{"executionMode"}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionModeSequential, result.Tour.ExecutionMode)
+}
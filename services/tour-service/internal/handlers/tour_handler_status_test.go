@@ -0,0 +1,392 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"tour-service/internal/auth"
+	"tour-service/internal/models"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ── Status helpers ────────────────────────────────────────────────────────────
+
+func requireStatusCode(t *testing.T, err error, code codes.Code) *status.Status {
+	t.Helper()
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok, "expected a gRPC status error, got %v", err)
+	assert.Equal(t, code, st.Code())
+	return st
+}
+
+// ── InvalidArgument ───────────────────────────────────────────────────────────
+
+func TestGetTourById_InvalidId_ReturnsInvalidArgument(t *testing.T) {
+	handler, _ := newStatusTestHandler()
+
+	result, err := handler.GetTourById(context.Background(), &pb.GetTourByIdRequest{TourId: "bad"})
+
+	st := requireStatusCode(t, err, codes.InvalidArgument)
+	require.Len(t, st.Details(), 1)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	assert.Equal(t, "tourId", badRequest.FieldViolations[0].Field)
+
+	// The envelope is still populated for callers that inspect it
+	assert.False(t, result.Success)
+	assert.Equal(t, "Invalid tour ID", result.Message)
+}
+
+// ── NotFound / Internal ───────────────────────────────────────────────────────
+
+func TestGetTourById_Missing_ReturnsNotFound(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tourID := primitive.NewObjectID()
+	mockRepo.On("GetTourByID", mock.Anything, tourID).
+		Return(nil, mongo.ErrNoDocuments)
+
+	_, err := handler.GetTourById(context.Background(), &pb.GetTourByIdRequest{TourId: tourID.Hex()})
+
+	st := requireStatusCode(t, err, codes.NotFound)
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ResourceInfo)
+	require.True(t, ok)
+	assert.Equal(t, "tour", info.ResourceType)
+	assert.Equal(t, tourID.Hex(), info.ResourceName)
+}
+
+func TestGetTourById_RepositoryError_ReturnsInternal(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	mockRepo.On("GetTourByID", mock.Anything, mock.Anything).
+		Return(nil, errors.New("connection reset"))
+
+	_, err := handler.GetTourById(context.Background(), &pb.GetTourByIdRequest{TourId: primitive.NewObjectID().Hex()})
+
+	requireStatusCode(t, err, codes.Internal)
+}
+
+func TestUpdateKeyPoint_KeyPointOfAnotherTour_ReturnsNotFound(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	ownTour := createTestTour("guide123")
+	foreignKeyPoint := createTestKeyPoint(primitive.NewObjectID())
+	mockRepo.On("GetTourByID", mock.Anything, ownTour.ID).
+		Return(ownTour, nil)
+	mockRepo.On("UpdateKeyPoint", mock.Anything, mock.MatchedBy(func(kp *models.KeyPoint) bool {
+		return kp.ID == foreignKeyPoint.ID && kp.TourID == ownTour.ID
+	})).Return(mongo.ErrNoDocuments)
+
+	_, err := handler.UpdateKeyPoint(contextAs("guide123", auth.RoleGuide), &pb.UpdateKeyPointRequest{
+		KeyPointId: foreignKeyPoint.ID.Hex(),
+		TourId:     ownTour.ID.Hex(),
+		GuideId:    "guide123",
+		Latitude:   44.8176,
+		Longitude:  20.4569,
+		Name:       "Hijacked",
+	})
+
+	requireStatusCode(t, err, codes.NotFound)
+}
+
+func TestDeleteKeyPoint_KeyPointOfAnotherTour_ReturnsNotFound(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	ownTour := createTestTour("guide123")
+	foreignKeyPoint := createTestKeyPoint(primitive.NewObjectID())
+	mockRepo.On("GetTourByID", mock.Anything, ownTour.ID).
+		Return(ownTour, nil)
+	mockRepo.On("DeleteKeyPoint", mock.Anything, ownTour.ID, foreignKeyPoint.ID).
+		Return(mongo.ErrNoDocuments)
+
+	_, err := handler.DeleteKeyPoint(contextAs("guide123", auth.RoleGuide), &pb.DeleteKeyPointRequest{
+		KeyPointId: foreignKeyPoint.ID.Hex(),
+		TourId:     ownTour.ID.Hex(),
+		GuideId:    "guide123",
+	})
+
+	st := requireStatusCode(t, err, codes.NotFound)
+	info, ok := st.Details()[0].(*errdetails.ResourceInfo)
+	require.True(t, ok)
+	assert.Equal(t, "keypoint", info.ResourceType)
+	mockRepo.AssertNotCalled(t, "SetTourMetrics", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ── PermissionDenied ──────────────────────────────────────────────────────────
+
+func TestPublishTour_WrongGuide_ReturnsPermissionDenied(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+
+	_, err := handler.PublishTour(contextAs("differentguide", auth.RoleGuide), &pb.PublishTourRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "differentguide",
+		Price:   25.0,
+	})
+
+	requireStatusCode(t, err, codes.PermissionDenied)
+	mockRepo.AssertNotCalled(t, "PublishTour", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ── FailedPrecondition ────────────────────────────────────────────────────────
+
+func TestAddToCart_UnpublishedTour_ReturnsFailedPrecondition(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+
+	_, err := handler.AddToCart(contextAs("tourist123", auth.RoleTourist), &pb.AddToCartRequest{
+		TouristId: "tourist123",
+		TourId:    tour.ID.Hex(),
+	})
+
+	st := requireStatusCode(t, err, codes.FailedPrecondition)
+	require.Len(t, st.Details(), 1)
+	failure, ok := st.Details()[0].(*errdetails.PreconditionFailure)
+	require.True(t, ok)
+	assert.Equal(t, "TOUR_STATUS", failure.Violations[0].Type)
+}
+
+func TestCheckout_EmptyCart_ReturnsFailedPrecondition(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	mockRepo.On("CheckoutCart", mock.Anything, "tourist123", "").
+		Return(nil, repository.ErrCartEmpty)
+
+	_, err := handler.Checkout(contextAs("tourist123", auth.RoleTourist), &pb.CheckoutRequest{TouristId: "tourist123"})
+
+	requireStatusCode(t, err, codes.FailedPrecondition)
+}
+
+func TestCheckout_AlreadyOwnedTours_ListsEveryConflict(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	owned := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()}
+	mockRepo.On("CheckoutCart", mock.Anything, "tourist123", "").
+		Return(nil, &repository.AlreadyPurchasedError{TourIDs: owned})
+
+	result, err := handler.Checkout(contextAs("tourist123", auth.RoleTourist), &pb.CheckoutRequest{TouristId: "tourist123"})
+
+	st := requireStatusCode(t, err, codes.FailedPrecondition)
+	require.Len(t, st.Details(), 1)
+	failure, ok := st.Details()[0].(*errdetails.PreconditionFailure)
+	require.True(t, ok)
+	require.Len(t, failure.Violations, 2)
+	assert.Equal(t, "tour/"+owned[0].Hex(), failure.Violations[0].Subject)
+	assert.Empty(t, result.Tokens)
+}
+
+func TestCheckout_ArchivedTourInCart_ReturnsFailedPrecondition(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	archived := primitive.NewObjectID()
+	mockRepo.On("CheckoutCart", mock.Anything, "tourist123", "").
+		Return(nil, &repository.UnavailableToursError{TourIDs: []primitive.ObjectID{archived}})
+
+	result, err := handler.Checkout(contextAs("tourist123", auth.RoleTourist), &pb.CheckoutRequest{TouristId: "tourist123"})
+
+	st := requireStatusCode(t, err, codes.FailedPrecondition)
+	failure, ok := st.Details()[0].(*errdetails.PreconditionFailure)
+	require.True(t, ok)
+	require.Len(t, failure.Violations, 1)
+	assert.Equal(t, "TOUR_STATUS", failure.Violations[0].Type)
+	assert.Equal(t, "tour/"+archived.Hex(), failure.Violations[0].Subject)
+	assert.Empty(t, result.Tokens)
+}
+
+func TestCheckout_CartChangedConcurrently_ReturnsAborted(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	mockRepo.On("CheckoutCart", mock.Anything, "tourist123", "").
+		Return(nil, repository.ErrCartChanged)
+
+	_, err := handler.Checkout(contextAs("tourist123", auth.RoleTourist), &pb.CheckoutRequest{TouristId: "tourist123"})
+
+	requireStatusCode(t, err, codes.Aborted)
+}
+
+// ── Legacy envelope ───────────────────────────────────────────────────────────
+
+func TestLegacyErrorEnvelope_SuppressesStatusError(t *testing.T) {
+	handler, _ := newTestHandler()
+
+	result, err := handler.GetTourById(context.Background(), &pb.GetTourByIdRequest{TourId: "bad"})
+
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+}
+
+// ── Authenticated principal ───────────────────────────────────────────────────
+
+func TestPublishTour_BodyGuideMismatchesPrincipal_ReturnsPermissionDenied(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	ctx := auth.NewContext(context.Background(), &auth.Principal{UserID: "guide123", Role: "guide"})
+	_, err := handler.PublishTour(ctx, &pb.PublishTourRequest{
+		TourId:  primitive.NewObjectID().Hex(),
+		GuideId: "someoneelse",
+		Price:   25.0,
+	})
+
+	requireStatusCode(t, err, codes.PermissionDenied)
+	mockRepo.AssertNotCalled(t, "GetTourByID", mock.Anything, mock.Anything)
+}
+
+func TestCheckout_BodyTouristMismatchesPrincipal_ReturnsPermissionDenied(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	ctx := auth.NewContext(context.Background(), &auth.Principal{UserID: "tourist123", Role: "tourist"})
+	_, err := handler.Checkout(ctx, &pb.CheckoutRequest{TouristId: "victim"})
+
+	requireStatusCode(t, err, codes.PermissionDenied)
+	mockRepo.AssertNotCalled(t, "GetOrCreateCart", mock.Anything, mock.Anything)
+}
+
+func TestCheckout_NoPrincipal_ReturnsUnauthenticated(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.Checkout(context.Background(), &pb.CheckoutRequest{TouristId: "tourist123"})
+
+	requireStatusCode(t, err, codes.Unauthenticated)
+	mockRepo.AssertNotCalled(t, "GetOrCreateCart", mock.Anything, mock.Anything)
+}
+
+func TestGetExecution_NoPrincipal_ReturnsUnauthenticated(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.GetExecution(context.Background(), &pb.GetExecutionRequest{
+		ExecutionId: primitive.NewObjectID().Hex(),
+		TouristId:   "tourist123",
+	})
+
+	requireStatusCode(t, err, codes.Unauthenticated)
+	mockRepo.AssertNotCalled(t, "GetExecution", mock.Anything, mock.Anything)
+}
+
+func TestAddKeyPoint_MatchingPrincipal_Succeeds(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("CreateKeyPoint", mock.Anything, mock.AnythingOfType("*models.KeyPoint")).
+		Return(nil)
+	expectTourMetricsRefresh(mockRepo, tour.ID)
+
+	ctx := auth.NewContext(context.Background(), &auth.Principal{UserID: "guide123", Role: "guide"})
+	result, err := handler.AddKeyPoint(ctx, &pb.AddKeyPointRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+		Name:    "Fortress",
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+}
+
+// ── Idempotent checkout ───────────────────────────────────────────────────────
+
+func TestCheckout_RepeatedIdempotencyKey_ReplaysResult(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	token := &models.PurchaseToken{TouristID: "tourist123", TourID: primitive.NewObjectID(), Token: "token-1"}
+	mockRepo.On("CheckoutCart", mock.Anything, "tourist123", "key-1").
+		Return(&repository.CheckoutResult{Tokens: []*models.PurchaseToken{token}, Replayed: true}, nil)
+
+	result, err := handler.Checkout(contextAs("tourist123", auth.RoleTourist), &pb.CheckoutRequest{TouristId: "tourist123", IdempotencyKey: "key-1"})
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.True(t, result.Replayed)
+	require.Len(t, result.Tokens, 1)
+	assert.Equal(t, "token-1", result.Tokens[0].Token)
+}
+
+func TestAddToCart_AlreadyPurchased_ReturnsAlreadyExists(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	tour.IsPublished = true
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("HasPurchased", mock.Anything, "tourist123", tour.ID).
+		Return(true, nil)
+
+	_, err := handler.AddToCart(contextAs("tourist123", auth.RoleTourist), &pb.AddToCartRequest{TouristId: "tourist123", TourId: tour.ID.Hex()})
+
+	requireStatusCode(t, err, codes.AlreadyExists)
+	mockRepo.AssertNotCalled(t, "UpdateCart", mock.Anything, mock.Anything)
+}
+
+// ── Tour length ───────────────────────────────────────────────────────────────
+
+func TestUpdateKeyPoint_RecomputesTourLength(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	start := createTestKeyPoint(tour.ID)
+	end := createTestKeyPoint(tour.ID)
+	end.Order = 2
+	end.Latitude, end.Longitude = 45.2671, 19.8335
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("UpdateKeyPoint", mock.Anything, mock.AnythingOfType("*models.KeyPoint")).
+		Return(nil)
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, tour.ID).
+		Return([]*models.KeyPoint{end, start}, nil)
+	mockRepo.On("SetTourMetrics", mock.Anything, tour.ID, mock.MatchedBy(func(metrics repository.TourMetrics) bool {
+		return metrics.LengthKm > 65 && metrics.LengthKm < 75
+	})).Return(nil)
+
+	result, err := handler.UpdateKeyPoint(contextAs("guide123", auth.RoleGuide), &pb.UpdateKeyPointRequest{
+		KeyPointId: end.ID.Hex(),
+		TourId:     tour.ID.Hex(),
+		GuideId:    "guide123",
+		Latitude:   end.Latitude,
+		Longitude:  end.Longitude,
+		Name:       "Novi Sad",
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	mockRepo.AssertCalled(t, "SetTourMetrics", mock.Anything, tour.ID, mock.Anything)
+}
+
+func TestAddKeyPoint_LengthRefreshFails_StillSucceeds(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("CreateKeyPoint", mock.Anything, mock.AnythingOfType("*models.KeyPoint")).
+		Return(nil)
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, tour.ID).
+		Return(nil, errors.New("database error"))
+
+	result, err := handler.AddKeyPoint(contextAs("guide123", auth.RoleGuide), &pb.AddKeyPointRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+		Name:    "Fortress",
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	mockRepo.AssertNotCalled(t, "SetTourMetrics", mock.Anything, mock.Anything, mock.Anything)
+}
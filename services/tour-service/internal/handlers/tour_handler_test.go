@@ -3,6 +3,7 @@ package handlers
 import (
 	"math"
 	"testing"
+	"tour-service/internal/models"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -63,3 +64,60 @@ func TestCalculateDistance_UsesEarthRadiusInMeters(t *testing.T) {
 	halfCircumference := math.Pi * 6371000
 	assert.InDelta(t, halfCircumference, distance, 100000)
 }
+
+// ── tourLengthKm ─────────────────────────────────────────────────────────────
+
+func TestTourLengthKm_NoKeyPoints_ReturnsZero(t *testing.T) {
+	assert.Equal(t, 0.0, tourLengthKm(nil))
+}
+
+func TestTourLengthKm_SingleKeyPoint_ReturnsZero(t *testing.T) {
+	keyPoints := []*models.KeyPoint{{Latitude: 44.8176, Longitude: 20.4569, Order: 1}}
+	assert.Equal(t, 0.0, tourLengthKm(keyPoints))
+}
+
+func TestTourLengthKm_SumsLegsInOrder(t *testing.T) {
+	// Belgrade → Novi Sad → Belgrade, stored out of order
+	keyPoints := []*models.KeyPoint{
+		{Latitude: 44.8176, Longitude: 20.4569, Order: 3},
+		{Latitude: 44.8176, Longitude: 20.4569, Order: 1},
+		{Latitude: 45.2671, Longitude: 19.8335, Order: 2},
+	}
+	assert.InDelta(t, 140, tourLengthKm(keyPoints), 10)
+}
+
+func TestTourLengthKm_DoesNotReorderInput(t *testing.T) {
+	keyPoints := []*models.KeyPoint{{Order: 2}, {Order: 1}}
+	tourLengthKm(keyPoints)
+	assert.Equal(t, int32(2), keyPoints[0].Order)
+}
+
+// ── withSuggestedTransportTimes ──────────────────────────────────────────────
+
+func TestWithSuggestedTransportTimes_NoPath_ReturnsGuideTimesOnly(t *testing.T) {
+	times := withSuggestedTransportTimes([]models.TransportTime{{Mode: models.TransportCar, Minutes: 20}}, 0)
+	assert.Equal(t, []models.TransportTime{{Mode: models.TransportCar, Minutes: 20}}, times)
+}
+
+func TestWithSuggestedTransportTimes_SuggestsEveryModeFromLength(t *testing.T) {
+	times := withSuggestedTransportTimes(nil, 10)
+
+	assert.Equal(t, []models.TransportTime{
+		{Mode: models.TransportWalking, Minutes: 120, Suggested: true},
+		{Mode: models.TransportBicycle, Minutes: 40, Suggested: true},
+		{Mode: models.TransportCar, Minutes: 15, Suggested: true},
+	}, times)
+}
+
+func TestWithSuggestedTransportTimes_KeepsGuideTimesAndReplacesOldSuggestions(t *testing.T) {
+	times := withSuggestedTransportTimes([]models.TransportTime{
+		{Mode: models.TransportWalking, Minutes: 150},
+		{Mode: models.TransportCar, Minutes: 5, Suggested: true},
+	}, 20)
+
+	assert.Equal(t, []models.TransportTime{
+		{Mode: models.TransportWalking, Minutes: 150},
+		{Mode: models.TransportBicycle, Minutes: 80, Suggested: true},
+		{Mode: models.TransportCar, Minutes: 30, Suggested: true},
+	}, times)
+}
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+	"tour-service/internal/auth"
+	"tour-service/internal/models"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// fakeTrackStream plays the client side of TrackExecution: samples queued
+// on requests are received in order, and closing requests ends the client's
+// side of the stream.
+type fakeTrackStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests chan *pb.TrackExecutionRequest
+	events   []*pb.TrackExecutionEvent
+}
+
+func newFakeTrackStream(ctx context.Context, samples ...*pb.TrackExecutionRequest) *fakeTrackStream {
+	stream := &fakeTrackStream{ctx: ctx, requests: make(chan *pb.TrackExecutionRequest, len(samples))}
+	for _, sample := range samples {
+		stream.requests <- sample
+	}
+	return stream
+}
+
+func (s *fakeTrackStream) Context() context.Context { return s.ctx }
+
+func (s *fakeTrackStream) Recv() (*pb.TrackExecutionRequest, error) {
+	select {
+	case req, ok := <-s.requests:
+		if !ok {
+			return nil, io.EOF
+		}
+		return req, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+func (s *fakeTrackStream) Send(event *pb.TrackExecutionEvent) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+// trackedExecution returns an active execution of tourist123 on a tour with
+// one keypoint, at the position of createTestKeyPoint.
+func trackedExecution(mockRepo *repository.MockTourRepository) (*models.TourExecution, *models.KeyPoint) {
+	execution := &models.TourExecution{
+		ID:                 primitive.NewObjectID(),
+		TouristID:          "tourist123",
+		TourID:             primitive.NewObjectID(),
+		Status:             "active",
+		CompletedKeypoints: []models.CompletedKeypoint{},
+	}
+	keypoint := createTestKeyPoint(execution.TourID)
+
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, execution.TourID).
+		Return([]*models.KeyPoint{keypoint}, nil)
+	mockRepo.On("UpsertPosition", mock.Anything, mock.AnythingOfType("*models.Position")).
+		Return(nil)
+	mockRepo.On("UpdateExecution", mock.Anything, mock.AnythingOfType("*models.TourExecution")).
+		Return(nil)
+	return execution, keypoint
+}
+
+// ── TrackExecution ────────────────────────────────────────────────────────────
+
+func TestTrackExecution_SampleAtKeyPoint_PushesKeyPointReached(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution, keypoint := trackedExecution(mockRepo)
+	mockRepo.On("GetExecution", mock.Anything, execution.ID).
+		Return(execution, nil)
+	mockRepo.On("GetPositionSamples", mock.Anything, execution.ID).
+		Return([]*models.PositionSample{}, nil)
+
+	stream := newFakeTrackStream(contextAs("tourist123", auth.RoleTourist),
+		&pb.TrackExecutionRequest{
+			ExecutionId: execution.ID.Hex(),
+			TouristId:   "tourist123",
+			Latitude:    45.2671,
+			Longitude:   19.8335,
+		},
+		&pb.TrackExecutionRequest{Latitude: keypoint.Latitude, Longitude: keypoint.Longitude},
+	)
+	close(stream.requests)
+
+	err := handler.TrackExecution(stream)
+
+	require.NoError(t, err)
+	require.Len(t, stream.events, 2)
+	reached := stream.events[0].GetKeyPointReached()
+	require.NotNil(t, reached)
+	assert.Equal(t, keypoint.ID.Hex(), reached.KeyPoint.Id)
+	assert.Equal(t, int32(1), reached.CompletedCount)
+	assert.Equal(t, int32(1), reached.TotalCount)
+	mockRepo.AssertNumberOfCalls(t, "UpsertPosition", 2)
+
+	// It was the only keypoint
+	completed := stream.events[1].GetTourCompleted()
+	require.NotNil(t, completed)
+	assert.Equal(t, int32(1), completed.Summary.CompletedKeyPoints)
+	assert.Equal(t, 100.0, completed.Summary.CompletionPercentage)
+}
+
+func TestTrackExecution_CompletedExecution_PushesTourCompleted(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution, _ := trackedExecution(mockRepo)
+	execution.Status = "completed"
+	mockRepo.On("GetExecution", mock.Anything, execution.ID).
+		Return(execution, nil)
+	mockRepo.On("GetPositionSamples", mock.Anything, execution.ID).
+		Return([]*models.PositionSample{}, nil)
+
+	stream := newFakeTrackStream(contextAs("tourist123", auth.RoleTourist), &pb.TrackExecutionRequest{
+		ExecutionId: execution.ID.Hex(),
+		TouristId:   "tourist123",
+	})
+
+	err := handler.TrackExecution(stream)
+
+	require.NoError(t, err)
+	require.Len(t, stream.events, 1)
+	assert.NotNil(t, stream.events[0].GetTourCompleted())
+	mockRepo.AssertNotCalled(t, "UpdateExecution", mock.Anything, mock.Anything)
+}
+
+func TestTrackExecution_AbandonedWhileIdle_PushesExecutionExpired(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+	handler.trackStatusInterval = 10 * time.Millisecond
+
+	execution, _ := trackedExecution(mockRepo)
+	abandoned := *execution
+	abandoned.Status = "abandoned"
+	mockRepo.On("GetExecution", mock.Anything, execution.ID).
+		Return(execution, nil).Once()
+	mockRepo.On("GetExecution", mock.Anything, execution.ID).
+		Return(&abandoned, nil)
+
+	stream := newFakeTrackStream(contextAs("tourist123", auth.RoleTourist), &pb.TrackExecutionRequest{
+		ExecutionId: execution.ID.Hex(),
+		TouristId:   "tourist123",
+	})
+
+	err := handler.TrackExecution(stream)
+
+	require.NoError(t, err)
+	require.Len(t, stream.events, 1)
+	expired := stream.events[0].GetExecutionExpired()
+	require.NotNil(t, expired)
+	assert.Equal(t, "abandoned", expired.Execution.Status)
+}
+
+func TestTrackExecution_OtherTouristsExecution_ReturnsPermissionDenied(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution, _ := trackedExecution(mockRepo)
+	mockRepo.On("GetExecution", mock.Anything, execution.ID).
+		Return(execution, nil)
+
+	stream := newFakeTrackStream(contextAs("tourist456", auth.RoleTourist), &pb.TrackExecutionRequest{
+		ExecutionId: execution.ID.Hex(),
+		TouristId:   "tourist456",
+	})
+
+	err := handler.TrackExecution(stream)
+
+	requireStatusCode(t, err, codes.PermissionDenied)
+	assert.Empty(t, stream.events)
+}
+
+func TestTrackExecution_SwitchingExecution_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution, _ := trackedExecution(mockRepo)
+	mockRepo.On("GetExecution", mock.Anything, execution.ID).
+		Return(execution, nil)
+
+	stream := newFakeTrackStream(contextAs("tourist123", auth.RoleTourist),
+		&pb.TrackExecutionRequest{ExecutionId: execution.ID.Hex(), TouristId: "tourist123"},
+		&pb.TrackExecutionRequest{ExecutionId: primitive.NewObjectID().Hex(), TouristId: "tourist123"},
+	)
+
+	err := handler.TrackExecution(stream)
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+}
+
+func TestTrackExecution_ClosedBeforeFirstSample_ReturnsNil(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	stream := newFakeTrackStream(context.Background())
+	close(stream.requests)
+
+	err := handler.TrackExecution(stream)
+
+	require.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "GetExecution", mock.Anything, mock.Anything)
+}
+
+// ── GetExecutionTrack ─────────────────────────────────────────────────────────
+
+func TestGetExecutionTrack_ReturnsPathDistanceAndSpeed(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution, _ := trackedExecution(mockRepo)
+	mockRepo.On("GetExecution", mock.Anything, execution.ID).
+		Return(execution, nil)
+
+	start := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	samples := []*models.PositionSample{
+		{ExecutionID: execution.ID, Latitude: 44.8176, Longitude: 20.4569, RecordedAt: start},
+		{ExecutionID: execution.ID, Latitude: 44.8186, Longitude: 20.4569, RecordedAt: start.Add(time.Minute)},
+		{ExecutionID: execution.ID, Latitude: 44.8196, Longitude: 20.4569, RecordedAt: start.Add(2 * time.Minute)},
+	}
+	mockRepo.On("GetPositionSamples", mock.Anything, execution.ID).
+		Return(samples, nil)
+
+	result, err := handler.GetExecutionTrack(contextAs("tourist123", auth.RoleTourist), &pb.GetExecutionTrackRequest{
+		ExecutionId: execution.ID.Hex(),
+		TouristId:   "tourist123",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Points, 3)
+	assert.Equal(t, "2026-05-01T10:01:00Z", result.Points[1].RecordedAt)
+	// 0.002° of latitude is about 222 m
+	assert.InDelta(t, 222.4, result.Distance, 0.5)
+	assert.Equal(t, 120.0, result.DurationSeconds)
+	assert.InDelta(t, 1.85, result.AverageSpeed, 0.01)
+}
+
+func TestGetExecutionTrack_SingleSample_HasNoSpeed(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution, _ := trackedExecution(mockRepo)
+	mockRepo.On("GetExecution", mock.Anything, execution.ID).
+		Return(execution, nil)
+	mockRepo.On("GetPositionSamples", mock.Anything, execution.ID).
+		Return([]*models.PositionSample{{ExecutionID: execution.ID, RecordedAt: time.Now()}}, nil)
+
+	result, err := handler.GetExecutionTrack(contextAs("tourist123", auth.RoleTourist), &pb.GetExecutionTrackRequest{
+		ExecutionId: execution.ID.Hex(),
+		TouristId:   "tourist123",
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, result.Points, 1)
+	assert.Zero(t, result.Distance)
+	assert.Zero(t, result.AverageSpeed)
+}
+
+func TestGetExecutionTrack_OtherTouristsExecution_ReturnsPermissionDenied(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	execution, _ := trackedExecution(mockRepo)
+	mockRepo.On("GetExecution", mock.Anything, execution.ID).
+		Return(execution, nil)
+
+	_, err := handler.GetExecutionTrack(contextAs("tourist456", auth.RoleTourist), &pb.GetExecutionTrackRequest{
+		ExecutionId: execution.ID.Hex(),
+		TouristId:   "tourist456",
+	})
+
+	requireStatusCode(t, err, codes.PermissionDenied)
+	mockRepo.AssertNotCalled(t, "GetPositionSamples", mock.Anything, mock.Anything)
+}
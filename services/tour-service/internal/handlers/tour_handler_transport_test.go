@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"tour-service/internal/auth"
+	"tour-service/internal/models"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// ── Duration filter ───────────────────────────────────────────────────────────
+
+func TestGetTours_MaxDuration_PassesFilterToRepository(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	filter := repository.TourFilter{TransportMode: models.TransportBicycle, MaxDurationMinutes: 60}
+	mockRepo.On("ListTours", mock.Anything, filter, repository.PageRequest{}).
+		Return(&repository.TourPage{Tours: []*models.Tour{createTestTour("guide123")}}, nil)
+
+	result, err := handler.GetTours(context.Background(), &pb.GetToursRequest{
+		PublishedOnly:      true,
+		TransportMode:      models.TransportBicycle,
+		MaxDurationMinutes: 60,
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, result.Tours, 1)
+	require.Len(t, result.Tours[0].TransportTimes, 1)
+	assert.Equal(t, int32(90), result.Tours[0].TransportTimes[0].Minutes)
+}
+
+func TestGetTours_UnknownTransportMode_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	_, err := handler.GetTours(context.Background(), &pb.GetToursRequest{
+		PublishedOnly:      true,
+		TransportMode:      "helicopter",
+		MaxDurationMinutes: 60,
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+	mockRepo.AssertNotCalled(t, "ListTours", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ── Guide estimates ───────────────────────────────────────────────────────────
+
+func TestUpdateTour_TransportTimes_KeepsGuideEstimatesAndSuggestsTheRest(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	tour.LengthKm = 10
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("UpdateTour", mock.Anything, tour).
+		Return(nil)
+
+	result, err := handler.UpdateTour(contextAs("guide123", auth.RoleGuide), &pb.UpdateTourRequest{
+		TourId:         tour.ID.Hex(),
+		GuideId:        "guide123",
+		TransportTimes: []*pb.TransportTime{{Mode: models.TransportWalking, Minutes: 150}},
+		UpdateMask:     &fieldmaskpb.FieldMask{Paths: []string{"transportTimes"}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.TransportTime{
+		{Mode: models.TransportWalking, Minutes: 150},
+		{Mode: models.TransportBicycle, Minutes: 40, Suggested: true},
+		{Mode: models.TransportCar, Minutes: 15, Suggested: true},
+	}, tour.TransportTimes)
+	assert.Len(t, result.Tour.TransportTimes, 3)
+}
+
+func TestUpdateTour_InvalidTransportTimes_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+
+	_, err := handler.UpdateTour(contextAs("guide123", auth.RoleGuide), &pb.UpdateTourRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+		TransportTimes: []*pb.TransportTime{
+			{Mode: models.TransportWalking, Minutes: 60},
+			{Mode: models.TransportWalking, Minutes: 70},
+			{Mode: models.TransportCar, Minutes: 0},
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"transportTimes"}},
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+	mockRepo.AssertNotCalled(t, "UpdateTour", mock.Anything, mock.Anything)
+}
+
+func TestPublishTour_NoTransportTimes_ReturnsFailedPrecondition(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	tour.TransportTimes = nil
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, tour.ID).
+		Return([]*models.KeyPoint{createTestKeyPoint(tour.ID), createTestKeyPoint(tour.ID)}, nil)
+
+	result, err := handler.PublishTour(contextAs("guide123", auth.RoleGuide), &pb.PublishTourRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+		Price:   25.0,
+	})
+
+	requireStatusCode(t, err, codes.FailedPrecondition)
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, "transportTimes", result.Violations[0].Field)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 	"time"
+	"tour-service/internal/auth"
 	"tour-service/internal/models"
 	"tour-service/internal/repository"
 	pb "tour-service/proto"
@@ -12,11 +13,21 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // ── Helpers ───────────────────────────────────────────────────────────────────
 
+// newTestHandler builds a handler in legacy envelope mode, where failures are
+// reported through Success/Message and the returned error is always nil.
 func newTestHandler() (*TourServiceHandler, *repository.MockTourRepository) {
+	mockRepo := new(repository.MockTourRepository)
+	handler := NewTourServiceHandler(mockRepo, WithLegacyErrorEnvelope(true))
+	return handler, mockRepo
+}
+
+// newStatusTestHandler builds a handler that surfaces failures as gRPC status errors.
+func newStatusTestHandler() (*TourServiceHandler, *repository.MockTourRepository) {
 	mockRepo := new(repository.MockTourRepository)
 	handler := NewTourServiceHandler(mockRepo)
 	return handler, mockRepo
@@ -34,9 +45,23 @@ func createTestTour(guideID string) *models.Tour {
 		Price:       0,
 		IsPublished: false,
 		CreatedAt:   time.Now(),
+		TransportTimes: []models.TransportTime{
+			{Mode: models.TransportWalking, Minutes: 90},
+		},
 	}
 }
 
+// expectTourMetricsRefresh stubs the length and transport time recomputation
+// that follows every keypoint write.
+func expectTourMetricsRefresh(mockRepo *repository.MockTourRepository, tourID primitive.ObjectID) {
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, tourID).
+		Return([]*models.KeyPoint{}, nil)
+	mockRepo.On("SetTourMetrics", mock.Anything, tourID, mock.MatchedBy(func(metrics repository.TourMetrics) bool {
+		return metrics.LengthKm == 0
+	})).
+		Return(nil)
+}
+
 func createTestKeyPoint(tourID primitive.ObjectID) *models.KeyPoint {
 	return &models.KeyPoint{
 		ID:          primitive.NewObjectID(),
@@ -65,7 +90,7 @@ func TestCreateTour_ValidRequest_ReturnsSuccess(t *testing.T) {
 		Tags:        []string{"nature"},
 	}
 
-	result, err := handler.CreateTour(context.Background(), req)
+	result, err := handler.CreateTour(contextAs("guide123", auth.RoleGuide), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -85,7 +110,7 @@ func TestCreateTour_RepositoryError_ReturnsFailure(t *testing.T) {
 		Difficulty:  "easy",
 	}
 
-	result, err := handler.CreateTour(context.Background(), req)
+	result, err := handler.CreateTour(contextAs("guide123", auth.RoleGuide), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -104,8 +129,8 @@ func TestGetTours_PublishedOnly_ReturnsPublishedTours(t *testing.T) {
 	tours[0].IsPublished = true
 	tours[1].IsPublished = true
 
-	mockRepo.On("GetPublishedTours", mock.Anything).
-		Return(tours, nil)
+	mockRepo.On("ListTours", mock.Anything, repository.TourFilter{}, repository.PageRequest{}).
+		Return(&repository.TourPage{Tours: tours}, nil)
 
 	req := &pb.GetToursRequest{PublishedOnly: true}
 	result, err := handler.GetTours(context.Background(), req)
@@ -120,11 +145,11 @@ func TestGetTours_ByGuideID_ReturnsToursByGuide(t *testing.T) {
 
 	tours := []*models.Tour{createTestTour("guide123")}
 
-	mockRepo.On("GetToursByGuideID", mock.Anything, "guide123").
-		Return(tours, nil)
+	mockRepo.On("ListTours", mock.Anything, repository.TourFilter{GuideID: "guide123", IncludeDrafts: true}, repository.PageRequest{}).
+		Return(&repository.TourPage{Tours: tours}, nil)
 
 	req := &pb.GetToursRequest{UserId: "guide123"}
-	result, err := handler.GetTours(context.Background(), req)
+	result, err := handler.GetTours(contextAs("guide123", auth.RoleGuide), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -134,7 +159,7 @@ func TestGetTours_ByGuideID_ReturnsToursByGuide(t *testing.T) {
 func TestGetTours_RepositoryError_ReturnsFailure(t *testing.T) {
 	handler, mockRepo := newTestHandler()
 
-	mockRepo.On("GetPublishedTours", mock.Anything).
+	mockRepo.On("ListTours", mock.Anything, repository.TourFilter{}, repository.PageRequest{}).
 		Return(nil, errors.New("database error"))
 
 	req := &pb.GetToursRequest{PublishedOnly: true}
@@ -153,6 +178,8 @@ func TestPublishTour_ValidRequest_ReturnsSuccess(t *testing.T) {
 
 	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
 		Return(tour, nil)
+	mockRepo.On("GetKeyPointsByTourID", mock.Anything, tour.ID).
+		Return([]*models.KeyPoint{createTestKeyPoint(tour.ID), createTestKeyPoint(tour.ID)}, nil)
 	mockRepo.On("PublishTour", mock.Anything, tour.ID, 25.0).
 		Return(nil)
 
@@ -162,7 +189,7 @@ func TestPublishTour_ValidRequest_ReturnsSuccess(t *testing.T) {
 		Price:   25.0,
 	}
 
-	result, err := handler.PublishTour(context.Background(), req)
+	result, err := handler.PublishTour(contextAs("guide123", auth.RoleGuide), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -180,7 +207,7 @@ func TestPublishTour_TourNotFound_ReturnsFailure(t *testing.T) {
 		Price:   25.0,
 	}
 
-	result, err := handler.PublishTour(context.Background(), req)
+	result, err := handler.PublishTour(contextAs("guide123", auth.RoleGuide), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -200,7 +227,7 @@ func TestPublishTour_WrongGuide_ReturnsUnauthorized(t *testing.T) {
 		Price:   25.0,
 	}
 
-	result, err := handler.PublishTour(context.Background(), req)
+	result, err := handler.PublishTour(contextAs("differentguide", auth.RoleGuide), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -218,6 +245,7 @@ func TestAddKeyPoint_ValidRequest_ReturnsSuccess(t *testing.T) {
 		Return(tour, nil)
 	mockRepo.On("CreateKeyPoint", mock.Anything, mock.AnythingOfType("*models.KeyPoint")).
 		Return(nil)
+	expectTourMetricsRefresh(mockRepo, tour.ID)
 
 	req := &pb.AddKeyPointRequest{
 		TourId:      tour.ID.Hex(),
@@ -229,7 +257,7 @@ func TestAddKeyPoint_ValidRequest_ReturnsSuccess(t *testing.T) {
 		Order:       1,
 	}
 
-	result, err := handler.AddKeyPoint(context.Background(), req)
+	result, err := handler.AddKeyPoint(contextAs("guide123", auth.RoleGuide), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -247,7 +275,7 @@ func TestAddKeyPoint_TourNotFound_ReturnsFailure(t *testing.T) {
 		Name:    "Test Point",
 	}
 
-	result, err := handler.AddKeyPoint(context.Background(), req)
+	result, err := handler.AddKeyPoint(contextAs("guide123", auth.RoleGuide), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -282,7 +310,7 @@ func TestAddToCart_ValidRequest_ReturnsSuccess(t *testing.T) {
 		TourId:    tour.ID.Hex(),
 	}
 
-	result, err := handler.AddToCart(context.Background(), req)
+	result, err := handler.AddToCart(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -302,7 +330,7 @@ func TestAddToCart_UnpublishedTour_ReturnsFailure(t *testing.T) {
 		TourId:    tour.ID.Hex(),
 	}
 
-	result, err := handler.AddToCart(context.Background(), req)
+	result, err := handler.AddToCart(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -320,7 +348,7 @@ func TestAddToCart_TourNotFound_ReturnsFailure(t *testing.T) {
 		TourId:    primitive.NewObjectID().Hex(),
 	}
 
-	result, err := handler.AddToCart(context.Background(), req)
+	result, err := handler.AddToCart(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -351,7 +379,7 @@ func TestStartTourExecution_ValidRequest_ReturnsSuccess(t *testing.T) {
 		StartLongitude: 20.4569,
 	}
 
-	result, err := handler.StartTourExecution(context.Background(), req)
+	result, err := handler.StartTourExecution(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -373,7 +401,7 @@ func TestStartTourExecution_NotPurchased_ReturnsFailure(t *testing.T) {
 		TourId:    tour.ID.Hex(),
 	}
 
-	result, err := handler.StartTourExecution(context.Background(), req)
+	result, err := handler.StartTourExecution(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.False(t, result.Success)
@@ -399,9 +427,11 @@ func TestGetKeyPoints_AsOwner_ReturnsAllKeyPoints(t *testing.T) {
 		Return(tour, nil)
 	mockRepo.On("HasPurchased", mock.Anything, "guide123", tourID).
 		Return(false, nil)
+	mockRepo.On("GetActiveExecution", mock.Anything, "guide123", tourID).
+		Return(nil, mongo.ErrNoDocuments)
 
 	req := &pb.GetKeyPointsRequest{TourId: tourID.Hex(), UserId: "guide123"}
-	result, err := handler.GetKeyPoints(context.Background(), req)
+	result, err := handler.GetKeyPoints(contextAs("guide123", auth.RoleGuide), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -425,9 +455,11 @@ func TestGetKeyPoints_AsPurchasedTourist_ReturnsAllKeyPoints(t *testing.T) {
 		Return(tour, nil)
 	mockRepo.On("HasPurchased", mock.Anything, "tourist123", tourID).
 		Return(true, nil)
+	mockRepo.On("GetActiveExecution", mock.Anything, "tourist123", tourID).
+		Return(nil, mongo.ErrNoDocuments)
 
 	req := &pb.GetKeyPointsRequest{TourId: tourID.Hex(), UserId: "tourist123"}
-	result, err := handler.GetKeyPoints(context.Background(), req)
+	result, err := handler.GetKeyPoints(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
@@ -451,9 +483,11 @@ func TestGetKeyPoints_UnpurchasedTourist_ReturnsOnlyFirst(t *testing.T) {
 		Return(tour, nil)
 	mockRepo.On("HasPurchased", mock.Anything, "tourist123", tourID).
 		Return(false, nil)
+	mockRepo.On("GetActiveExecution", mock.Anything, "tourist123", tourID).
+		Return(nil, mongo.ErrNoDocuments)
 
 	req := &pb.GetKeyPointsRequest{TourId: tourID.Hex(), UserId: "tourist123"}
-	result, err := handler.GetKeyPoints(context.Background(), req)
+	result, err := handler.GetKeyPoints(contextAs("tourist123", auth.RoleTourist), req)
 
 	assert.Nil(t, err)
 	assert.True(t, result.Success)
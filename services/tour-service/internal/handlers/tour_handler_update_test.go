@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"testing"
+	"tour-service/internal/auth"
+	pb "tour-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// ── UpdateTour ────────────────────────────────────────────────────────────────
+
+func TestUpdateTour_Draft_UpdatesMaskedFieldsOnly(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("UpdateTour", mock.Anything, tour).
+		Return(nil)
+
+	result, err := handler.UpdateTour(contextAs("guide123", auth.RoleGuide), &pb.UpdateTourRequest{
+		TourId:      tour.ID.Hex(),
+		GuideId:     "guide123",
+		Name:        "Renamed Tour",
+		Description: "ignored",
+		Difficulty:  "hard",
+		UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"name", "difficulty"}},
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "Renamed Tour", result.Tour.Name)
+	assert.Equal(t, "hard", result.Tour.Difficulty)
+	assert.Equal(t, "A test tour description", result.Tour.Description)
+}
+
+func TestUpdateTour_PublishedTour_UpdatesPrice(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	tour.IsPublished = true
+	tour.Status = "published"
+	tour.Price = 20.0
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+	mockRepo.On("UpdateTour", mock.Anything, tour).
+		Return(nil)
+
+	result, err := handler.UpdateTour(contextAs("guide123", auth.RoleGuide), &pb.UpdateTourRequest{
+		TourId:     tour.ID.Hex(),
+		GuideId:    "guide123",
+		Price:      35.0,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"price"}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 35.0, result.Tour.Price)
+}
+
+func TestUpdateTour_DraftPrice_ReturnsFailedPrecondition(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+
+	_, err := handler.UpdateTour(contextAs("guide123", auth.RoleGuide), &pb.UpdateTourRequest{
+		TourId:     tour.ID.Hex(),
+		GuideId:    "guide123",
+		Price:      10.0,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"price"}},
+	})
+
+	requireStatusCode(t, err, codes.FailedPrecondition)
+	mockRepo.AssertNotCalled(t, "UpdateTour", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTour_PublishedTour_RejectsEmptyRequiredFields(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	tour.IsPublished = true
+	tour.Status = "published"
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+
+	_, err := handler.UpdateTour(contextAs("guide123", auth.RoleGuide), &pb.UpdateTourRequest{
+		TourId:     tour.ID.Hex(),
+		GuideId:    "guide123",
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"description", "tags"}},
+	})
+
+	st := requireStatusCode(t, err, codes.InvalidArgument)
+	require.Len(t, st.Details(), 1)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	assert.Len(t, badRequest.FieldViolations, 2)
+	mockRepo.AssertNotCalled(t, "UpdateTour", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTour_UnknownMaskPath_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+
+	_, err := handler.UpdateTour(contextAs("guide123", auth.RoleGuide), &pb.UpdateTourRequest{
+		TourId:     tour.ID.Hex(),
+		GuideId:    "guide123",
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"guideId"}},
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+	assert.Equal(t, "guide123", tour.GuideID)
+}
+
+func TestUpdateTour_EmptyMask_ReturnsInvalidArgument(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+
+	_, err := handler.UpdateTour(contextAs("guide123", auth.RoleGuide), &pb.UpdateTourRequest{
+		TourId:  tour.ID.Hex(),
+		GuideId: "guide123",
+	})
+
+	requireStatusCode(t, err, codes.InvalidArgument)
+	mockRepo.AssertNotCalled(t, "GetTourByID", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTour_WrongGuide_ReturnsPermissionDenied(t *testing.T) {
+	handler, mockRepo := newStatusTestHandler()
+
+	tour := createTestTour("guide123")
+	mockRepo.On("GetTourByID", mock.Anything, tour.ID).
+		Return(tour, nil)
+
+	_, err := handler.UpdateTour(contextAs("wrongguide", auth.RoleGuide), &pb.UpdateTourRequest{
+		TourId:     tour.ID.Hex(),
+		GuideId:    "wrongguide",
+		Name:       "Hijacked",
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+	})
+
+	requireStatusCode(t, err, codes.PermissionDenied)
+	assert.Equal(t, "Test Tour", tour.Name)
+}
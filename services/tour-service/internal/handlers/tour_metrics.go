@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"tour-service/internal/models"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// transportModes lists the supported transport modes in reporting order.
+var transportModes = []string{models.TransportWalking, models.TransportBicycle, models.TransportCar}
+
+// averageSpeedKmh is the speed each suggested transport time assumes.
+var averageSpeedKmh = map[string]float64{
+	models.TransportWalking: 5,
+	models.TransportBicycle: 15,
+	models.TransportCar:     40,
+}
+
+// refreshTourMetrics recomputes the length, suggested transport times and
+// searchable keypoint text of a tour after its keypoints changed. The
+// keypoint write has already succeeded at this point, so a failure is only
+// logged and corrected by the next keypoint change.
+func (h *TourServiceHandler) refreshTourMetrics(ctx context.Context, tour *models.Tour) {
+	keyPoints, err := h.repo.GetKeyPointsByTourID(ctx, tour.ID)
+	if err != nil {
+		log.Printf("Error getting keypoints for tour metrics: %v", err)
+		return
+	}
+
+	lengthKm := tourLengthKm(keyPoints)
+	metrics := repository.TourMetrics{
+		LengthKm:          lengthKm,
+		TransportTimes:    withSuggestedTransportTimes(tour.TransportTimes, lengthKm),
+		KeyPointSummaries: keyPointSummaries(keyPoints),
+	}
+	if err := h.repo.SetTourMetrics(ctx, tour.ID, metrics); err != nil {
+		log.Printf("Error updating tour metrics: %v", err)
+	}
+}
+
+func orderedKeyPoints(keyPoints []*models.KeyPoint) []*models.KeyPoint {
+	ordered := slices.Clone(keyPoints)
+	slices.SortStableFunc(ordered, func(a, b *models.KeyPoint) int {
+		return cmp.Compare(a.Order, b.Order)
+	})
+	return ordered
+}
+
+// tourLengthKm sums the distances between consecutive keypoints in Order.
+func tourLengthKm(keyPoints []*models.KeyPoint) float64 {
+	ordered := orderedKeyPoints(keyPoints)
+
+	var meters float64
+	for i := 1; i < len(ordered); i++ {
+		prev, next := ordered[i-1], ordered[i]
+		meters += calculateDistance(prev.Latitude, prev.Longitude, next.Latitude, next.Longitude)
+	}
+	return meters / 1000
+}
+
+// keyPointSummaries copies the keypoint text, in Order, for full-text search.
+func keyPointSummaries(keyPoints []*models.KeyPoint) []models.KeyPointSummary {
+	ordered := orderedKeyPoints(keyPoints)
+	summaries := make([]models.KeyPointSummary, len(ordered))
+	for i, kp := range ordered {
+		summaries[i] = models.KeyPointSummary{Name: kp.Name, Description: kp.Description}
+	}
+	return summaries
+}
+
+// withSuggestedTransportTimes keeps the guide's own estimates and suggests a
+// time for every other mode from lengthKm. Tours without a path yet get no
+// suggestions.
+func withSuggestedTransportTimes(times []models.TransportTime, lengthKm float64) []models.TransportTime {
+	result := make([]models.TransportTime, 0, len(transportModes))
+	for _, mode := range transportModes {
+		i := slices.IndexFunc(times, func(t models.TransportTime) bool {
+			return t.Mode == mode && !t.Suggested
+		})
+		if i >= 0 {
+			result = append(result, times[i])
+			continue
+		}
+		if lengthKm > 0 {
+			result = append(result, models.TransportTime{
+				Mode:      mode,
+				Minutes:   int32(math.Ceil(lengthKm / averageSpeedKmh[mode] * 60)),
+				Suggested: true,
+			})
+		}
+	}
+	return result
+}
+
+// transportTimesFromProto converts the estimates a guide sent and reports
+// unknown or repeated modes and non-positive durations.
+func transportTimesFromProto(times []*pb.TransportTime) ([]models.TransportTime, []*errdetails.BadRequest_FieldViolation) {
+	var violations []*errdetails.BadRequest_FieldViolation
+	result := make([]models.TransportTime, 0, len(times))
+	for i, t := range times {
+		field := fmt.Sprintf("transportTimes[%d]", i)
+		switch {
+		case !slices.Contains(transportModes, t.Mode):
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       field + ".mode",
+				Description: fmt.Sprintf("unknown transport mode %q", t.Mode),
+			})
+		case slices.ContainsFunc(result, func(r models.TransportTime) bool { return r.Mode == t.Mode }):
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       field + ".mode",
+				Description: fmt.Sprintf("transport mode %q is listed twice", t.Mode),
+			})
+		case t.Minutes <= 0:
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       field + ".minutes",
+				Description: "minutes must be positive",
+			})
+		default:
+			result = append(result, models.TransportTime{Mode: t.Mode, Minutes: t.Minutes})
+		}
+	}
+	return result, violations
+}
+
+func mapTransportTimesToProto(times []models.TransportTime) []*pb.TransportTime {
+	result := make([]*pb.TransportTime, len(times))
+	for i, t := range times {
+		result[i] = &pb.TransportTime{
+			Mode:      t.Mode,
+			Minutes:   t.Minutes,
+			Suggested: t.Suggested,
+		}
+	}
+	return result
+}
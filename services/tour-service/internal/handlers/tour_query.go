@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"tour-service/internal/repository"
+	pb "tour-service/proto"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+var tourSorts = map[pb.TourSort]repository.TourSort{
+	pb.TourSort_TOUR_SORT_NEWEST:     repository.SortNewest,
+	pb.TourSort_TOUR_SORT_PRICE_ASC:  repository.SortPriceAsc,
+	pb.TourSort_TOUR_SORT_PRICE_DESC: repository.SortPriceDesc,
+	pb.TourSort_TOUR_SORT_NAME:       repository.SortName,
+}
+
+// tourQueryFromProto translates the filters and paging of a GetTours request
+// and returns every invalid field.
+func tourQueryFromProto(req *pb.GetToursRequest) (repository.TourFilter, repository.PageRequest, []*errdetails.BadRequest_FieldViolation) {
+	var violations []*errdetails.BadRequest_FieldViolation
+	violate := func(field, description string) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+	}
+
+	filter := repository.TourFilter{
+		Difficulty:         req.Difficulty,
+		GuideID:            req.GuideId,
+		Tags:               req.Tags,
+		MatchAllTags:       req.MatchAllTags,
+		MinPrice:           req.MinPrice,
+		MaxPrice:           req.MaxPrice,
+		TransportMode:      req.TransportMode,
+		MaxDurationMinutes: req.MaxDurationMinutes,
+	}
+
+	// A guide listing their own tours also sees drafts
+	if !req.PublishedOnly && req.UserId != "" {
+		if req.GuideId != "" && req.GuideId != req.UserId {
+			violate("guideId", "guide ID must match userId when listing drafts")
+		}
+		filter.GuideID = req.UserId
+		filter.IncludeDrafts = true
+	}
+
+	if req.Difficulty != "" && !slices.Contains(validDifficulties, req.Difficulty) {
+		violate("difficulty", "difficulty must be one of "+strings.Join(validDifficulties, ", "))
+	}
+	if req.MinPrice != nil && *req.MinPrice < 0 {
+		violate("minPrice", "minimum price must not be negative")
+	}
+	if req.MaxPrice != nil && *req.MaxPrice < 0 {
+		violate("maxPrice", "maximum price must not be negative")
+	}
+	if req.MinPrice != nil && req.MaxPrice != nil && *req.MinPrice > *req.MaxPrice {
+		violate("minPrice", "minimum price must not exceed maximum price")
+	}
+	filter.CreatedAfter = parseTimeFilter("createdAfter", req.CreatedAfter, violate)
+	filter.CreatedBefore = parseTimeFilter("createdBefore", req.CreatedBefore, violate)
+	if req.TransportMode != "" && !slices.Contains(transportModes, req.TransportMode) {
+		violate("transportMode", "transport mode must be one of "+strings.Join(transportModes, ", "))
+	}
+	if req.MaxDurationMinutes < 0 {
+		violate("maxDurationMinutes", "maximum duration must not be negative")
+	}
+
+	sort, ok := tourSorts[req.Sort]
+	if !ok {
+		violate("sort", fmt.Sprintf("unknown sort order %d", req.Sort))
+	}
+	if req.PageSize < 0 || req.PageSize > repository.MaxPageSize {
+		violate("pageSize", fmt.Sprintf("page size must be between 0 and %d", repository.MaxPageSize))
+	}
+	page := repository.PageRequest{
+		Sort:  sort,
+		Size:  int(req.PageSize),
+		Token: req.PageToken,
+	}
+
+	return filter, page, violations
+}
+
+func parseTimeFilter(field, value string, violate func(field, description string)) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		violate(field, "must be an RFC3339 timestamp")
+	}
+	return parsed
+}
@@ -6,29 +6,96 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Tour lifecycle states. Drafts are published once; published tours can be
+// archived and reactivated any number of times.
+const (
+	TourStatusDraft     = "draft"
+	TourStatusPublished = "published"
+	TourStatusArchived  = "archived"
+)
+
+// Execution modes of a tour. In free roam keypoints can be completed in any
+// order; sequential tours only accept the next keypoint by Order. Tours
+// without a mode are free roam.
+const (
+	ExecutionModeFreeRoam   = "free_roam"
+	ExecutionModeSequential = "sequential"
+)
+
+// Execution states. Executions start active and end completed or abandoned;
+// neither end state changes again.
+const (
+	ExecutionStatusActive    = "active"
+	ExecutionStatusCompleted = "completed"
+	ExecutionStatusAbandoned = "abandoned"
+)
+
 type Tour struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty"`
-	GuideID     string             `bson:"guideId"`
-	Name        string             `bson:"name"`
-	Description string             `bson:"description"`
-	Difficulty  string             `bson:"difficulty"`
-	Tags        []string           `bson:"tags"`
-	Status      string             `bson:"status"` // "draft", "published"
-	Price       float64            `bson:"price"`
-	IsPublished bool               `bson:"isPublished"`
-	PublishedAt time.Time          `bson:"publishedAt,omitempty"`
-	CreatedAt   time.Time          `bson:"createdAt"`
+	ID                primitive.ObjectID `bson:"_id,omitempty"`
+	GuideID           string             `bson:"guideId"`
+	Name              string             `bson:"name"`
+	Description       string             `bson:"description"`
+	Difficulty        string             `bson:"difficulty"`
+	Tags              []string           `bson:"tags"`
+	Status            string             `bson:"status"`          // "draft", "published", "archived"
+	ExecutionMode     string             `bson:"executionMode"`   // "free_roam" or "sequential"
+	ProximityRadius   float64            `bson:"proximityRadius"` // Meters; 0 uses the default
+	Price             float64            `bson:"price"`
+	IsPublished       bool               `bson:"isPublished"`
+	PublishedAt       time.Time          `bson:"publishedAt,omitempty"`
+	ArchivedAt        time.Time          `bson:"archivedAt,omitempty"`
+	LengthKm          float64            `bson:"lengthKm"` // Path length through the keypoints in Order
+	TransportTimes    []TransportTime    `bson:"transportTimes"`
+	KeyPointSummaries []KeyPointSummary  `bson:"keyPointSummaries"` // Keypoint text copied onto the tour for the text index
+	ReviewCount       int32              `bson:"reviewCount"`
+	RatingTotal       int32              `bson:"ratingTotal"`   // Sum of all review ratings
+	AverageRating     float64            `bson:"averageRating"` // RatingTotal / ReviewCount, 0 without reviews
+	CreatedAt         time.Time          `bson:"createdAt"`
+}
+
+// Transport modes a tour duration can be estimated for.
+const (
+	TransportWalking = "walking"
+	TransportBicycle = "bicycle"
+	TransportCar     = "car"
+)
+
+// TransportTime is how long a tour takes in one transport mode. Suggested
+// entries are derived from the tour length and replaced whenever it changes;
+// entries set by the guide are kept as they are.
+type TransportTime struct {
+	Mode      string `bson:"mode"`
+	Minutes   int32  `bson:"minutes"`
+	Suggested bool   `bson:"suggested"`
+}
+
+// KeyPointSummary is the searchable text of a keypoint.
+type KeyPointSummary struct {
+	Name        string `bson:"name"`
+	Description string `bson:"description"`
 }
 
 type KeyPoint struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty"`
-	TourID      primitive.ObjectID `bson:"tourId"`
-	Latitude    float64            `bson:"latitude"`
-	Longitude   float64            `bson:"longitude"`
-	Name        string             `bson:"name"`
-	Description string             `bson:"description"`
-	Image       string             `bson:"image"`
-	Order       int32              `bson:"order"`
+	ID              primitive.ObjectID `bson:"_id,omitempty"`
+	TourID          primitive.ObjectID `bson:"tourId"`
+	Latitude        float64            `bson:"latitude"`
+	Longitude       float64            `bson:"longitude"`
+	Name            string             `bson:"name"`
+	Description     string             `bson:"description"`
+	Image           string             `bson:"image"`
+	Order           int32              `bson:"order"`
+	Location        GeoPoint           `bson:"location"`                  // Mirrors Latitude/Longitude for geospatial queries
+	ProximityRadius float64            `bson:"proximityRadius,omitempty"` // Meters; 0 uses the tour's radius
+}
+
+// GeoPoint is a GeoJSON point. Coordinates are [longitude, latitude].
+type GeoPoint struct {
+	Type        string    `bson:"type"`
+	Coordinates []float64 `bson:"coordinates"`
+}
+
+func NewGeoPoint(latitude, longitude float64) GeoPoint {
+	return GeoPoint{Type: "Point", Coordinates: []float64{longitude, latitude}}
 }
 
 type Position struct {
@@ -39,6 +106,16 @@ type Position struct {
 	UpdatedAt time.Time          `bson:"updatedAt"`
 }
 
+// PositionSample is a position recorded while an execution was active. The
+// samples of an execution, in time order, are the path the tourist walked.
+type PositionSample struct {
+	ExecutionID primitive.ObjectID `bson:"executionId"`
+	TouristID   string             `bson:"touristId"`
+	Latitude    float64            `bson:"latitude"`
+	Longitude   float64            `bson:"longitude"`
+	RecordedAt  time.Time          `bson:"recordedAt"`
+}
+
 type ShoppingCart struct {
 	ID         primitive.ObjectID `bson:"_id,omitempty"`
 	TouristID  string             `bson:"touristId"`
@@ -60,20 +137,50 @@ type PurchaseToken struct {
 	PurchasedAt time.Time          `bson:"purchasedAt"`
 }
 
+// CheckoutRecord remembers the outcome of a checkout made with an
+// idempotency key so that retries replay it instead of buying again. It is
+// stored Pending before the checkout starts, which holds the key against
+// concurrent calls, and filled in with the tokens once the purchase is made.
+type CheckoutRecord struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	TouristID      string             `bson:"touristId"`
+	IdempotencyKey string             `bson:"idempotencyKey"`
+	Tokens         []PurchaseToken    `bson:"tokens"`
+	Pending        bool               `bson:"pending,omitempty"`
+	CreatedAt      time.Time          `bson:"createdAt"`
+}
+
 type TourExecution struct {
-	ID                 primitive.ObjectID  `bson:"_id,omitempty"`
-	TouristID          string              `bson:"touristId"`
-	TourID             primitive.ObjectID  `bson:"tourId"`
-	Status             string              `bson:"status"` // "active", "completed", "abandoned"
-	StartedAt          time.Time           `bson:"startedAt"`
-	CompletedAt        time.Time           `bson:"completedAt,omitempty"`
-	LastActivity       time.Time           `bson:"lastActivity"`
-	StartLatitude      float64             `bson:"startLatitude"`
-	StartLongitude     float64             `bson:"startLongitude"`
-	CompletedKeypoints []CompletedKeypoint `bson:"completedKeypoints"`
+	ID                   primitive.ObjectID  `bson:"_id,omitempty"`
+	TouristID            string              `bson:"touristId"`
+	TourID               primitive.ObjectID  `bson:"tourId"`
+	Status               string              `bson:"status"`          // ExecutionStatus*
+	Mode                 string              `bson:"mode"`            // ExecutionMode of the tour when the execution started
+	ProximityRadius      float64             `bson:"proximityRadius"` // Tour radius when the execution started, 0 for the default
+	StartedAt            time.Time           `bson:"startedAt"`
+	CompletedAt          time.Time           `bson:"completedAt,omitempty"`
+	LastActivity         time.Time           `bson:"lastActivity"`
+	StartLatitude        float64             `bson:"startLatitude"`
+	StartLongitude       float64             `bson:"startLongitude"`
+	CompletedKeypoints   []CompletedKeypoint `bson:"completedKeypoints"`
+	CompletionPercentage float64             `bson:"completionPercentage,omitempty"` // Share of keypoints completed, 0 to 100, recorded on completion
 }
 
 type CompletedKeypoint struct {
 	KeypointID  primitive.ObjectID `bson:"keypointId"`
 	CompletedAt time.Time          `bson:"completedAt"`
 }
+
+// Review is a tourist's rating of a tour they bought and walked. A tourist
+// reviews a tour once and edits that review afterwards.
+type Review struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	TourID    primitive.ObjectID `bson:"tourId"`
+	TouristID string             `bson:"touristId"`
+	Rating    int32              `bson:"rating"` // 1 to 5
+	Comment   string             `bson:"comment"`
+	VisitDate time.Time          `bson:"visitDate"`
+	Images    []string           `bson:"images"`
+	CreatedAt time.Time          `bson:"createdAt"`
+	UpdatedAt time.Time          `bson:"updatedAt"`
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	// ErrCartEmpty is returned by CheckoutCart when there is nothing to buy.
+	ErrCartEmpty = errors.New("cart is empty")
+	// ErrCartChanged is returned by CheckoutCart when the cart was modified
+	// while the checkout was in progress.
+	ErrCartChanged = errors.New("cart changed during checkout")
+	// ErrTourStatusChanged is returned by lifecycle transitions when the tour
+	// is no longer in the state the transition starts from.
+	ErrTourStatusChanged = errors.New("tour status changed")
+	// ErrInvalidPageToken is returned by listings for a page token that is
+	// malformed or was issued for a different sort order.
+	ErrInvalidPageToken = errors.New("invalid page token")
+	// ErrReviewExists is returned by CreateReview when the tourist has
+	// already reviewed the tour.
+	ErrReviewExists = errors.New("tour already reviewed")
+	// ErrReviewChanged is returned by UpdateReview when the review was edited
+	// concurrently.
+	ErrReviewChanged = errors.New("review changed")
+	// ErrKeyPointsMismatch is returned by ReorderKeyPoints when the new
+	// sequence does not list every keypoint of the tour exactly once.
+	ErrKeyPointsMismatch = errors.New("keypoint sequence does not match the tour")
+	// ErrExecutionStatusChanged is returned by UpdateExecution when the
+	// execution was completed or abandoned since it was read.
+	ErrExecutionStatusChanged = errors.New("execution status changed")
+
+	// errCheckoutReservationLost is returned when a checkout ran so long
+	// that a retry with the same idempotency key took the key over.
+	errCheckoutReservationLost = errors.New("checkout reservation lost")
+)
+
+// AlreadyPurchasedError is returned by CheckoutCart when the tourist already
+// owns one or more of the tours in the cart. Nothing is purchased.
+type AlreadyPurchasedError struct {
+	TourIDs []primitive.ObjectID
+}
+
+func (e *AlreadyPurchasedError) Error() string {
+	ids := make([]string, len(e.TourIDs))
+	for i, id := range e.TourIDs {
+		ids[i] = id.Hex()
+	}
+	return fmt.Sprintf("tours already purchased: %s", strings.Join(ids, ", "))
+}
+
+// UnavailableToursError is returned by CheckoutCart when tours in the cart
+// were archived or unpublished after they were added. Nothing is purchased.
+type UnavailableToursError struct {
+	TourIDs []primitive.ObjectID
+}
+
+func (e *UnavailableToursError) Error() string {
+	ids := make([]string, len(e.TourIDs))
+	for i, id := range e.TourIDs {
+		ids[i] = id.Hex()
+	}
+	return fmt.Sprintf("tours no longer available: %s", strings.Join(ids, ", "))
+}
+
+// isTextIndexMissing reports whether err comes from a $text query on a
+// collection without a text index.
+func isTextIndexMissing(err error) bool {
+	var serverErr mongo.ServerError
+	if !errors.As(err, &serverErr) {
+		return false
+	}
+	return serverErr.HasErrorCode(27)
+}
+
+// isTransactionUnsupported reports whether err comes from running a
+// transaction against a standalone mongod, which only replica sets and
+// sharded clusters support.
+func isTransactionUnsupported(err error) bool {
+	var serverErr mongo.ServerError
+	if !errors.As(err, &serverErr) {
+		return false
+	}
+	return serverErr.HasErrorCode(20) && serverErr.HasErrorMessage("Transaction numbers are only allowed")
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+	"tour-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ExecutionFilter narrows ListExecutions to one tourist's executions. The
+// other zero values leave a field unfiltered.
+type ExecutionFilter struct {
+	TouristID string
+	Status    string
+	TourID    primitive.ObjectID
+
+	// StartedAfter and StartedBefore are exclusive bounds on
+	// TourExecution.StartedAt.
+	StartedAfter  time.Time
+	StartedBefore time.Time
+}
+
+func (f ExecutionFilter) query() bson.M {
+	query := bson.M{"touristId": f.TouristID}
+	if f.Status != "" {
+		query["status"] = f.Status
+	}
+	if !f.TourID.IsZero() {
+		query["tourId"] = f.TourID
+	}
+	if started := timeRangeQuery(f.StartedAfter, f.StartedBefore); len(started) > 0 {
+		query["startedAt"] = started
+	}
+	return query
+}
+
+// executionOrder lists the most recently started executions first.
+var executionOrder = bson.D{{Key: "startedAt", Value: -1}, {Key: "_id", Value: -1}}
+
+// ExecutionPage is one page of executions, most recently started first.
+// NextPageToken is empty on the last page.
+type ExecutionPage struct {
+	Executions    []*models.TourExecution
+	NextPageToken string
+}
+
+// executionPageToken records the start of the last execution on a page.
+type executionPageToken struct {
+	StartedAt time.Time          `json:"st"`
+	ID        primitive.ObjectID `json:"id"`
+}
+
+func encodeExecutionPageToken(last *models.TourExecution) string {
+	data, _ := json.Marshal(executionPageToken{StartedAt: last.StartedAt, ID: last.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeExecutionPageToken(token string) (*executionPageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var decoded executionPageToken
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.ID.IsZero() {
+		return nil, ErrInvalidPageToken
+	}
+	return &decoded, nil
+}
+
+// after matches the executions that follow the token in executionOrder.
+func (t *executionPageToken) after() bson.A {
+	return bson.A{
+		bson.M{"startedAt": bson.M{"$lt": t.StartedAt}},
+		bson.M{"startedAt": t.StartedAt, "_id": bson.M{"$lt": t.ID}},
+	}
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"testing"
+	"time"
+	"tour-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestExecutionFilter_Empty_MatchesTouristOnly(t *testing.T) {
+	assert.Equal(t, bson.M{"touristId": "tourist123"}, ExecutionFilter{TouristID: "tourist123"}.query())
+}
+
+func TestExecutionFilter_AllFields(t *testing.T) {
+	tourID := primitive.NewObjectID()
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	query := ExecutionFilter{
+		TouristID:     "tourist123",
+		Status:        models.ExecutionStatusCompleted,
+		TourID:        tourID,
+		StartedAfter:  after,
+		StartedBefore: before,
+	}.query()
+
+	assert.Equal(t, bson.M{
+		"touristId": "tourist123",
+		"status":    models.ExecutionStatusCompleted,
+		"tourId":    tourID,
+		"startedAt": bson.M{"$gt": after, "$lt": before},
+	}, query)
+}
+
+func TestExecutionPageToken_RoundTrips(t *testing.T) {
+	execution := &models.TourExecution{
+		ID:        primitive.NewObjectID(),
+		StartedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	token, err := decodeExecutionPageToken(encodeExecutionPageToken(execution))
+
+	require.NoError(t, err)
+	assert.Equal(t, execution.ID, token.ID)
+	assert.True(t, execution.StartedAt.Equal(token.StartedAt))
+}
+
+func TestExecutionPageToken_Garbage_IsRejected(t *testing.T) {
+	_, err := decodeExecutionPageToken("not a token!")
+
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestExecutionPageToken_After_ResumesPastLastExecution(t *testing.T) {
+	started := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	id := primitive.NewObjectID()
+	token := &executionPageToken{StartedAt: started, ID: id}
+
+	assert.Equal(t, bson.A{
+		bson.M{"startedAt": bson.M{"$lt": started}},
+		bson.M{"startedAt": started, "_id": bson.M{"$lt": id}},
+	}, token.after())
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"slices"
+	"time"
 	"tour-service/internal/models"
 
 	"github.com/stretchr/testify/mock"
@@ -35,12 +37,12 @@ func (m *MockTourRepository) GetToursByGuideID(ctx context.Context, guideID stri
 	return args.Get(0).([]*models.Tour), args.Error(1)
 }
 
-func (m *MockTourRepository) GetPublishedTours(ctx context.Context) ([]*models.Tour, error) {
-	args := m.Called(ctx)
+func (m *MockTourRepository) ListTours(ctx context.Context, filter TourFilter, page PageRequest) (*TourPage, error) {
+	args := m.Called(ctx, filter, page)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*models.Tour), args.Error(1)
+	return args.Get(0).(*TourPage), args.Error(1)
 }
 
 func (m *MockTourRepository) UpdateTour(ctx context.Context, tour *models.Tour) error {
@@ -53,6 +55,21 @@ func (m *MockTourRepository) PublishTour(ctx context.Context, tourID primitive.O
 	return args.Error(0)
 }
 
+func (m *MockTourRepository) ArchiveTour(ctx context.Context, tourID primitive.ObjectID) error {
+	args := m.Called(ctx, tourID)
+	return args.Error(0)
+}
+
+func (m *MockTourRepository) ReactivateTour(ctx context.Context, tourID primitive.ObjectID) error {
+	args := m.Called(ctx, tourID)
+	return args.Error(0)
+}
+
+func (m *MockTourRepository) SetTourMetrics(ctx context.Context, tourID primitive.ObjectID, metrics TourMetrics) error {
+	args := m.Called(ctx, tourID, metrics)
+	return args.Error(0)
+}
+
 // ── KeyPoint operations ──────────────────────────────────────────────────────
 
 func (m *MockTourRepository) CreateKeyPoint(ctx context.Context, keypoint *models.KeyPoint) error {
@@ -73,11 +90,46 @@ func (m *MockTourRepository) UpdateKeyPoint(ctx context.Context, keypoint *model
 	return args.Error(0)
 }
 
-func (m *MockTourRepository) DeleteKeyPoint(ctx context.Context, keypointID primitive.ObjectID) error {
-	args := m.Called(ctx, keypointID)
+func (m *MockTourRepository) DeleteKeyPoint(ctx context.Context, tourID, keypointID primitive.ObjectID) error {
+	args := m.Called(ctx, tourID, keypointID)
+	return args.Error(0)
+}
+
+func (m *MockTourRepository) ReorderKeyPoints(ctx context.Context, tourID primitive.ObjectID, keyPointIDs []primitive.ObjectID) error {
+	args := m.Called(ctx, tourID, keyPointIDs)
+	return args.Error(0)
+}
+
+func (m *MockTourRepository) ImportKeyPoints(ctx context.Context, tourID primitive.ObjectID, keypoints []*models.KeyPoint, replace bool) error {
+	args := m.Called(ctx, tourID, keypoints, replace)
 	return args.Error(0)
 }
 
+func (m *MockTourRepository) SearchToursNearby(ctx context.Context, latitude, longitude, radiusMeters float64, limit int) ([]*NearbyTour, error) {
+	args := m.Called(ctx, latitude, longitude, radiusMeters, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*NearbyTour), args.Error(1)
+}
+
+// SearchTours returns the page it was stubbed with, or searches the tours it
+// was stubbed with using MatchTours the way the repository does without a
+// text index.
+func (m *MockTourRepository) SearchTours(ctx context.Context, query string, page PageRequest) (*TourSearchPage, error) {
+	args := m.Called(ctx, query, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	if tours, ok := args.Get(0).([]*models.Tour); ok {
+		published := slices.DeleteFunc(slices.Clone(tours), func(tour *models.Tour) bool {
+			return !tour.IsPublished
+		})
+		return pageSearchHits(MatchTours(published, query), query, page)
+	}
+	return args.Get(0).(*TourSearchPage), args.Error(1)
+}
+
 // ── Position operations ──────────────────────────────────────────────────────
 
 func (m *MockTourRepository) UpsertPosition(ctx context.Context, position *models.Position) error {
@@ -93,6 +145,14 @@ func (m *MockTourRepository) GetPosition(ctx context.Context, touristID string)
 	return args.Get(0).(*models.Position), args.Error(1)
 }
 
+func (m *MockTourRepository) GetPositionSamples(ctx context.Context, executionID primitive.ObjectID) ([]*models.PositionSample, error) {
+	args := m.Called(ctx, executionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PositionSample), args.Error(1)
+}
+
 // ── Cart operations ──────────────────────────────────────────────────────────
 
 func (m *MockTourRepository) GetOrCreateCart(ctx context.Context, touristID string) (*models.ShoppingCart, error) {
@@ -108,21 +168,49 @@ func (m *MockTourRepository) UpdateCart(ctx context.Context, cart *models.Shoppi
 	return args.Error(0)
 }
 
-func (m *MockTourRepository) ClearCart(ctx context.Context, touristID string) error {
-	args := m.Called(ctx, touristID)
-	return args.Error(0)
+// ── Purchase token operations ────────────────────────────────────────────────
+
+func (m *MockTourRepository) HasPurchased(ctx context.Context, touristID string, tourID primitive.ObjectID) (bool, error) {
+	args := m.Called(ctx, touristID, tourID)
+	return args.Bool(0), args.Error(1)
 }
 
-// ── Purchase token operations ────────────────────────────────────────────────
+// ── Checkout ─────────────────────────────────────────────────────────────────
+
+func (m *MockTourRepository) CheckoutCart(ctx context.Context, touristID, idempotencyKey string) (*CheckoutResult, error) {
+	args := m.Called(ctx, touristID, idempotencyKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*CheckoutResult), args.Error(1)
+}
+
+// ── Review operations ────────────────────────────────────────────────────────
 
-func (m *MockTourRepository) CreatePurchaseToken(ctx context.Context, token *models.PurchaseToken) error {
-	args := m.Called(ctx, token)
+func (m *MockTourRepository) CreateReview(ctx context.Context, review *models.Review) error {
+	args := m.Called(ctx, review)
 	return args.Error(0)
 }
 
-func (m *MockTourRepository) HasPurchased(ctx context.Context, touristID string, tourID primitive.ObjectID) (bool, error) {
-	args := m.Called(ctx, touristID, tourID)
-	return args.Bool(0), args.Error(1)
+func (m *MockTourRepository) GetReviewByID(ctx context.Context, reviewID primitive.ObjectID) (*models.Review, error) {
+	args := m.Called(ctx, reviewID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Review), args.Error(1)
+}
+
+func (m *MockTourRepository) GetReviewsByTourID(ctx context.Context, tourID primitive.ObjectID) ([]*models.Review, error) {
+	args := m.Called(ctx, tourID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Review), args.Error(1)
+}
+
+func (m *MockTourRepository) UpdateReview(ctx context.Context, review *models.Review, previousRating int32) error {
+	args := m.Called(ctx, review, previousRating)
+	return args.Error(0)
 }
 
 // ── Execution operations ─────────────────────────────────────────────────────
@@ -145,6 +233,11 @@ func (m *MockTourRepository) UpdateExecution(ctx context.Context, execution *mod
 	return args.Error(0)
 }
 
+func (m *MockTourRepository) AbandonStaleExecutions(ctx context.Context, inactiveSince time.Time) (int64, error) {
+	args := m.Called(ctx, inactiveSince)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockTourRepository) GetActiveExecution(ctx context.Context, touristID string, tourID primitive.ObjectID) (*models.TourExecution, error) {
 	args := m.Called(ctx, touristID, tourID)
 	if args.Get(0) == nil {
@@ -153,10 +246,15 @@ func (m *MockTourRepository) GetActiveExecution(ctx context.Context, touristID s
 	return args.Get(0).(*models.TourExecution), args.Error(1)
 }
 
-func (m *MockTourRepository) GetExecutionsByTouristID(ctx context.Context, touristID string) ([]*models.TourExecution, error) {
-	args := m.Called(ctx, touristID)
+func (m *MockTourRepository) ListExecutions(ctx context.Context, filter ExecutionFilter, page PageRequest) (*ExecutionPage, error) {
+	args := m.Called(ctx, filter, page)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*models.TourExecution), args.Error(1)
+	return args.Get(0).(*ExecutionPage), args.Error(1)
+}
+
+func (m *MockTourRepository) HasReviewableExecution(ctx context.Context, touristID string, tourID primitive.ObjectID, activeSince time.Time) (bool, error) {
+	args := m.Called(ctx, touristID, tourID, activeSince)
+	return args.Bool(0), args.Error(1)
 }
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+	"tour-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Page size bounds for listings.
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// TourSort orders tour listings. Every order breaks ties on the tour ID so
+// pages never overlap or skip tours.
+type TourSort int
+
+const (
+	SortNewest TourSort = iota
+	SortPriceAsc
+	SortPriceDesc
+	SortName
+)
+
+func (s TourSort) key() (field string, direction int) {
+	switch s {
+	case SortPriceAsc:
+		return "price", 1
+	case SortPriceDesc:
+		return "price", -1
+	case SortName:
+		return "name", 1
+	default:
+		return "createdAt", -1
+	}
+}
+
+func (s TourSort) order() bson.D {
+	field, direction := s.key()
+	return bson.D{{Key: field, Value: direction}, {Key: "_id", Value: direction}}
+}
+
+// PageRequest selects one page of a listing. Token is the NextPageToken of
+// the previous page and is only valid with the same Sort.
+type PageRequest struct {
+	Sort  TourSort
+	Size  int
+	Token string
+}
+
+func (p PageRequest) size() int {
+	switch {
+	case p.Size <= 0:
+		return DefaultPageSize
+	case p.Size > MaxPageSize:
+		return MaxPageSize
+	default:
+		return p.Size
+	}
+}
+
+// TourPage is one page of tours. NextPageToken is empty on the last page.
+type TourPage struct {
+	Tours         []*models.Tour
+	NextPageToken string
+}
+
+// pageToken records the sort key of the last tour on a page, so the next page
+// can resume right after it even if tours were added in the meantime.
+type pageToken struct {
+	Sort      TourSort           `json:"s"`
+	ID        primitive.ObjectID `json:"id"`
+	Price     float64            `json:"p,omitempty"`
+	Name      string             `json:"n,omitempty"`
+	CreatedAt time.Time          `json:"c,omitempty"`
+}
+
+func encodePageToken(sort TourSort, last *models.Tour) string {
+	data, _ := json.Marshal(pageToken{
+		Sort:      sort,
+		ID:        last.ID,
+		Price:     last.Price,
+		Name:      last.Name,
+		CreatedAt: last.CreatedAt,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(token string, sort TourSort) (*pageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var decoded pageToken
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.ID.IsZero() || decoded.Sort != sort {
+		return nil, ErrInvalidPageToken
+	}
+	return &decoded, nil
+}
+
+// after matches the tours that follow the token in sort order.
+func (t *pageToken) after() bson.A {
+	field, direction := t.Sort.key()
+	operator := "$gt"
+	if direction < 0 {
+		operator = "$lt"
+	}
+
+	var value interface{}
+	switch field {
+	case "price":
+		value = t.Price
+	case "name":
+		value = t.Name
+	default:
+		value = t.CreatedAt
+	}
+
+	return bson.A{
+		bson.M{field: bson.M{operator: value}},
+		bson.M{field: value, "_id": bson.M{operator: t.ID}},
+	}
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"testing"
+	"time"
+	"tour-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPageRequest_Size_AppliesDefaultAndLimit(t *testing.T) {
+	assert.Equal(t, DefaultPageSize, PageRequest{}.size())
+	assert.Equal(t, 5, PageRequest{Size: 5}.size())
+	assert.Equal(t, MaxPageSize, PageRequest{Size: 1000}.size())
+}
+
+func TestPageToken_RoundTrips(t *testing.T) {
+	tour := &models.Tour{ID: primitive.NewObjectID(), Name: "Fortress walk", Price: 12.5}
+
+	token, err := decodePageToken(encodePageToken(SortName, tour), SortName)
+
+	require.NoError(t, err)
+	assert.Equal(t, tour.ID, token.ID)
+	assert.Equal(t, "Fortress walk", token.Name)
+}
+
+func TestPageToken_OtherSort_IsRejected(t *testing.T) {
+	tour := &models.Tour{ID: primitive.NewObjectID(), Price: 12.5}
+
+	_, err := decodePageToken(encodePageToken(SortPriceAsc, tour), SortNewest)
+
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestPageToken_Garbage_IsRejected(t *testing.T) {
+	_, err := decodePageToken("not a token!", SortNewest)
+
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestPageToken_After_ResumesPastLastTourInSortOrder(t *testing.T) {
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	id := primitive.NewObjectID()
+	token := &pageToken{Sort: SortNewest, ID: id, CreatedAt: created}
+
+	assert.Equal(t, bson.A{
+		bson.M{"createdAt": bson.M{"$lt": created}},
+		bson.M{"createdAt": created, "_id": bson.M{"$lt": id}},
+	}, token.after())
+}
+
+func TestTourSort_Order_BreaksTiesOnID(t *testing.T) {
+	assert.Equal(t, bson.D{{Key: "price", Value: 1}, {Key: "_id", Value: 1}}, SortPriceAsc.order())
+	assert.Equal(t, bson.D{{Key: "price", Value: -1}, {Key: "_id", Value: -1}}, SortPriceDesc.order())
+}
@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+	"tour-service/internal/models"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Snippet fields, named as clients see them.
+const (
+	SnippetName                = "name"
+	SnippetDescription         = "description"
+	SnippetTags                = "tags"
+	SnippetKeyPointName        = "keyPoints.name"
+	SnippetKeyPointDescription = "keyPoints.description"
+)
+
+// textField is one field of the tours text index. MatchTours scores with the
+// same weights, so both rank results alike.
+type textField struct {
+	path    string
+	snippet string
+	weight  int
+	values  func(tour *models.Tour) []string
+}
+
+// textFields lists the indexed fields from the most to the least relevant.
+var textFields = []textField{
+	{"name", SnippetName, 10, func(t *models.Tour) []string { return []string{t.Name} }},
+	{"tags", SnippetTags, 5, func(t *models.Tour) []string { return t.Tags }},
+	{"keyPointSummaries.name", SnippetKeyPointName, 3, func(t *models.Tour) []string {
+		names := make([]string, len(t.KeyPointSummaries))
+		for i, kp := range t.KeyPointSummaries {
+			names[i] = kp.Name
+		}
+		return names
+	}},
+	{"description", SnippetDescription, 2, func(t *models.Tour) []string { return []string{t.Description} }},
+	{"keyPointSummaries.description", SnippetKeyPointDescription, 1, func(t *models.Tour) []string {
+		descriptions := make([]string, len(t.KeyPointSummaries))
+		for i, kp := range t.KeyPointSummaries {
+			descriptions[i] = kp.Description
+		}
+		return descriptions
+	}},
+}
+
+func textIndexKeys() bson.D {
+	keys := make(bson.D, len(textFields))
+	for i, f := range textFields {
+		keys[i] = bson.E{Key: f.path, Value: "text"}
+	}
+	return keys
+}
+
+func textIndexWeights() bson.D {
+	weights := make(bson.D, len(textFields))
+	for i, f := range textFields {
+		weights[i] = bson.E{Key: f.path, Value: f.weight}
+	}
+	return weights
+}
+
+// Snippet bounds.
+const (
+	maxSnippets      = 3
+	snippetRunes     = 160
+	snippetLeadRunes = 40
+)
+
+// Highlight marks a matched word in a snippet. Start and End are character
+// offsets into the snippet text, End exclusive.
+type Highlight struct {
+	Start int
+	End   int
+}
+
+// Snippet is an excerpt of a matched field.
+type Snippet struct {
+	Field      string
+	Text       string
+	Highlights []Highlight
+}
+
+// TourSearchHit is one search result. Higher scores are more relevant.
+type TourSearchHit struct {
+	Tour     *models.Tour
+	Score    float64
+	Snippets []Snippet
+}
+
+// TourSearchPage is one page of search results, most relevant first.
+// NextPageToken is empty on the last page.
+type TourSearchPage struct {
+	Hits          []*TourSearchHit
+	NextPageToken string
+}
+
+// searchTerms splits a query into distinct lower-case words.
+func searchTerms(query string) []string {
+	var terms []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(query), isWordSeparator) {
+		if !slices.Contains(terms, word) {
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+func isWordSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// matchWords returns the highlights of the words in text that start with one
+// of the terms.
+func matchWords(text []rune, terms []string) []Highlight {
+	var highlights []Highlight
+	for start := 0; start < len(text); {
+		if isWordSeparator(text[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(text) && !isWordSeparator(text[end]) {
+			end++
+		}
+		word := strings.ToLower(string(text[start:end]))
+		for _, term := range terms {
+			if strings.HasPrefix(word, term) {
+				highlights = append(highlights, Highlight{Start: start, End: end})
+				break
+			}
+		}
+		start = end
+	}
+	return highlights
+}
+
+// excerpt cuts a window of at most snippetRunes characters around the first
+// highlight and shifts the highlights into it.
+func excerpt(text []rune, highlights []Highlight) Snippet {
+	start, end := 0, len(text)
+	if len(text) > snippetRunes {
+		start = max(0, highlights[0].Start-snippetLeadRunes)
+		end = min(len(text), start+snippetRunes)
+		start = max(0, end-snippetRunes)
+	}
+
+	prefix := ""
+	if start > 0 {
+		prefix = "…"
+	}
+	suffix := ""
+	if end < len(text) {
+		suffix = "…"
+	}
+
+	shift := start - len([]rune(prefix))
+	var visible []Highlight
+	for _, h := range highlights {
+		if h.Start >= start && h.End <= end {
+			visible = append(visible, Highlight{Start: h.Start - shift, End: h.End - shift})
+		}
+	}
+	return Snippet{Text: prefix + string(text[start:end]) + suffix, Highlights: visible}
+}
+
+// MatchTour matches a tour against a query the way the text index does,
+// treating every query word as a case-insensitive word prefix. It returns nil
+// when no word matches.
+func MatchTour(tour *models.Tour, query string) *TourSearchHit {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	hit := &TourSearchHit{Tour: tour}
+	for _, f := range textFields {
+		for _, value := range f.values(tour) {
+			text := []rune(value)
+			highlights := matchWords(text, terms)
+			if len(highlights) == 0 {
+				continue
+			}
+			hit.Score += float64(f.weight * len(highlights))
+			if len(hit.Snippets) < maxSnippets {
+				snippet := excerpt(text, highlights)
+				snippet.Field = f.snippet
+				hit.Snippets = append(hit.Snippets, snippet)
+			}
+		}
+	}
+	if hit.Score == 0 {
+		return nil
+	}
+	return hit
+}
+
+// MatchTours searches tours without Mongo, most relevant first with ties
+// broken on the tour ID. MockTourRepository searches with it, and
+// SearchTours falls back to it when the tours text index is missing.
+func MatchTours(tours []*models.Tour, query string) []*TourSearchHit {
+	var hits []*TourSearchHit
+	for _, tour := range tours {
+		if hit := MatchTour(tour, query); hit != nil {
+			hits = append(hits, hit)
+		}
+	}
+	slices.SortFunc(hits, func(a, b *TourSearchHit) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Tour.ID.Hex(), b.Tour.ID.Hex())
+	})
+	return hits
+}
+
+// pageSearchHits cuts the page after page.Token out of hits ranked by
+// MatchTours.
+func pageSearchHits(hits []*TourSearchHit, query string, page PageRequest) (*TourSearchPage, error) {
+	if page.Token != "" {
+		token, err := decodeSearchPageToken(page.Token, query, searchSourceMatcher)
+		if err != nil {
+			return nil, err
+		}
+		hits = slices.DeleteFunc(hits, func(hit *TourSearchHit) bool {
+			return !token.follows(hit)
+		})
+	}
+
+	size := page.size()
+	result := &TourSearchPage{Hits: hits}
+	if len(hits) > size {
+		result.Hits = hits[:size]
+		result.NextPageToken = encodeSearchPageToken(query, searchSourceMatcher, hits[size-1])
+	}
+	return result, nil
+}
+
+// Search scoring backends. Text index and MatchTours scores are not
+// comparable, so a page token only resumes the backend that issued it.
+const (
+	searchSourceIndex   = "index"
+	searchSourceMatcher = "match"
+)
+
+// searchPageToken records the score of the last hit on a page. It is bound to
+// the query and the scoring backend it was issued for.
+type searchPageToken struct {
+	Query  string             `json:"q"`
+	Source string             `json:"src"`
+	Score  float64            `json:"sc"`
+	ID     primitive.ObjectID `json:"id"`
+}
+
+func encodeSearchPageToken(query, source string, last *TourSearchHit) string {
+	data, _ := json.Marshal(searchPageToken{Query: query, Source: source, Score: last.Score, ID: last.Tour.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSearchPageToken(token, query, source string) (*searchPageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var decoded searchPageToken
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.ID.IsZero() || decoded.Query != query || decoded.Source != source {
+		return nil, ErrInvalidPageToken
+	}
+	return &decoded, nil
+}
+
+// follows reports whether hit comes after the token in relevance order.
+func (t *searchPageToken) follows(hit *TourSearchHit) bool {
+	if hit.Score != t.Score {
+		return hit.Score < t.Score
+	}
+	return strings.Compare(hit.Tour.ID.Hex(), t.ID.Hex()) > 0
+}
+
+// after matches the hits that follow the token in relevance order.
+func (t *searchPageToken) after() bson.A {
+	return bson.A{
+		bson.M{"score": bson.M{"$lt": t.Score}},
+		bson.M{"score": t.Score, "_id": bson.M{"$gt": t.ID}},
+	}
+}
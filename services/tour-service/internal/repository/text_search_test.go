@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"tour-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newSearchTour(name, description string, tags ...string) *models.Tour {
+	return &models.Tour{ID: primitive.NewObjectID(), Name: name, Description: description, Tags: tags}
+}
+
+func TestMatchTour_MatchesWordPrefixesCaseInsensitively(t *testing.T) {
+	tour := newSearchTour("Belgrade Fortress", "Walk along the old walls")
+
+	hit := MatchTour(tour, "FORT")
+
+	require.NotNil(t, hit)
+	require.Len(t, hit.Snippets, 1)
+	assert.Equal(t, SnippetName, hit.Snippets[0].Field)
+	assert.Equal(t, []Highlight{{Start: 9, End: 17}}, hit.Snippets[0].Highlights)
+}
+
+func TestMatchTour_NoMatchingWord_ReturnsNil(t *testing.T) {
+	tour := newSearchTour("Belgrade Fortress", "Walk along the old walls")
+
+	assert.Nil(t, MatchTour(tour, "river"))
+	assert.Nil(t, MatchTour(tour, "  ,, "))
+	assert.Nil(t, MatchTour(tour, "ress"), "matches start at word boundaries")
+}
+
+func TestMatchTour_SearchesKeyPointText(t *testing.T) {
+	tour := newSearchTour("City walk", "An afternoon downtown")
+	tour.KeyPointSummaries = []models.KeyPointSummary{
+		{Name: "Kalemegdan", Description: "Park around the fortress"},
+	}
+
+	hit := MatchTour(tour, "fortress")
+
+	require.NotNil(t, hit)
+	require.Len(t, hit.Snippets, 1)
+	assert.Equal(t, SnippetKeyPointDescription, hit.Snippets[0].Field)
+	assert.Equal(t, 1.0, hit.Score)
+}
+
+func TestMatchTour_LongText_IsCutAroundFirstMatch(t *testing.T) {
+	description := strings.Repeat("lorem ipsum ", 30) + "Danube" + strings.Repeat(" dolor sit", 30)
+	tour := newSearchTour("City walk", description)
+
+	hit := MatchTour(tour, "danube")
+
+	require.NotNil(t, hit)
+	snippet := hit.Snippets[0]
+	text := []rune(snippet.Text)
+	assert.LessOrEqual(t, len(text), snippetRunes+2)
+	assert.True(t, strings.HasPrefix(snippet.Text, "…"))
+	assert.True(t, strings.HasSuffix(snippet.Text, "…"))
+	require.Len(t, snippet.Highlights, 1)
+	h := snippet.Highlights[0]
+	assert.Equal(t, "Danube", string(text[h.Start:h.End]))
+}
+
+func TestMatchTours_RanksByFieldWeight(t *testing.T) {
+	inDescription := newSearchTour("City walk", "Ends at the fortress")
+	inName := newSearchTour("Fortress walk", "Around the walls")
+	inTags := newSearchTour("Old town", "Around the walls", "fortress")
+
+	hits := MatchTours([]*models.Tour{inDescription, inName, inTags}, "fortress")
+
+	require.Len(t, hits, 3)
+	assert.Equal(t, inName, hits[0].Tour)
+	assert.Equal(t, inTags, hits[1].Tour)
+	assert.Equal(t, inDescription, hits[2].Tour)
+}
+
+func TestSearchPageToken_OtherQuery_IsRejected(t *testing.T) {
+	hit := &TourSearchHit{Tour: newSearchTour("Fortress walk", ""), Score: 1.5}
+	token := encodeSearchPageToken("fortress", searchSourceIndex, hit)
+
+	decoded, err := decodeSearchPageToken(token, "fortress", searchSourceIndex)
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, decoded.Score)
+
+	_, err = decodeSearchPageToken(token, "danube", searchSourceIndex)
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestSearchPageToken_OtherSource_IsRejected(t *testing.T) {
+	hit := &TourSearchHit{Tour: newSearchTour("Fortress walk", ""), Score: 1.5}
+	token := encodeSearchPageToken("fortress", searchSourceIndex, hit)
+
+	_, err := decodeSearchPageToken(token, "fortress", searchSourceMatcher)
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+
+	_, err = pageSearchHits(MatchTours([]*models.Tour{hit.Tour}, "fortress"), "fortress", PageRequest{Token: token})
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestPageSearchHits_ResumesAfterToken(t *testing.T) {
+	tours := []*models.Tour{
+		newSearchTour("Fortress walk", ""),
+		newSearchTour("Fortress ride", ""),
+		newSearchTour("Old town", "Ends at the fortress"),
+	}
+	hits := MatchTours(tours, "fortress")
+
+	first, err := pageSearchHits(hits, "fortress", PageRequest{Size: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Hits, 2)
+	require.NotEmpty(t, first.NextPageToken)
+
+	second, err := pageSearchHits(MatchTours(tours, "fortress"), "fortress", PageRequest{Size: 2, Token: first.NextPageToken})
+	require.NoError(t, err)
+	require.Len(t, second.Hits, 1)
+	assert.Equal(t, tours[2], second.Hits[0].Tour)
+	assert.Empty(t, second.NextPageToken)
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TourFilter narrows ListTours. Zero values leave a field unfiltered.
+type TourFilter struct {
+	Difficulty string
+	GuideID    string
+
+	// IncludeDrafts lists unpublished tours too; callers set it only for a
+	// guide listing their own tours.
+	IncludeDrafts bool
+
+	// Tags matches tours with any of the tags, or all of them when
+	// MatchAllTags is set.
+	Tags         []string
+	MatchAllTags bool
+
+	// MinPrice and MaxPrice are inclusive bounds; nil leaves a side open so
+	// free tours can still be selected with a zero bound.
+	MinPrice *float64
+	MaxPrice *float64
+
+	// CreatedAfter and CreatedBefore are exclusive bounds on Tour.CreatedAt.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// MaxDurationMinutes keeps tours that take at most this long in
+	// TransportMode, or in any mode when TransportMode is empty.
+	TransportMode      string
+	MaxDurationMinutes int32
+}
+
+func (f TourFilter) query() bson.M {
+	query := bson.M{}
+	if !f.IncludeDrafts {
+		query["isPublished"] = true
+	}
+	if f.Difficulty != "" {
+		query["difficulty"] = f.Difficulty
+	}
+	if f.GuideID != "" {
+		query["guideId"] = f.GuideID
+	}
+	if len(f.Tags) > 0 {
+		operator := "$in"
+		if f.MatchAllTags {
+			operator = "$all"
+		}
+		query["tags"] = bson.M{operator: f.Tags}
+	}
+	if price := rangeQuery("$gte", f.MinPrice, "$lte", f.MaxPrice); len(price) > 0 {
+		query["price"] = price
+	}
+	if created := timeRangeQuery(f.CreatedAfter, f.CreatedBefore); len(created) > 0 {
+		query["createdAt"] = created
+	}
+	if f.MaxDurationMinutes > 0 {
+		match := bson.M{"minutes": bson.M{"$lte": f.MaxDurationMinutes}}
+		if f.TransportMode != "" {
+			match["mode"] = f.TransportMode
+		}
+		query["transportTimes"] = bson.M{"$elemMatch": match}
+	}
+	return query
+}
+
+func rangeQuery(lowerOp string, lower *float64, upperOp string, upper *float64) bson.M {
+	bounds := bson.M{}
+	if lower != nil {
+		bounds[lowerOp] = *lower
+	}
+	if upper != nil {
+		bounds[upperOp] = *upper
+	}
+	return bounds
+}
+
+func timeRangeQuery(after, before time.Time) bson.M {
+	bounds := bson.M{}
+	if !after.IsZero() {
+		bounds["$gt"] = after
+	}
+	if !before.IsZero() {
+		bounds["$lt"] = before
+	}
+	return bounds
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestTourFilter_Empty_MatchesPublishedTours(t *testing.T) {
+	assert.Equal(t, bson.M{"isPublished": true}, TourFilter{}.query())
+}
+
+func TestTourFilter_IncludeDrafts_MatchesGuidesTours(t *testing.T) {
+	query := TourFilter{GuideID: "guide123", IncludeDrafts: true}.query()
+
+	assert.Equal(t, bson.M{"guideId": "guide123"}, query)
+}
+
+func TestTourFilter_MaxDuration_MatchesAnyMode(t *testing.T) {
+	query := TourFilter{MaxDurationMinutes: 45}.query()
+
+	assert.Equal(t, bson.M{"$elemMatch": bson.M{"minutes": bson.M{"$lte": int32(45)}}}, query["transportTimes"])
+}
+
+func TestTourFilter_MaxDurationWithMode_MatchesThatMode(t *testing.T) {
+	query := TourFilter{TransportMode: "car", MaxDurationMinutes: 45}.query()
+
+	assert.Equal(t, bson.M{"$elemMatch": bson.M{
+		"mode":    "car",
+		"minutes": bson.M{"$lte": int32(45)},
+	}}, query["transportTimes"])
+}
+
+func TestTourFilter_Tags_MatchAnyByDefault(t *testing.T) {
+	query := TourFilter{Tags: []string{"nature", "history"}}.query()
+
+	assert.Equal(t, bson.M{"$in": []string{"nature", "history"}}, query["tags"])
+}
+
+func TestTourFilter_Tags_MatchAll(t *testing.T) {
+	query := TourFilter{Tags: []string{"nature", "history"}, MatchAllTags: true}.query()
+
+	assert.Equal(t, bson.M{"$all": []string{"nature", "history"}}, query["tags"])
+}
+
+func TestTourFilter_ZeroMaxPrice_SelectsFreeTours(t *testing.T) {
+	free := 0.0
+	query := TourFilter{MaxPrice: &free}.query()
+
+	assert.Equal(t, bson.M{"$lte": 0.0}, query["price"])
+}
+
+func TestTourFilter_CreatedRange_UsesExclusiveBounds(t *testing.T) {
+	after := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	query := TourFilter{CreatedAfter: after, CreatedBefore: before}.query()
+
+	assert.Equal(t, bson.M{"$gt": after, "$lt": before}, query["createdAt"])
+}
@@ -2,9 +2,12 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"log"
 	"time"
 	"tour-service/internal/models"
 
+	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -12,30 +15,173 @@ import (
 )
 
 type TourRepository struct {
+	client              *mongo.Client
 	toursCollection     *mongo.Collection
 	keypointsCollection *mongo.Collection
 	positionCollection  *mongo.Collection
+	sampleCollection    *mongo.Collection
 	cartCollection      *mongo.Collection
 	tokenCollection     *mongo.Collection
+	checkoutCollection  *mongo.Collection
 	executionCollection *mongo.Collection
+	reviewCollection    *mongo.Collection
 }
 
+// checkoutRecordTTL bounds how long an idempotency key can be replayed.
+const checkoutRecordTTL = 24 * time.Hour
+
+// A checkout holding an idempotency key is polled by concurrent calls with
+// the same key, and taken over once it has held the key for longer than
+// checkoutReservationTimeout without finishing.
+const (
+	checkoutPollInterval       = 50 * time.Millisecond
+	checkoutReservationTimeout = time.Minute
+)
+
+// positionSampleCollection is the time-series collection of position
+// samples, set up by EnsurePositionHistory.
+const positionSampleCollection = "position_samples"
+
 func NewTourRepository(db *mongo.Database) *TourRepository {
 	return &TourRepository{
+		client:              db.Client(),
 		toursCollection:     db.Collection("tours"),
 		keypointsCollection: db.Collection("keypoints"),
 		positionCollection:  db.Collection("positions"),
+		sampleCollection:    db.Collection(positionSampleCollection),
 		cartCollection:      db.Collection("carts"),
 		tokenCollection:     db.Collection("purchase_tokens"),
+		checkoutCollection:  db.Collection("checkouts"),
 		executionCollection: db.Collection("executions"),
+		reviewCollection:    db.Collection("reviews"),
+	}
+}
+
+// EnsureIndexes creates the indexes the repository relies on. It is safe to
+// call on every startup.
+func (r *TourRepository) EnsureIndexes(ctx context.Context) error {
+	// A tour can only ever be bought once per tourist
+	_, err := r.tokenCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "touristId", Value: 1}, {Key: "tourId", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.checkoutCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "touristId", Value: 1}, {Key: "idempotencyKey", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "createdAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(checkoutRecordTTL.Seconds())),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Keypoints are read in tour order and searched by location
+	_, err = r.keypointsCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "tourId", Value: 1}, {Key: "order", Value: 1}}},
+		{Keys: bson.D{{Key: "location", Value: "2dsphere"}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Serve the sort orders and filters of ListTours
+	_, err = r.toursCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "isPublished", Value: 1}, {Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "isPublished", Value: 1}, {Key: "price", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "isPublished", Value: 1}, {Key: "name", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "isPublished", Value: 1}, {Key: "difficulty", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "isPublished", Value: 1}, {Key: "tags", Value: 1}}},
+		{Keys: bson.D{{Key: "guideId", Value: 1}, {Key: "isPublished", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "guideId", Value: 1}, {Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{
+			{Key: "isPublished", Value: 1},
+			{Key: "transportTimes.mode", Value: 1},
+			{Key: "transportTimes.minutes", Value: 1},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// A tourist reviews a tour once; reviews are listed newest first
+	_, err = r.reviewCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "tourId", Value: 1}, {Key: "touristId", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "tourId", Value: 1}, {Key: "createdAt", Value: -1}}},
+	})
+	if err != nil {
+		return err
 	}
+
+	// Stale executions are swept by status and last activity; tourists list
+	// theirs newest first
+	_, err = r.executionCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "lastActivity", Value: 1}}},
+		{Keys: bson.D{{Key: "touristId", Value: 1}, {Key: "startedAt", Value: -1}, {Key: "_id", Value: -1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// A collection holds a single text index, so it is named to make changes explicit
+	_, err = r.toursCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    textIndexKeys(),
+		Options: options.Index().SetName("tours_text").SetWeights(textIndexWeights()),
+	})
+	return err
+}
+
+// EnsurePositionHistory creates the time-series collection of position
+// samples, or applies retention to it when it already exists. MongoDB
+// removes samples older than retention; zero keeps them forever.
+func (r *TourRepository) EnsurePositionHistory(ctx context.Context, retention time.Duration) error {
+	db := r.sampleCollection.Database()
+	opts := options.CreateCollection().SetTimeSeriesOptions(options.TimeSeries().
+		SetTimeField("recordedAt").
+		SetMetaField("executionId").
+		SetGranularity("seconds"))
+	if retention > 0 {
+		opts.SetExpireAfterSeconds(int64(retention.Seconds()))
+	}
+
+	err := db.CreateCollection(ctx, positionSampleCollection, opts)
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) && serverErr.HasErrorCode(48) { // NamespaceExists
+		var expireAfter interface{} = "off"
+		if retention > 0 {
+			expireAfter = int64(retention.Seconds())
+		}
+		err = db.RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: positionSampleCollection},
+			{Key: "expireAfterSeconds", Value: expireAfter},
+		}).Err()
+	}
+	if err != nil {
+		return err
+	}
+
+	// The path of an execution is read in time order
+	_, err = r.sampleCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "executionId", Value: 1}, {Key: "recordedAt", Value: 1}},
+	})
+	return err
 }
 
 // ============ Tour Operations ============
 
 func (r *TourRepository) CreateTour(ctx context.Context, tour *models.Tour) error {
 	tour.CreatedAt = time.Now()
-	tour.Status = "draft"
+	tour.Status = models.TourStatusDraft
 	tour.Price = 0
 	tour.IsPublished = false
 
@@ -70,8 +216,22 @@ func (r *TourRepository) GetToursByGuideID(ctx context.Context, guideID string)
 	return tours, nil
 }
 
-func (r *TourRepository) GetPublishedTours(ctx context.Context) ([]*models.Tour, error) {
-	cursor, err := r.toursCollection.Find(ctx, bson.M{"isPublished": true})
+// ListTours returns one page of the tours matching filter, which are only
+// published ones unless the filter includes drafts.
+func (r *TourRepository) ListTours(ctx context.Context, filter TourFilter, page PageRequest) (*TourPage, error) {
+	query := filter.query()
+	if page.Token != "" {
+		token, err := decodePageToken(page.Token, page.Sort)
+		if err != nil {
+			return nil, err
+		}
+		query["$or"] = token.after()
+	}
+
+	// One extra tour tells whether another page follows
+	size := page.size()
+	opts := options.Find().SetSort(page.Sort.order()).SetLimit(int64(size + 1))
+	cursor, err := r.toursCollection.Find(ctx, query, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -81,14 +241,32 @@ func (r *TourRepository) GetPublishedTours(ctx context.Context) ([]*models.Tour,
 	if err = cursor.All(ctx, &tours); err != nil {
 		return nil, err
 	}
-	return tours, nil
+
+	result := &TourPage{Tours: tours}
+	if len(tours) > size {
+		result.Tours = tours[:size]
+		result.NextPageToken = encodePageToken(page.Sort, tours[size-1])
+	}
+	return result, nil
 }
 
+// UpdateTour saves the fields a guide edits. Fields derived from keypoints
+// and reviews are maintained by their own updates and left alone, so a
+// concurrent review is not overwritten with a stale rating.
 func (r *TourRepository) UpdateTour(ctx context.Context, tour *models.Tour) error {
 	_, err := r.toursCollection.UpdateOne(
 		ctx,
 		bson.M{"_id": tour.ID},
-		bson.M{"$set": tour},
+		bson.M{"$set": bson.M{
+			"name":            tour.Name,
+			"description":     tour.Description,
+			"difficulty":      tour.Difficulty,
+			"tags":            tour.Tags,
+			"price":           tour.Price,
+			"transportTimes":  tour.TransportTimes,
+			"executionMode":   tour.ExecutionMode,
+			"proximityRadius": tour.ProximityRadius,
+		}},
 	)
 	return err
 }
@@ -99,7 +277,7 @@ func (r *TourRepository) PublishTour(ctx context.Context, tourID primitive.Objec
 		bson.M{"_id": tourID},
 		bson.M{"$set": bson.M{
 			"isPublished": true,
-			"status":      "published",
+			"status":      models.TourStatusPublished,
 			"price":       price,
 			"publishedAt": time.Now(),
 		}},
@@ -107,19 +285,101 @@ func (r *TourRepository) PublishTour(ctx context.Context, tourID primitive.Objec
 	return err
 }
 
-// ============ KeyPoint Operations ============
+// ArchiveTour takes a published tour off sale. Tourists who already bought it
+// can still execute it.
+func (r *TourRepository) ArchiveTour(ctx context.Context, tourID primitive.ObjectID) error {
+	return r.transitionTour(ctx, tourID, models.TourStatusPublished, bson.M{
+		"$set": bson.M{
+			"isPublished": false,
+			"status":      models.TourStatusArchived,
+			"archivedAt":  time.Now(),
+		},
+	})
+}
 
-func (r *TourRepository) CreateKeyPoint(ctx context.Context, keypoint *models.KeyPoint) error {
-	result, err := r.keypointsCollection.InsertOne(ctx, keypoint)
+// ReactivateTour puts an archived tour back on sale.
+func (r *TourRepository) ReactivateTour(ctx context.Context, tourID primitive.ObjectID) error {
+	return r.transitionTour(ctx, tourID, models.TourStatusArchived, bson.M{
+		"$set": bson.M{
+			"isPublished": true,
+			"status":      models.TourStatusPublished,
+		},
+		"$unset": bson.M{"archivedAt": ""},
+	})
+}
+
+// TourMetrics are the tour fields derived from its keypoints.
+type TourMetrics struct {
+	LengthKm          float64
+	TransportTimes    []models.TransportTime
+	KeyPointSummaries []models.KeyPointSummary
+}
+
+// SetTourMetrics stores the values derived from a tour's keypoints.
+func (r *TourRepository) SetTourMetrics(ctx context.Context, tourID primitive.ObjectID, metrics TourMetrics) error {
+	_, err := r.toursCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": tourID},
+		bson.M{"$set": bson.M{
+			"lengthKm":          metrics.LengthKm,
+			"transportTimes":    metrics.TransportTimes,
+			"keyPointSummaries": metrics.KeyPointSummaries,
+		}},
+	)
+	return err
+}
+
+// transitionTour applies update only while the tour is still in status from,
+// so concurrent transitions cannot both succeed.
+func (r *TourRepository) transitionTour(ctx context.Context, tourID primitive.ObjectID, from string, update bson.M) error {
+	result, err := r.toursCollection.UpdateOne(ctx, bson.M{"_id": tourID, "status": from}, update)
 	if err != nil {
 		return err
 	}
-	keypoint.ID = result.InsertedID.(primitive.ObjectID)
+	if result.MatchedCount == 0 {
+		return ErrTourStatusChanged
+	}
 	return nil
 }
 
+// ============ KeyPoint Operations ============
+
+// Keypoint orders are positions in the tour, numbered from 1 without gaps.
+
+// CreateKeyPoint inserts a keypoint at keypoint.Order, moving the keypoints
+// from that position on one place back. An Order of 0 or past the end
+// appends the keypoint; keypoint.Order is set to the position it got.
+func (r *TourRepository) CreateKeyPoint(ctx context.Context, keypoint *models.KeyPoint) error {
+	keypoint.ID = primitive.NewObjectID()
+	keypoint.Location = models.NewGeoPoint(keypoint.Latitude, keypoint.Longitude)
+	requested := keypoint.Order
+
+	return r.atomically(ctx, func(ctx context.Context) error {
+		count, err := r.keypointsCollection.CountDocuments(ctx, bson.M{"tourId": keypoint.TourID})
+		if err != nil {
+			return err
+		}
+
+		keypoint.Order = requested
+		if keypoint.Order <= 0 || int64(keypoint.Order) > count {
+			keypoint.Order = int32(count) + 1
+		} else if _, err := r.keypointsCollection.UpdateMany(
+			ctx,
+			bson.M{"tourId": keypoint.TourID, "order": bson.M{"$gte": keypoint.Order}},
+			bson.M{"$inc": bson.M{"order": 1}},
+		); err != nil {
+			return err
+		}
+
+		_, err = r.keypointsCollection.InsertOne(ctx, keypoint)
+		return err
+	})
+}
+
+// GetKeyPointsByTourID returns the keypoints of a tour in order.
 func (r *TourRepository) GetKeyPointsByTourID(ctx context.Context, tourID primitive.ObjectID) ([]*models.KeyPoint, error) {
-	cursor, err := r.keypointsCollection.Find(ctx, bson.M{"tourId": tourID})
+	opts := options.Find().SetSort(bson.D{{Key: "order", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.keypointsCollection.Find(ctx, bson.M{"tourId": tourID}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -132,25 +392,429 @@ func (r *TourRepository) GetKeyPointsByTourID(ctx context.Context, tourID primit
 	return keypoints, nil
 }
 
+// UpdateKeyPoint saves the location, content and radius of a keypoint and
+// reloads the rest of it into keypoint. Its order only changes through
+// ReorderKeyPoints. It returns mongo.ErrNoDocuments unless the keypoint
+// belongs to keypoint.TourID.
 func (r *TourRepository) UpdateKeyPoint(ctx context.Context, keypoint *models.KeyPoint) error {
-	_, err := r.keypointsCollection.UpdateOne(
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	return r.keypointsCollection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": keypoint.ID, "tourId": keypoint.TourID},
+		bson.M{"$set": bson.M{
+			"latitude":        keypoint.Latitude,
+			"longitude":       keypoint.Longitude,
+			"location":        models.NewGeoPoint(keypoint.Latitude, keypoint.Longitude),
+			"name":            keypoint.Name,
+			"description":     keypoint.Description,
+			"image":           keypoint.Image,
+			"proximityRadius": keypoint.ProximityRadius,
+		}},
+		opts,
+	).Decode(keypoint)
+}
+
+// DeleteKeyPoint removes a keypoint of a tour and moves the keypoints after
+// it one place forward, so orders stay without gaps. It returns
+// mongo.ErrNoDocuments unless the keypoint belongs to tourID.
+func (r *TourRepository) DeleteKeyPoint(ctx context.Context, tourID, keypointID primitive.ObjectID) error {
+	return r.atomically(ctx, func(ctx context.Context) error {
+		var deleted models.KeyPoint
+		err := r.keypointsCollection.FindOneAndDelete(ctx, bson.M{"_id": keypointID, "tourId": tourID}).Decode(&deleted)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.keypointsCollection.UpdateMany(
+			ctx,
+			bson.M{"tourId": tourID, "order": bson.M{"$gt": deleted.Order}},
+			bson.M{"$inc": bson.M{"order": -1}},
+		)
+		return err
+	})
+}
+
+// ReorderKeyPoints gives the keypoints of a tour the order of keyPointIDs.
+// It returns ErrKeyPointsMismatch unless keyPointIDs lists every keypoint of
+// the tour exactly once.
+func (r *TourRepository) ReorderKeyPoints(ctx context.Context, tourID primitive.ObjectID, keyPointIDs []primitive.ObjectID) error {
+	return r.atomically(ctx, func(ctx context.Context) error {
+		cursor, err := r.keypointsCollection.Find(ctx, bson.M{"tourId": tourID}, options.Find().SetProjection(bson.M{"_id": 1}))
+		if err != nil {
+			return err
+		}
+		var current []struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err = cursor.All(ctx, &current); err != nil {
+			return err
+		}
+
+		listed := make(map[primitive.ObjectID]bool, len(keyPointIDs))
+		for _, id := range keyPointIDs {
+			listed[id] = true
+		}
+		if len(current) != len(keyPointIDs) || len(listed) != len(keyPointIDs) {
+			return ErrKeyPointsMismatch
+		}
+		for _, kp := range current {
+			if !listed[kp.ID] {
+				return ErrKeyPointsMismatch
+			}
+		}
+
+		updates := make([]mongo.WriteModel, len(keyPointIDs))
+		for i, id := range keyPointIDs {
+			updates[i] = mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": id, "tourId": tourID}).
+				SetUpdate(bson.M{"$set": bson.M{"order": int32(i + 1)}})
+		}
+		_, err = r.keypointsCollection.BulkWrite(ctx, updates)
+		return err
+	})
+}
+
+// ImportKeyPoints adds keypoints to the end of a tour in the given order, or
+// replaces every keypoint of the tour with them when replace is set. IDs and
+// orders are assigned to keypoints.
+func (r *TourRepository) ImportKeyPoints(ctx context.Context, tourID primitive.ObjectID, keypoints []*models.KeyPoint, replace bool) error {
+	for _, keypoint := range keypoints {
+		keypoint.ID = primitive.NewObjectID()
+		keypoint.TourID = tourID
+		keypoint.Location = models.NewGeoPoint(keypoint.Latitude, keypoint.Longitude)
+	}
+
+	return r.atomically(ctx, func(ctx context.Context) error {
+		var count int64
+		var err error
+		if replace {
+			_, err = r.keypointsCollection.DeleteMany(ctx, bson.M{"tourId": tourID})
+		} else {
+			count, err = r.keypointsCollection.CountDocuments(ctx, bson.M{"tourId": tourID})
+		}
+		if err != nil {
+			return err
+		}
+
+		docs := make([]interface{}, len(keypoints))
+		for i, keypoint := range keypoints {
+			keypoint.Order = int32(count) + int32(i) + 1
+			docs[i] = keypoint
+		}
+		_, err = r.keypointsCollection.InsertMany(ctx, docs)
+		return err
+	})
+}
+
+// BackfillKeyPointLocations gives keypoints stored before locations were
+// tracked a GeoJSON location, so geospatial searches see every keypoint.
+func (r *TourRepository) BackfillKeyPointLocations(ctx context.Context) error {
+	_, err := r.keypointsCollection.UpdateMany(
 		ctx,
-		bson.M{"_id": keypoint.ID},
-		bson.M{"$set": keypoint},
+		bson.M{"location": bson.M{"$exists": false}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{
+			"location": bson.M{
+				"type":        "Point",
+				"coordinates": bson.A{"$longitude", "$latitude"},
+			},
+		}}}},
 	)
 	return err
 }
 
-func (r *TourRepository) DeleteKeyPoint(ctx context.Context, keypointID primitive.ObjectID) error {
-	_, err := r.keypointsCollection.DeleteOne(ctx, bson.M{"_id": keypointID})
+// BackfillKeyPointOrders renumbers the keypoints of every tour whose orders
+// do not run 1..n, which UpdateKeyPoint used to reset to 0. Keypoints keep
+// the sequence GetKeyPointsByTourID lists them in.
+func (r *TourRepository) BackfillKeyPointOrders(ctx context.Context) error {
+	cursor, err := r.keypointsCollection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "tourId", Value: 1}, {Key: "order", Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":       "$tourId",
+			"keyPoints": bson.M{"$push": bson.M{"_id": "$_id", "order": "$order"}},
+		}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	var tours []struct {
+		KeyPoints []struct {
+			ID    primitive.ObjectID `bson:"_id"`
+			Order int32              `bson:"order"`
+		} `bson:"keyPoints"`
+	}
+	if err = cursor.All(ctx, &tours); err != nil {
+		return err
+	}
+
+	var updates []mongo.WriteModel
+	for _, tour := range tours {
+		for i, kp := range tour.KeyPoints {
+			if kp.Order != int32(i+1) {
+				updates = append(updates, mongo.NewUpdateOneModel().
+					SetFilter(bson.M{"_id": kp.ID}).
+					SetUpdate(bson.M{"$set": bson.M{"order": int32(i + 1)}}))
+			}
+		}
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	_, err = r.keypointsCollection.BulkWrite(ctx, updates)
+	return err
+}
+
+// BackfillKeyPointSummaries copies the keypoint text onto tours stored before
+// it was indexed for search. Tours that already have summaries keep them.
+func (r *TourRepository) BackfillKeyPointSummaries(ctx context.Context) error {
+	cursor, err := r.keypointsCollection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "tourId", Value: 1}, {Key: "order", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":               "$tourId",
+			"keyPointSummaries": bson.M{"$push": bson.M{"name": "$name", "description": "$description"}},
+		}}},
+		{{Key: "$merge", Value: bson.M{
+			"into": r.toursCollection.Name(),
+			"on":   "_id",
+			"whenMatched": mongo.Pipeline{{{Key: "$set", Value: bson.M{
+				"keyPointSummaries": bson.M{"$ifNull": bson.A{"$keyPointSummaries", "$$new.keyPointSummaries"}},
+			}}}},
+			"whenNotMatched": "discard",
+		}}},
+	})
+	if err != nil {
+		return err
+	}
+	return cursor.Close(ctx)
+}
+
+// DedupePurchaseTokens deletes all but the first token of every tourist and
+// tour pair that was bought more than once, so the unique index on purchase
+// tokens can be built over data from before it existed.
+func (r *TourRepository) DedupePurchaseTokens(ctx context.Context) error {
+	cursor, err := r.tokenCollection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "purchasedAt", Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"touristId": "$touristId", "tourId": "$tourId"},
+			"ids": bson.M{"$push": "$_id"},
+		}}},
+		{{Key: "$match", Value: bson.M{"ids.1": bson.M{"$exists": true}}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	var groups []struct {
+		IDs []primitive.ObjectID `bson:"ids"`
+	}
+	if err = cursor.All(ctx, &groups); err != nil {
+		return err
+	}
+
+	var duplicates []primitive.ObjectID
+	for _, group := range groups {
+		duplicates = append(duplicates, group.IDs[1:]...)
+	}
+	if len(duplicates) == 0 {
+		return nil
+	}
+	_, err = r.tokenCollection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": duplicates}})
 	return err
 }
 
+// DedupeReviews keeps only the latest review of every tourist and tour pair
+// that was reviewed more than once, and takes the ratings of the deleted
+// reviews off their tour, so the unique index on reviews can be built.
+func (r *TourRepository) DedupeReviews(ctx context.Context) error {
+	cursor, err := r.reviewCollection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "updatedAt", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     bson.M{"tourId": "$tourId", "touristId": "$touristId"},
+			"reviews": bson.M{"$push": bson.M{"_id": "$_id", "rating": "$rating"}},
+		}}},
+		{{Key: "$match", Value: bson.M{"reviews.1": bson.M{"$exists": true}}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	var groups []struct {
+		Key struct {
+			TourID primitive.ObjectID `bson:"tourId"`
+		} `bson:"_id"`
+		Reviews []struct {
+			ID     primitive.ObjectID `bson:"_id"`
+			Rating int32              `bson:"rating"`
+		} `bson:"reviews"`
+	}
+	if err = cursor.All(ctx, &groups); err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		var ids []primitive.ObjectID
+		var total int32
+		for _, review := range group.Reviews[1:] {
+			ids = append(ids, review.ID)
+			total += review.Rating
+		}
+
+		err := r.atomically(ctx, func(ctx context.Context) error {
+			result, err := r.reviewCollection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+			if err != nil {
+				return err
+			}
+			if result.DeletedCount == 0 {
+				return nil
+			}
+			return r.addRatings(ctx, group.Key.TourID, -int32(len(ids)), -total)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NearbyTour is a published tour found by SearchToursNearby.
+type NearbyTour struct {
+	Tour *models.Tour `bson:"tour"`
+	// DistanceMeters is measured to the first keypoint of the tour.
+	DistanceMeters float64 `bson:"distance"`
+}
+
+// SearchToursNearby returns published tours whose first keypoint lies within
+// radiusMeters of the given point, nearest first.
+func (r *TourRepository) SearchToursNearby(ctx context.Context, latitude, longitude, radiusMeters float64, limit int) ([]*NearbyTour, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$geoNear", Value: bson.M{
+			"near":          models.NewGeoPoint(latitude, longitude),
+			"distanceField": "distance",
+			"maxDistance":   radiusMeters,
+			"spherical":     true,
+		}}},
+		// Keep only keypoints no other keypoint of the same tour precedes
+		{{Key: "$lookup", Value: bson.M{
+			"from": r.keypointsCollection.Name(),
+			"let":  bson.M{"tourId": "$tourId", "order": "$order"},
+			"pipeline": mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{"$expr": bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$tourId", "$$tourId"}},
+					bson.M{"$lt": bson.A{"$order", "$$order"}},
+				}}}}},
+				{{Key: "$limit", Value: 1}},
+			},
+			"as": "earlier",
+		}}},
+		{{Key: "$match", Value: bson.M{"earlier": bson.M{"$size": 0}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$tourId", "distance": bson.M{"$min": "$distance"}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.toursCollection.Name(),
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "tour",
+		}}},
+		{{Key: "$unwind", Value: "$tour"}},
+		{{Key: "$match", Value: bson.M{"tour.isPublished": true}}},
+		{{Key: "$sort", Value: bson.D{{Key: "distance", Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.keypointsCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tours []*NearbyTour
+	if err = cursor.All(ctx, &tours); err != nil {
+		return nil, err
+	}
+	return tours, nil
+}
+
+// SearchTours runs a full-text search over published tours, most relevant
+// first. Results are always ordered by relevance, so page.Sort is ignored and
+// page tokens are bound to the query and to how it was scored instead.
+func (r *TourRepository) SearchTours(ctx context.Context, query string, page PageRequest) (*TourSearchPage, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"$text": bson.M{"$search": query}, "isPublished": true}}},
+		{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}},
+	}
+	if page.Token != "" {
+		token, err := decodeSearchPageToken(page.Token, query, searchSourceIndex)
+		if err != nil {
+			return nil, err
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"$or": token.after()}}})
+	}
+
+	// One extra hit tells whether another page follows
+	size := page.size()
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: 1}}}},
+		bson.D{{Key: "$limit", Value: size + 1}},
+	)
+
+	cursor, err := r.toursCollection.Aggregate(ctx, pipeline)
+	if isTextIndexMissing(err) {
+		log.Printf("Tours text index is missing, searching without it")
+		return r.searchToursWithoutIndex(ctx, query, page)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var scored []struct {
+		models.Tour `bson:",inline"`
+		Score       float64 `bson:"score"`
+	}
+	if err = cursor.All(ctx, &scored); err != nil {
+		return nil, err
+	}
+
+	// Mongo ranks the hits; the snippets come from matching them again here
+	hits := make([]*TourSearchHit, len(scored))
+	for i := range scored {
+		tour := &scored[i].Tour
+		hits[i] = &TourSearchHit{Tour: tour, Score: scored[i].Score}
+		if match := MatchTour(tour, query); match != nil {
+			hits[i].Snippets = match.Snippets
+		}
+	}
+
+	result := &TourSearchPage{Hits: hits}
+	if len(hits) > size {
+		result.Hits = hits[:size]
+		result.NextPageToken = encodeSearchPageToken(query, searchSourceIndex, hits[size-1])
+	}
+	return result, nil
+}
+
+// searchToursWithoutIndex ranks every published tour with MatchTours, for
+// databases where the tours text index has not been built.
+func (r *TourRepository) searchToursWithoutIndex(ctx context.Context, query string, page PageRequest) (*TourSearchPage, error) {
+	cursor, err := r.toursCollection.Find(ctx, bson.M{"isPublished": true})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tours []*models.Tour
+	if err = cursor.All(ctx, &tours); err != nil {
+		return nil, err
+	}
+	return pageSearchHits(MatchTours(tours, query), query, page)
+}
+
 // ============ Position Operations ============
 
+// UpsertPosition replaces the current position of the tourist and records
+// it as a sample of every execution they have active.
 func (r *TourRepository) UpsertPosition(ctx context.Context, position *models.Position) error {
 	position.UpdatedAt = time.Now()
-	
+
 	// Use upsert option to insert if not found
 	opts := options.Update().SetUpsert(true)
 	_, err := r.positionCollection.UpdateOne(
@@ -159,6 +823,36 @@ func (r *TourRepository) UpsertPosition(ctx context.Context, position *models.Po
 		bson.M{"$set": position},
 		opts,
 	)
+	if err != nil {
+		return err
+	}
+
+	executionIDs, err := r.executionCollection.Distinct(ctx, "_id", bson.M{
+		"touristId": position.TouristID,
+		"status":    models.ExecutionStatusActive,
+	})
+	if err != nil || len(executionIDs) == 0 {
+		return err
+	}
+
+	samples := make([]interface{}, 0, len(executionIDs))
+	for _, id := range executionIDs {
+		executionID, ok := id.(primitive.ObjectID)
+		if !ok {
+			continue
+		}
+		samples = append(samples, &models.PositionSample{
+			ExecutionID: executionID,
+			TouristID:   position.TouristID,
+			Latitude:    position.Latitude,
+			Longitude:   position.Longitude,
+			RecordedAt:  position.UpdatedAt,
+		})
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+	_, err = r.sampleCollection.InsertMany(ctx, samples)
 	return err
 }
 
@@ -171,6 +865,23 @@ func (r *TourRepository) GetPosition(ctx context.Context, touristID string) (*mo
 	return &position, nil
 }
 
+// GetPositionSamples returns the recorded path of an execution, oldest
+// sample first.
+func (r *TourRepository) GetPositionSamples(ctx context.Context, executionID primitive.ObjectID) ([]*models.PositionSample, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "recordedAt", Value: 1}})
+	cursor, err := r.sampleCollection.Find(ctx, bson.M{"executionId": executionID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	samples := []*models.PositionSample{}
+	if err = cursor.All(ctx, &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
 // ============ Shopping Cart Operations ============
 
 func (r *TourRepository) GetOrCreateCart(ctx context.Context, touristID string) (*models.ShoppingCart, error) {
@@ -207,36 +918,514 @@ func (r *TourRepository) UpdateCart(ctx context.Context, cart *models.ShoppingCa
 	return err
 }
 
-func (r *TourRepository) ClearCart(ctx context.Context, touristID string) error {
-	_, err := r.cartCollection.UpdateOne(
+// ============ Purchase Token Operations ============
+
+func (r *TourRepository) HasPurchased(ctx context.Context, touristID string, tourID primitive.ObjectID) (bool, error) {
+	count, err := r.tokenCollection.CountDocuments(ctx, bson.M{
+		"touristId": touristID,
+		"tourId":    tourID,
+	})
+	return count > 0, err
+}
+
+// ============ Checkout ============
+
+// CheckoutResult is the outcome of CheckoutCart.
+type CheckoutResult struct {
+	Tokens []*models.PurchaseToken
+	// Replayed is set when the result was recorded by an earlier call with
+	// the same idempotency key and nothing new was purchased.
+	Replayed bool
+}
+
+// CheckoutCart turns every item in the tourist's cart into a purchase token
+// and empties the cart as a single unit of work. Either all tours are bought
+// or none are. On a standalone mongod, where transactions are unavailable,
+// it falls back to compensating writes.
+//
+// When idempotencyKey is set the result is recorded under (touristID, key)
+// and later calls with the same key return it unchanged. A call made while
+// another with the same key is still running waits for its result.
+func (r *TourRepository) CheckoutCart(ctx context.Context, touristID, idempotencyKey string) (*CheckoutResult, error) {
+	result, err := r.checkoutOnce(ctx, touristID, idempotencyKey)
+	if mongo.IsDuplicateKeyError(err) {
+		// A concurrent checkout won the race on a token or the idempotency
+		// key. Running again replays its record or reports the conflict.
+		result, err = r.checkoutOnce(ctx, touristID, idempotencyKey)
+	}
+	return result, err
+}
+
+func (r *TourRepository) checkoutOnce(ctx context.Context, touristID, idempotencyKey string) (*CheckoutResult, error) {
+	var reservation primitive.ObjectID
+	if idempotencyKey != "" {
+		var record *models.CheckoutRecord
+		var err error
+		reservation, record, err = r.reserveCheckout(ctx, touristID, idempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		if record != nil {
+			return replayCheckout(record), nil
+		}
+	}
+
+	session, err := r.client.StartSession()
+	if err != nil {
+		r.releaseCheckout(ctx, reservation)
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	result, err := session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return r.checkoutCart(sc, touristID, reservation)
+	})
+	if isTransactionUnsupported(err) {
+		return r.checkoutCartWithCompensation(ctx, touristID, reservation)
+	}
+	if err != nil {
+		r.releaseCheckout(ctx, reservation)
+		return nil, err
+	}
+	return &CheckoutResult{Tokens: result.([]*models.PurchaseToken)}, nil
+}
+
+// reserveCheckout claims (touristID, idempotencyKey) for a new checkout and
+// returns the ID of the pending record. When another call holds the key it
+// waits for that call to finish and returns its record to replay instead; a
+// key released by a failed checkout is claimed again.
+func (r *TourRepository) reserveCheckout(ctx context.Context, touristID, idempotencyKey string) (primitive.ObjectID, *models.CheckoutRecord, error) {
+	for {
+		reservation := models.CheckoutRecord{
+			ID:             primitive.NewObjectID(),
+			TouristID:      touristID,
+			IdempotencyKey: idempotencyKey,
+			Pending:        true,
+			CreatedAt:      time.Now(),
+		}
+		_, err := r.checkoutCollection.InsertOne(ctx, reservation)
+		if err == nil {
+			return reservation.ID, nil, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return primitive.NilObjectID, nil, err
+		}
+
+		record, err := r.getCheckoutRecord(ctx, touristID, idempotencyKey)
+		if err != nil {
+			return primitive.NilObjectID, nil, err
+		}
+		switch {
+		case record == nil:
+			// The holder failed and released the key
+			continue
+		case !record.Pending:
+			return primitive.NilObjectID, record, nil
+		case time.Since(record.CreatedAt) > checkoutReservationTimeout:
+			// The checkout holding the key died before finishing
+			r.releaseCheckout(ctx, record.ID)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return primitive.NilObjectID, nil, ctx.Err()
+		case <-time.After(checkoutPollInterval):
+		}
+	}
+}
+
+func (r *TourRepository) getCheckoutRecord(ctx context.Context, touristID, idempotencyKey string) (*models.CheckoutRecord, error) {
+	var record models.CheckoutRecord
+	err := r.checkoutCollection.FindOne(ctx, bson.M{
+		"touristId":      touristID,
+		"idempotencyKey": idempotencyKey,
+	}).Decode(&record)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// completeCheckout fills the pending record of a checkout in with the tokens
+// it bought. A zero reservation means the checkout had no idempotency key.
+func (r *TourRepository) completeCheckout(ctx context.Context, reservation primitive.ObjectID, tokens []*models.PurchaseToken) error {
+	if reservation.IsZero() {
+		return nil
+	}
+	stored := make([]models.PurchaseToken, len(tokens))
+	for i, token := range tokens {
+		stored[i] = *token
+	}
+	result, err := r.checkoutCollection.UpdateOne(
 		ctx,
-		bson.M{"touristId": touristID},
+		bson.M{"_id": reservation, "pending": true},
+		bson.M{
+			"$set":   bson.M{"tokens": stored},
+			"$unset": bson.M{"pending": ""},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errCheckoutReservationLost
+	}
+	return nil
+}
+
+// releaseCheckout gives up the idempotency key of a checkout that failed, so
+// a retry with the same key can run it again.
+func (r *TourRepository) releaseCheckout(ctx context.Context, reservation primitive.ObjectID) {
+	if reservation.IsZero() {
+		return
+	}
+	if _, err := r.checkoutCollection.DeleteOne(ctx, bson.M{"_id": reservation, "pending": true}); err != nil {
+		log.Printf("Error releasing checkout reservation %s: %v", reservation.Hex(), err)
+	}
+}
+
+func replayCheckout(record *models.CheckoutRecord) *CheckoutResult {
+	tokens := make([]*models.PurchaseToken, len(record.Tokens))
+	for i := range record.Tokens {
+		tokens[i] = &record.Tokens[i]
+	}
+	return &CheckoutResult{Tokens: tokens, Replayed: true}
+}
+
+func (r *TourRepository) checkoutCart(ctx context.Context, touristID string, reservation primitive.ObjectID) ([]*models.PurchaseToken, error) {
+	cart, tokens, err := r.prepareCheckout(ctx, touristID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.tokenCollection.InsertMany(ctx, tokenDocuments(tokens)); err != nil {
+		return nil, err
+	}
+	if err := r.completeCheckout(ctx, reservation, tokens); err != nil {
+		return nil, err
+	}
+	if err := r.clearCheckedOutCart(ctx, cart); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// checkoutCartWithCompensation performs the same steps as checkoutCart
+// without a transaction. If a step fails it deletes any tokens it created
+// and releases the idempotency key.
+func (r *TourRepository) checkoutCartWithCompensation(ctx context.Context, touristID string, reservation primitive.ObjectID) (*CheckoutResult, error) {
+	cart, tokens, err := r.prepareCheckout(ctx, touristID)
+	if err != nil {
+		r.releaseCheckout(ctx, reservation)
+		return nil, err
+	}
+
+	result, err := r.tokenCollection.InsertMany(ctx, tokenDocuments(tokens))
+	if err != nil {
+		// An ordered InsertMany may have written a prefix before failing
+		if result != nil {
+			r.deleteTokens(ctx, result.InsertedIDs)
+		}
+		r.releaseCheckout(ctx, reservation)
+		return nil, err
+	}
+
+	if err := r.completeCheckout(ctx, reservation, tokens); err != nil {
+		r.deleteTokens(ctx, result.InsertedIDs)
+		r.releaseCheckout(ctx, reservation)
+		return nil, err
+	}
+
+	if err := r.clearCheckedOutCart(ctx, cart); err != nil {
+		r.deleteTokens(ctx, result.InsertedIDs)
+		r.discardCheckout(ctx, reservation)
+		return nil, err
+	}
+	return &CheckoutResult{Tokens: tokens}, nil
+}
+
+// discardCheckout deletes the completed record of a checkout whose purchase
+// was rolled back.
+func (r *TourRepository) discardCheckout(ctx context.Context, reservation primitive.ObjectID) {
+	if reservation.IsZero() {
+		return
+	}
+	if _, err := r.checkoutCollection.DeleteOne(ctx, bson.M{"_id": reservation}); err != nil {
+		log.Printf("Error discarding checkout record %s: %v", reservation.Hex(), err)
+	}
+}
+
+// prepareCheckout loads the cart, rejects it if any tour is no longer
+// published or already owned, and builds one unsaved token per item.
+func (r *TourRepository) prepareCheckout(ctx context.Context, touristID string) (*models.ShoppingCart, []*models.PurchaseToken, error) {
+	var cart models.ShoppingCart
+	err := r.cartCollection.FindOne(ctx, bson.M{"touristId": touristID}).Decode(&cart)
+	if err == mongo.ErrNoDocuments || (err == nil && len(cart.Items) == 0) {
+		return nil, nil, ErrCartEmpty
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tourIDs := make([]primitive.ObjectID, len(cart.Items))
+	for i, item := range cart.Items {
+		tourIDs[i] = item.TourID
+	}
+
+	// Tours may have been archived while they sat in the cart
+	published, err := r.toursCollection.Distinct(ctx, "_id", bson.M{
+		"_id":         bson.M{"$in": tourIDs},
+		"isPublished": true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(published) < len(tourIDs) {
+		available := make(map[primitive.ObjectID]bool, len(published))
+		for _, id := range published {
+			if oid, ok := id.(primitive.ObjectID); ok {
+				available[oid] = true
+			}
+		}
+		unavailable := &UnavailableToursError{}
+		for _, id := range tourIDs {
+			if !available[id] {
+				unavailable.TourIDs = append(unavailable.TourIDs, id)
+			}
+		}
+		if len(unavailable.TourIDs) > 0 {
+			return nil, nil, unavailable
+		}
+	}
+
+	cursor, err := r.tokenCollection.Find(ctx, bson.M{
+		"touristId": touristID,
+		"tourId":    bson.M{"$in": tourIDs},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	var owned []models.PurchaseToken
+	if err := cursor.All(ctx, &owned); err != nil {
+		return nil, nil, err
+	}
+	if len(owned) > 0 {
+		conflict := &AlreadyPurchasedError{}
+		for _, token := range owned {
+			conflict.TourIDs = append(conflict.TourIDs, token.TourID)
+		}
+		return nil, nil, conflict
+	}
+
+	now := time.Now()
+	tokens := make([]*models.PurchaseToken, len(cart.Items))
+	for i, item := range cart.Items {
+		tokens[i] = &models.PurchaseToken{
+			ID:          primitive.NewObjectID(),
+			TouristID:   touristID,
+			TourID:      item.TourID,
+			Token:       uuid.New().String(),
+			PurchasedAt: now,
+		}
+	}
+	return &cart, tokens, nil
+}
+
+// clearCheckedOutCart empties the cart only if it still holds exactly the
+// items that were checked out, so a concurrent AddToCart is never lost.
+func (r *TourRepository) clearCheckedOutCart(ctx context.Context, cart *models.ShoppingCart) error {
+	result, err := r.cartCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": cart.ID, "items": cart.Items},
 		bson.M{"$set": bson.M{
 			"items":      []models.CartItem{},
 			"totalPrice": 0,
 		}},
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrCartChanged
+	}
+	return nil
 }
 
-// ============ Purchase Token Operations ============
+func (r *TourRepository) deleteTokens(ctx context.Context, ids []interface{}) {
+	if len(ids) == 0 {
+		return
+	}
+	if _, err := r.tokenCollection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}}); err != nil {
+		log.Printf("Error rolling back purchase tokens %v: %v", ids, err)
+	}
+}
+
+func tokenDocuments(tokens []*models.PurchaseToken) []interface{} {
+	docs := make([]interface{}, len(tokens))
+	for i, token := range tokens {
+		docs[i] = token
+	}
+	return docs
+}
+
+// ============ Review Operations ============
 
-func (r *TourRepository) CreatePurchaseToken(ctx context.Context, token *models.PurchaseToken) error {
-	token.PurchasedAt = time.Now()
-	result, err := r.tokenCollection.InsertOne(ctx, token)
+// CreateReview stores a review and adds its rating to the tour as a single
+// unit of work. On a standalone mongod the review is deleted again if the
+// tour cannot be updated.
+func (r *TourRepository) CreateReview(ctx context.Context, review *models.Review) error {
+	now := time.Now()
+	review.ID = primitive.NewObjectID()
+	review.CreatedAt = now
+	review.UpdatedAt = now
+
+	create := func(ctx context.Context) error {
+		if _, err := r.reviewCollection.InsertOne(ctx, review); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return ErrReviewExists
+			}
+			return err
+		}
+		return r.addRatings(ctx, review.TourID, 1, review.Rating)
+	}
+
+	err := r.inTransaction(ctx, create)
+	if !isTransactionUnsupported(err) {
+		return err
+	}
+
+	if err := create(ctx); err != nil {
+		if !errors.Is(err, ErrReviewExists) {
+			_, _ = r.reviewCollection.DeleteOne(ctx, bson.M{"_id": review.ID})
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *TourRepository) GetReviewByID(ctx context.Context, reviewID primitive.ObjectID) (*models.Review, error) {
+	var review models.Review
+	err := r.reviewCollection.FindOne(ctx, bson.M{"_id": reviewID}).Decode(&review)
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// GetReviewsByTourID returns the reviews of a tour, newest first.
+func (r *TourRepository) GetReviewsByTourID(ctx context.Context, tourID primitive.ObjectID) ([]*models.Review, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := r.reviewCollection.Find(ctx, bson.M{"tourId": tourID}, opts)
 	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reviews []*models.Review
+	if err = cursor.All(ctx, &reviews); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// UpdateReview saves an edited review and moves the tour rating by the
+// difference to previousRating. It returns ErrReviewChanged when the stored
+// rating no longer is previousRating, so concurrent edits cannot both count.
+// On a standalone mongod the rating is restored if the tour cannot be updated.
+func (r *TourRepository) UpdateReview(ctx context.Context, review *models.Review, previousRating int32) error {
+	review.UpdatedAt = time.Now()
+
+	save := func(ctx context.Context, rating, expected int32) error {
+		result, err := r.reviewCollection.UpdateOne(
+			ctx,
+			bson.M{"_id": review.ID, "rating": expected},
+			bson.M{"$set": bson.M{
+				"rating":    rating,
+				"comment":   review.Comment,
+				"visitDate": review.VisitDate,
+				"images":    review.Images,
+				"updatedAt": review.UpdatedAt,
+			}},
+		)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return ErrReviewChanged
+		}
+		return nil
+	}
+	update := func(ctx context.Context) error {
+		if err := save(ctx, review.Rating, previousRating); err != nil {
+			return err
+		}
+		return r.addRatings(ctx, review.TourID, 0, review.Rating-previousRating)
+	}
+
+	err := r.inTransaction(ctx, update)
+	if !isTransactionUnsupported(err) {
+		return err
+	}
+
+	if err := save(ctx, review.Rating, previousRating); err != nil {
+		return err
+	}
+	if err := r.addRatings(ctx, review.TourID, 0, review.Rating-previousRating); err != nil {
+		_ = save(ctx, previousRating, review.Rating)
 		return err
 	}
-	token.ID = result.InsertedID.(primitive.ObjectID)
 	return nil
 }
 
-func (r *TourRepository) HasPurchased(ctx context.Context, touristID string, tourID primitive.ObjectID) (bool, error) {
-	count, err := r.tokenCollection.CountDocuments(ctx, bson.M{
-		"touristId": touristID,
-		"tourId":    tourID,
+// addRatings adds count reviews with a combined rating of total to a tour
+// and recomputes its average in the same update.
+func (r *TourRepository) addRatings(ctx context.Context, tourID primitive.ObjectID, count, total int32) error {
+	_, err := r.toursCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": tourID},
+		mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{
+				"reviewCount": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$reviewCount", 0}}, count}},
+				"ratingTotal": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$ratingTotal", 0}}, total}},
+			}}},
+			{{Key: "$set", Value: bson.M{
+				"averageRating": bson.M{"$cond": bson.A{
+					bson.M{"$gt": bson.A{"$reviewCount", 0}},
+					bson.M{"$divide": bson.A{"$ratingTotal", "$reviewCount"}},
+					0,
+				}},
+			}}},
+		},
+	)
+	return err
+}
+
+// atomically runs fn in a transaction, or as plain writes on a standalone
+// mongod where transactions are unavailable.
+func (r *TourRepository) atomically(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := r.inTransaction(ctx, fn)
+	if isTransactionUnsupported(err) {
+		return fn(ctx)
+	}
+	return err
+}
+
+// inTransaction runs fn in a transaction. Callers fall back to running
+// without one when isTransactionUnsupported reports the returned error.
+func (r *TourRepository) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := r.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
 	})
-	return count > 0, err
+	return err
 }
 
 // ============ Tour Execution Operations ============
@@ -244,7 +1433,7 @@ func (r *TourRepository) HasPurchased(ctx context.Context, touristID string, tou
 func (r *TourRepository) CreateExecution(ctx context.Context, execution *models.TourExecution) error {
 	execution.StartedAt = time.Now()
 	execution.LastActivity = time.Now()
-	execution.Status = "active"
+	execution.Status = models.ExecutionStatusActive
 	execution.CompletedKeypoints = []models.CompletedKeypoint{}
 
 	result, err := r.executionCollection.InsertOne(ctx, execution)
@@ -252,6 +1441,19 @@ func (r *TourRepository) CreateExecution(ctx context.Context, execution *models.
 		return err
 	}
 	execution.ID = result.InsertedID.(primitive.ObjectID)
+
+	// The path starts where the execution did. Time-series collections take
+	// no part in transactions, so a failure here leaves the execution as is.
+	_, err = r.sampleCollection.InsertOne(ctx, &models.PositionSample{
+		ExecutionID: execution.ID,
+		TouristID:   execution.TouristID,
+		Latitude:    execution.StartLatitude,
+		Longitude:   execution.StartLongitude,
+		RecordedAt:  execution.StartedAt,
+	})
+	if err != nil {
+		log.Printf("Error recording start of execution %s: %v", execution.ID.Hex(), err)
+	}
 	return nil
 }
 
@@ -264,14 +1466,44 @@ func (r *TourRepository) GetExecution(ctx context.Context, executionID primitive
 	return &execution, nil
 }
 
+// UpdateExecution saves an execution read while it was active, including
+// its move to completed or abandoned. It returns ErrExecutionStatusChanged
+// when the stored execution is no longer active, so a finished run is never
+// reopened by a stale copy.
 func (r *TourRepository) UpdateExecution(ctx context.Context, execution *models.TourExecution) error {
 	execution.LastActivity = time.Now()
-	_, err := r.executionCollection.UpdateOne(
+	result, err := r.executionCollection.UpdateOne(
 		ctx,
-		bson.M{"_id": execution.ID},
+		bson.M{"_id": execution.ID, "status": models.ExecutionStatusActive},
 		bson.M{"$set": execution},
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrExecutionStatusChanged
+	}
+	return nil
+}
+
+// AbandonStaleExecutions abandons the active executions with no activity
+// since inactiveSince and returns how many it abandoned.
+func (r *TourRepository) AbandonStaleExecutions(ctx context.Context, inactiveSince time.Time) (int64, error) {
+	result, err := r.executionCollection.UpdateMany(
+		ctx,
+		bson.M{
+			"status":       models.ExecutionStatusActive,
+			"lastActivity": bson.M{"$lt": inactiveSince},
+		},
+		bson.M{"$set": bson.M{
+			"status":      models.ExecutionStatusAbandoned,
+			"completedAt": time.Now(),
+		}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
 }
 
 func (r *TourRepository) GetActiveExecution(ctx context.Context, touristID string, tourID primitive.ObjectID) (*models.TourExecution, error) {
@@ -279,20 +1511,45 @@ func (r *TourRepository) GetActiveExecution(ctx context.Context, touristID strin
 	err := r.executionCollection.FindOne(ctx, bson.M{
 		"touristId": touristID,
 		"tourId":    tourID,
-		"status":    "active",
+		"status":    models.ExecutionStatusActive,
 	}).Decode(&execution)
-	
+
 	if err != nil {
 		return nil, err
 	}
 	return &execution, nil
 }
 
-func (r *TourRepository) GetExecutionsByTouristID(ctx context.Context, touristID string) ([]*models.TourExecution, error) {
-	cursor, err := r.executionCollection.Find(ctx, bson.M{
+// HasReviewableExecution reports whether the tourist completed the tour or
+// was active on it at or after activeSince.
+func (r *TourRepository) HasReviewableExecution(ctx context.Context, touristID string, tourID primitive.ObjectID, activeSince time.Time) (bool, error) {
+	count, err := r.executionCollection.CountDocuments(ctx, bson.M{
 		"touristId": touristID,
-		"status":    "active", // Only active executions
+		"tourId":    tourID,
+		"$or": bson.A{
+			bson.M{"status": models.ExecutionStatusCompleted},
+			bson.M{"lastActivity": bson.M{"$gte": activeSince}},
+		},
 	})
+	return count > 0, err
+}
+
+// ListExecutions returns a page of a tourist's executions, most recently
+// started first. page.Sort is ignored.
+func (r *TourRepository) ListExecutions(ctx context.Context, filter ExecutionFilter, page PageRequest) (*ExecutionPage, error) {
+	query := filter.query()
+	if page.Token != "" {
+		token, err := decodeExecutionPageToken(page.Token)
+		if err != nil {
+			return nil, err
+		}
+		query["$or"] = token.after()
+	}
+
+	// One extra execution tells whether another page follows
+	size := page.size()
+	opts := options.Find().SetSort(executionOrder).SetLimit(int64(size + 1))
+	cursor, err := r.executionCollection.Find(ctx, query, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -302,5 +1559,11 @@ func (r *TourRepository) GetExecutionsByTouristID(ctx context.Context, touristID
 	if err = cursor.All(ctx, &executions); err != nil {
 		return nil, err
 	}
-	return executions, nil
-}
\ No newline at end of file
+
+	result := &ExecutionPage{Executions: executions}
+	if len(executions) > size {
+		result.Executions = executions[:size]
+		result.NextPageToken = encodeExecutionPageToken(executions[size-1])
+	}
+	return result, nil
+}
@@ -0,0 +1,431 @@
+//go:build integration
+
+// Integration tests against a real MongoDB. Run them with
+//
+//	MONGO_URI=mongodb://localhost:27017 go test -tags integration ./internal/repository/
+//
+// Every test works in a throwaway database that is dropped afterwards.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+	"tour-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func newIntegrationRepository(t *testing.T) *TourRepository {
+	t.Helper()
+	uri := os.Getenv("MONGO_URI")
+	if uri == "" {
+		t.Skip("MONGO_URI is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	require.NoError(t, err)
+	require.NoError(t, client.Ping(ctx, nil))
+
+	db := client.Database(fmt.Sprintf("tour-test-%s", primitive.NewObjectID().Hex()))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+		_ = client.Disconnect(ctx)
+	})
+
+	repo := NewTourRepository(db)
+	require.NoError(t, repo.EnsureIndexes(ctx))
+	return repo
+}
+
+func seedKeyPoints(t *testing.T, repo *TourRepository, tourID primitive.ObjectID, names ...string) []*models.KeyPoint {
+	t.Helper()
+	keyPoints := make([]*models.KeyPoint, len(names))
+	for i, name := range names {
+		keyPoints[i] = &models.KeyPoint{TourID: tourID, Name: name, Latitude: 44.8176, Longitude: 20.4569}
+		require.NoError(t, repo.CreateKeyPoint(context.Background(), keyPoints[i]))
+	}
+	return keyPoints
+}
+
+func keyPointNames(t *testing.T, repo *TourRepository, tourID primitive.ObjectID) []string {
+	t.Helper()
+	keyPoints, err := repo.GetKeyPointsByTourID(context.Background(), tourID)
+	require.NoError(t, err)
+	names := make([]string, len(keyPoints))
+	for i, kp := range keyPoints {
+		names[i] = kp.Name
+		assert.Equal(t, int32(i+1), kp.Order, "order of %s", kp.Name)
+	}
+	return names
+}
+
+// ── Keypoint scoping ──────────────────────────────────────────────────────────
+
+func TestIntegration_UpdateKeyPoint_OtherTour_ReturnsErrNoDocuments(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	ownTour, foreignTour := primitive.NewObjectID(), primitive.NewObjectID()
+	foreign := seedKeyPoints(t, repo, foreignTour, "Gate")[0]
+
+	err := repo.UpdateKeyPoint(context.Background(), &models.KeyPoint{
+		ID:     foreign.ID,
+		TourID: ownTour,
+		Name:   "Hijacked",
+	})
+
+	assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+	assert.Equal(t, []string{"Gate"}, keyPointNames(t, repo, foreignTour))
+}
+
+func TestIntegration_DeleteKeyPoint_OtherTour_ReturnsErrNoDocuments(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	ownTour, foreignTour := primitive.NewObjectID(), primitive.NewObjectID()
+	foreign := seedKeyPoints(t, repo, foreignTour, "Gate")[0]
+
+	err := repo.DeleteKeyPoint(context.Background(), ownTour, foreign.ID)
+
+	assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+	assert.Equal(t, []string{"Gate"}, keyPointNames(t, repo, foreignTour))
+}
+
+func TestIntegration_UpdateKeyPoint_KeepsOrder(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	tourID := primitive.NewObjectID()
+	keyPoints := seedKeyPoints(t, repo, tourID, "Gate", "Tower")
+
+	update := &models.KeyPoint{ID: keyPoints[1].ID, TourID: tourID, Name: "Clock tower"}
+	require.NoError(t, repo.UpdateKeyPoint(context.Background(), update))
+
+	assert.Equal(t, int32(2), update.Order)
+	assert.Equal(t, []string{"Gate", "Clock tower"}, keyPointNames(t, repo, tourID))
+}
+
+// ── Keypoint ordering ─────────────────────────────────────────────────────────
+
+func TestIntegration_CreateKeyPoint_InsertsAtOrder(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	tourID := primitive.NewObjectID()
+	seedKeyPoints(t, repo, tourID, "Gate", "Tower")
+
+	inserted := &models.KeyPoint{TourID: tourID, Name: "Well", Order: 2}
+	require.NoError(t, repo.CreateKeyPoint(context.Background(), inserted))
+
+	assert.Equal(t, int32(2), inserted.Order)
+	assert.Equal(t, []string{"Gate", "Well", "Tower"}, keyPointNames(t, repo, tourID))
+}
+
+func TestIntegration_DeleteKeyPoint_CompactsOrder(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	tourID := primitive.NewObjectID()
+	keyPoints := seedKeyPoints(t, repo, tourID, "Gate", "Well", "Tower")
+
+	require.NoError(t, repo.DeleteKeyPoint(context.Background(), tourID, keyPoints[0].ID))
+
+	assert.Equal(t, []string{"Well", "Tower"}, keyPointNames(t, repo, tourID))
+}
+
+func TestIntegration_ReorderKeyPoints(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	tourID := primitive.NewObjectID()
+	keyPoints := seedKeyPoints(t, repo, tourID, "Gate", "Well", "Tower")
+
+	err := repo.ReorderKeyPoints(context.Background(), tourID, []primitive.ObjectID{keyPoints[0].ID, keyPoints[2].ID})
+	assert.ErrorIs(t, err, ErrKeyPointsMismatch)
+
+	err = repo.ReorderKeyPoints(context.Background(), tourID, []primitive.ObjectID{keyPoints[2].ID, keyPoints[0].ID, keyPoints[1].ID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Tower", "Gate", "Well"}, keyPointNames(t, repo, tourID))
+}
+
+func TestIntegration_BackfillKeyPointOrders_RenumbersBrokenTours(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	ctx := context.Background()
+	broken := primitive.NewObjectID()
+	intact := primitive.NewObjectID()
+	// Orders left behind by the old UpdateKeyPoint
+	_, err := repo.keypointsCollection.InsertMany(ctx, []interface{}{
+		&models.KeyPoint{ID: primitive.NewObjectID(), TourID: broken, Name: "Gate", Order: 0},
+		&models.KeyPoint{ID: primitive.NewObjectID(), TourID: broken, Name: "Well", Order: 2},
+		&models.KeyPoint{ID: primitive.NewObjectID(), TourID: broken, Name: "Tower", Order: 2},
+	})
+	require.NoError(t, err)
+	seedKeyPoints(t, repo, intact, "Bridge", "Square")
+
+	require.NoError(t, repo.BackfillKeyPointOrders(ctx))
+
+	keyPoints, err := repo.GetKeyPointsByTourID(ctx, broken)
+	require.NoError(t, err)
+	for i, kp := range keyPoints {
+		assert.Equal(t, int32(i+1), kp.Order)
+	}
+	assert.Equal(t, []string{"Gate", "Well", "Tower"}, keyPointNames(t, repo, broken))
+	assert.Equal(t, []string{"Bridge", "Square"}, keyPointNames(t, repo, intact))
+}
+
+func TestIntegration_ImportKeyPoints(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	tourID := primitive.NewObjectID()
+	seedKeyPoints(t, repo, tourID, "Gate")
+
+	appended := []*models.KeyPoint{{Name: "Well"}, {Name: "Tower"}}
+	require.NoError(t, repo.ImportKeyPoints(context.Background(), tourID, appended, false))
+	assert.Equal(t, int32(3), appended[1].Order)
+	assert.Equal(t, []string{"Gate", "Well", "Tower"}, keyPointNames(t, repo, tourID))
+
+	replacement := []*models.KeyPoint{{Name: "Bridge"}}
+	require.NoError(t, repo.ImportKeyPoints(context.Background(), tourID, replacement, true))
+	assert.Equal(t, []string{"Bridge"}, keyPointNames(t, repo, tourID))
+}
+
+// ── Position history ──────────────────────────────────────────────────────────
+
+func TestIntegration_UpsertPosition_RecordsSamplesOfActiveExecutions(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	ctx := context.Background()
+	require.NoError(t, repo.EnsurePositionHistory(ctx, time.Hour))
+	// A second run only updates the retention of the existing collection
+	require.NoError(t, repo.EnsurePositionHistory(ctx, 2*time.Hour))
+
+	active := &models.TourExecution{TouristID: "tourist123", TourID: primitive.NewObjectID(), StartLatitude: 44.8176, StartLongitude: 20.4569}
+	require.NoError(t, repo.CreateExecution(ctx, active))
+	finished := &models.TourExecution{TouristID: "tourist123", TourID: primitive.NewObjectID()}
+	require.NoError(t, repo.CreateExecution(ctx, finished))
+	finished.Status = "completed"
+	require.NoError(t, repo.UpdateExecution(ctx, finished))
+
+	require.NoError(t, repo.UpsertPosition(ctx, &models.Position{TouristID: "tourist123", Latitude: 44.8231, Longitude: 20.4504}))
+
+	samples, err := repo.GetPositionSamples(ctx, active.ID)
+	require.NoError(t, err)
+	require.Len(t, samples, 2)
+	assert.Equal(t, 44.8176, samples[0].Latitude)
+	assert.Equal(t, 44.8231, samples[1].Latitude)
+
+	samples, err = repo.GetPositionSamples(ctx, finished.ID)
+	require.NoError(t, err)
+	assert.Len(t, samples, 1, "only the start of a finished execution is recorded")
+}
+
+// ── Execution state ───────────────────────────────────────────────────────────
+
+func TestIntegration_UpdateExecution_FinishedExecution_ReturnsErrExecutionStatusChanged(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	ctx := context.Background()
+
+	execution := &models.TourExecution{TouristID: "tourist123", TourID: primitive.NewObjectID()}
+	require.NoError(t, repo.CreateExecution(ctx, execution))
+	stale := *execution
+	execution.Status = models.ExecutionStatusAbandoned
+	require.NoError(t, repo.UpdateExecution(ctx, execution))
+
+	stale.Status = models.ExecutionStatusCompleted
+	err := repo.UpdateExecution(ctx, &stale)
+
+	assert.ErrorIs(t, err, ErrExecutionStatusChanged)
+	stored, err := repo.GetExecution(ctx, execution.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusAbandoned, stored.Status)
+}
+
+func TestIntegration_AbandonStaleExecutions(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	ctx := context.Background()
+
+	stale := &models.TourExecution{TouristID: "tourist123", TourID: primitive.NewObjectID()}
+	require.NoError(t, repo.CreateExecution(ctx, stale))
+	// Dates are stored to the millisecond
+	time.Sleep(10 * time.Millisecond)
+	cutoff := time.Now()
+	fresh := &models.TourExecution{TouristID: "tourist123", TourID: primitive.NewObjectID()}
+	require.NoError(t, repo.CreateExecution(ctx, fresh))
+
+	abandoned, err := repo.AbandonStaleExecutions(ctx, cutoff)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), abandoned)
+
+	stored, err := repo.GetExecution(ctx, stale.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusAbandoned, stored.Status)
+	stored, err = repo.GetExecution(ctx, fresh.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusActive, stored.Status)
+}
+
+// ── Execution history ─────────────────────────────────────────────────────────
+
+func TestIntegration_ListExecutions_PagesNewestFirst(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	ctx := context.Background()
+
+	var ids []primitive.ObjectID
+	for i := 0; i < 3; i++ {
+		execution := &models.TourExecution{TouristID: "tourist123", TourID: primitive.NewObjectID()}
+		require.NoError(t, repo.CreateExecution(ctx, execution))
+		ids = append(ids, execution.ID)
+		// Dates are stored to the millisecond
+		time.Sleep(10 * time.Millisecond)
+	}
+	other := &models.TourExecution{TouristID: "tourist456", TourID: primitive.NewObjectID()}
+	require.NoError(t, repo.CreateExecution(ctx, other))
+
+	filter := ExecutionFilter{TouristID: "tourist123"}
+	first, err := repo.ListExecutions(ctx, filter, PageRequest{Size: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Executions, 2)
+	assert.Equal(t, ids[2], first.Executions[0].ID)
+	assert.Equal(t, ids[1], first.Executions[1].ID)
+	require.NotEmpty(t, first.NextPageToken)
+
+	second, err := repo.ListExecutions(ctx, filter, PageRequest{Size: 2, Token: first.NextPageToken})
+	require.NoError(t, err)
+	require.Len(t, second.Executions, 1)
+	assert.Equal(t, ids[0], second.Executions[0].ID)
+	assert.Empty(t, second.NextPageToken)
+}
+
+// ── Startup dedupe ────────────────────────────────────────────────────────────
+
+func TestIntegration_DedupePurchaseTokens_KeepsFirstToken(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	ctx := context.Background()
+	// Simulate a database from before the unique index
+	_, err := repo.tokenCollection.Indexes().DropAll(ctx)
+	require.NoError(t, err)
+
+	tourID := primitive.NewObjectID()
+	first := time.Now().Add(-time.Hour)
+	_, err = repo.tokenCollection.InsertMany(ctx, []interface{}{
+		&models.PurchaseToken{ID: primitive.NewObjectID(), TouristID: "tourist123", TourID: tourID, Token: "first", PurchasedAt: first},
+		&models.PurchaseToken{ID: primitive.NewObjectID(), TouristID: "tourist123", TourID: tourID, Token: "retry", PurchasedAt: first.Add(time.Second)},
+		&models.PurchaseToken{ID: primitive.NewObjectID(), TouristID: "tourist456", TourID: tourID, Token: "other", PurchasedAt: first},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.DedupePurchaseTokens(ctx))
+	require.NoError(t, repo.EnsureIndexes(ctx))
+
+	var tokens []*models.PurchaseToken
+	cursor, err := repo.tokenCollection.Find(ctx, bson.M{"touristId": "tourist123"})
+	require.NoError(t, err)
+	require.NoError(t, cursor.All(ctx, &tokens))
+	require.Len(t, tokens, 1)
+	assert.Equal(t, "first", tokens[0].Token)
+}
+
+func TestIntegration_DedupeReviews_KeepsLatestReviewAndRating(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	ctx := context.Background()
+	_, err := repo.reviewCollection.Indexes().DropAll(ctx)
+	require.NoError(t, err)
+
+	tour := &models.Tour{GuideID: "guide123", Name: "Fortress walk"}
+	require.NoError(t, repo.CreateTour(ctx, tour))
+	older := &models.Review{TourID: tour.ID, TouristID: "tourist123", Rating: 1}
+	require.NoError(t, repo.CreateReview(ctx, older))
+	time.Sleep(10 * time.Millisecond)
+	newer := &models.Review{TourID: tour.ID, TouristID: "tourist123", Rating: 5}
+	require.NoError(t, repo.CreateReview(ctx, newer))
+
+	require.NoError(t, repo.DedupeReviews(ctx))
+	require.NoError(t, repo.EnsureIndexes(ctx))
+
+	reviews, err := repo.GetReviewsByTourID(ctx, tour.ID)
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	assert.Equal(t, newer.ID, reviews[0].ID)
+	stored, err := repo.GetTourByID(ctx, tour.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), stored.ReviewCount)
+	assert.Equal(t, 5.0, stored.AverageRating)
+}
+
+// ── Checkout ──────────────────────────────────────────────────────────────────
+
+func TestIntegration_CheckoutCart_ConcurrentSameKey_ReplaysFirstResult(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	ctx := context.Background()
+
+	tour := &models.Tour{GuideID: "guide123", Name: "Fortress walk"}
+	require.NoError(t, repo.CreateTour(ctx, tour))
+	require.NoError(t, repo.PublishTour(ctx, tour.ID, 25))
+	cart, err := repo.GetOrCreateCart(ctx, "tourist123")
+	require.NoError(t, err)
+	cart.Items = []models.CartItem{{TourID: tour.ID, TourName: tour.Name, Price: 25}}
+	require.NoError(t, repo.UpdateCart(ctx, cart))
+
+	results := make([]*CheckoutResult, 2)
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = repo.CheckoutCart(ctx, "tourist123", "key-1")
+		}(i)
+	}
+	wg.Wait()
+
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+	require.Len(t, results[0].Tokens, 1)
+	require.Len(t, results[1].Tokens, 1)
+	assert.Equal(t, results[0].Tokens[0].Token, results[1].Tokens[0].Token)
+	assert.True(t, results[0].Replayed != results[1].Replayed, "exactly one call buys")
+}
+
+func TestIntegration_CheckoutCart_ArchivedTour_ReturnsUnavailableToursError(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	ctx := context.Background()
+
+	published := &models.Tour{GuideID: "guide123", Name: "Fortress walk"}
+	require.NoError(t, repo.CreateTour(ctx, published))
+	require.NoError(t, repo.PublishTour(ctx, published.ID, 25))
+	archived := &models.Tour{GuideID: "guide123", Name: "River walk"}
+	require.NoError(t, repo.CreateTour(ctx, archived))
+	require.NoError(t, repo.PublishTour(ctx, archived.ID, 10))
+	cart, err := repo.GetOrCreateCart(ctx, "tourist123")
+	require.NoError(t, err)
+	cart.Items = []models.CartItem{{TourID: published.ID, Price: 25}, {TourID: archived.ID, Price: 10}}
+	require.NoError(t, repo.UpdateCart(ctx, cart))
+	require.NoError(t, repo.ArchiveTour(ctx, archived.ID))
+
+	_, err = repo.CheckoutCart(ctx, "tourist123", "")
+
+	var unavailable *UnavailableToursError
+	require.ErrorAs(t, err, &unavailable)
+	assert.Equal(t, []primitive.ObjectID{archived.ID}, unavailable.TourIDs)
+	purchased, err := repo.HasPurchased(ctx, "tourist123", published.ID)
+	require.NoError(t, err)
+	assert.False(t, purchased)
+}
+
+// ── Search ────────────────────────────────────────────────────────────────────
+
+func TestIntegration_SearchTours_WithoutTextIndex_FallsBackToMatcher(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	ctx := context.Background()
+	_, err := repo.toursCollection.Indexes().DropOne(ctx, "tours_text")
+	require.NoError(t, err)
+
+	tour := &models.Tour{GuideID: "guide123", Name: "Fortress walk"}
+	require.NoError(t, repo.CreateTour(ctx, tour))
+	require.NoError(t, repo.PublishTour(ctx, tour.ID, 25))
+
+	result, err := repo.SearchTours(ctx, "fortress", PageRequest{})
+	require.NoError(t, err)
+	require.Len(t, result.Hits, 1)
+	assert.Equal(t, tour.ID, result.Hits[0].Tour.ID)
+	assert.NotEmpty(t, result.Hits[0].Snippets)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 	"tour-service/internal/models"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -12,33 +13,50 @@ type TourRepositoryInterface interface {
 	CreateTour(ctx context.Context, tour *models.Tour) error
 	GetTourByID(ctx context.Context, id primitive.ObjectID) (*models.Tour, error)
 	GetToursByGuideID(ctx context.Context, guideID string) ([]*models.Tour, error)
-	GetPublishedTours(ctx context.Context) ([]*models.Tour, error)
+	ListTours(ctx context.Context, filter TourFilter, page PageRequest) (*TourPage, error)
 	UpdateTour(ctx context.Context, tour *models.Tour) error
 	PublishTour(ctx context.Context, tourID primitive.ObjectID, price float64) error
+	ArchiveTour(ctx context.Context, tourID primitive.ObjectID) error
+	ReactivateTour(ctx context.Context, tourID primitive.ObjectID) error
+	SetTourMetrics(ctx context.Context, tourID primitive.ObjectID, metrics TourMetrics) error
 
 	// KeyPoint operations
 	CreateKeyPoint(ctx context.Context, keypoint *models.KeyPoint) error
 	GetKeyPointsByTourID(ctx context.Context, tourID primitive.ObjectID) ([]*models.KeyPoint, error)
 	UpdateKeyPoint(ctx context.Context, keypoint *models.KeyPoint) error
-	DeleteKeyPoint(ctx context.Context, keypointID primitive.ObjectID) error
+	DeleteKeyPoint(ctx context.Context, tourID, keypointID primitive.ObjectID) error
+	ReorderKeyPoints(ctx context.Context, tourID primitive.ObjectID, keyPointIDs []primitive.ObjectID) error
+	ImportKeyPoints(ctx context.Context, tourID primitive.ObjectID, keypoints []*models.KeyPoint, replace bool) error
+	SearchToursNearby(ctx context.Context, latitude, longitude, radiusMeters float64, limit int) ([]*NearbyTour, error)
+	SearchTours(ctx context.Context, query string, page PageRequest) (*TourSearchPage, error)
 
 	// Position operations
 	UpsertPosition(ctx context.Context, position *models.Position) error
 	GetPosition(ctx context.Context, touristID string) (*models.Position, error)
+	GetPositionSamples(ctx context.Context, executionID primitive.ObjectID) ([]*models.PositionSample, error)
 
 	// Cart operations
 	GetOrCreateCart(ctx context.Context, touristID string) (*models.ShoppingCart, error)
 	UpdateCart(ctx context.Context, cart *models.ShoppingCart) error
-	ClearCart(ctx context.Context, touristID string) error
 
 	// Purchase token operations
-	CreatePurchaseToken(ctx context.Context, token *models.PurchaseToken) error
 	HasPurchased(ctx context.Context, touristID string, tourID primitive.ObjectID) (bool, error)
 
+	// Checkout
+	CheckoutCart(ctx context.Context, touristID, idempotencyKey string) (*CheckoutResult, error)
+
+	// Review operations
+	CreateReview(ctx context.Context, review *models.Review) error
+	GetReviewByID(ctx context.Context, reviewID primitive.ObjectID) (*models.Review, error)
+	GetReviewsByTourID(ctx context.Context, tourID primitive.ObjectID) ([]*models.Review, error)
+	UpdateReview(ctx context.Context, review *models.Review, previousRating int32) error
+
 	// Execution operations
 	CreateExecution(ctx context.Context, execution *models.TourExecution) error
 	GetExecution(ctx context.Context, executionID primitive.ObjectID) (*models.TourExecution, error)
 	UpdateExecution(ctx context.Context, execution *models.TourExecution) error
+	AbandonStaleExecutions(ctx context.Context, inactiveSince time.Time) (int64, error)
 	GetActiveExecution(ctx context.Context, touristID string, tourID primitive.ObjectID) (*models.TourExecution, error)
-	GetExecutionsByTouristID(ctx context.Context, touristID string) ([]*models.TourExecution, error)
+	ListExecutions(ctx context.Context, filter ExecutionFilter, page PageRequest) (*ExecutionPage, error)
+	HasReviewableExecution(ctx context.Context, touristID string, tourID primitive.ObjectID, activeSince time.Time) (bool, error)
 }
@@ -0,0 +1,61 @@
+// Package sweeper abandons tour executions that have gone quiet, so a
+// tourist who walks away without abandoning a run does not keep it active
+// forever.
+package sweeper
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"tour-service/internal/repository"
+)
+
+// Sweeper periodically abandons the active executions with no activity for
+// longer than its window.
+type Sweeper struct {
+	repo     repository.TourRepositoryInterface
+	window   time.Duration
+	interval time.Duration
+	now      func() time.Time
+}
+
+func New(repo repository.TourRepositoryInterface, window, interval time.Duration) *Sweeper {
+	return &Sweeper{
+		repo:     repo,
+		window:   window,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Run sweeps once and then every interval until ctx is done.
+func (s *Sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Sweep(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Error abandoning stale executions: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep abandons the executions that have been inactive for longer than the
+// window and returns how many there were.
+func (s *Sweeper) Sweep(ctx context.Context) (int64, error) {
+	abandoned, err := s.repo.AbandonStaleExecutions(ctx, s.now().Add(-s.window))
+	if err != nil {
+		return 0, err
+	}
+	if abandoned > 0 {
+		log.Printf("Abandoned %d executions inactive for over %s", abandoned, s.window)
+	}
+	return abandoned, nil
+}
@@ -0,0 +1,73 @@
+package sweeper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"tour-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweep_AbandonsExecutionsInactiveForWindow(t *testing.T) {
+	mockRepo := new(repository.MockTourRepository)
+	s := New(mockRepo, 2*time.Hour, time.Minute)
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	mockRepo.On("AbandonStaleExecutions", mock.Anything, now.Add(-2*time.Hour)).
+		Return(int64(3), nil)
+
+	abandoned, err := s.Sweep(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), abandoned)
+}
+
+func TestSweep_RepositoryError_ReturnsError(t *testing.T) {
+	mockRepo := new(repository.MockTourRepository)
+	s := New(mockRepo, time.Hour, time.Minute)
+
+	mockRepo.On("AbandonStaleExecutions", mock.Anything, mock.Anything).
+		Return(int64(0), errors.New("connection refused"))
+
+	_, err := s.Sweep(context.Background())
+
+	assert.Error(t, err)
+}
+
+func TestRun_StopsWhenContextIsDone(t *testing.T) {
+	mockRepo := new(repository.MockTourRepository)
+	s := New(mockRepo, time.Hour, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	swept := make(chan struct{}, 1)
+	mockRepo.On("AbandonStaleExecutions", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) {
+			select {
+			case swept <- struct{}{}:
+			default:
+			}
+		}).
+		Return(int64(0), nil)
+
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	<-swept
+	<-swept
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after the context was cancelled")
+	}
+}
@@ -5,18 +5,30 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
+	"tour-service/internal/auth"
 	"tour-service/internal/config"
 	"tour-service/internal/handlers"
 	"tour-service/internal/repository"
+	"tour-service/internal/sweeper"
 	pb "tour-service/proto"
+	authpb "tour-service/proto/auth"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
+// shutdownTimeout is how long open RPCs, tracking streams included, get to
+// finish on shutdown before they are cut off.
+const shutdownTimeout = 15 * time.Second
+
 func main() {
 	// Load configuration
 	cfg := config.LoadConfig()
@@ -41,12 +53,50 @@ func main() {
 	// Get database and create repository
 	db := client.Database(cfg.DatabaseName)
 	repo := repository.NewTourRepository(db)
+	if err := repo.BackfillKeyPointLocations(ctx); err != nil {
+		log.Fatalf("Failed to backfill keypoint locations: %v", err)
+	}
+	if err := repo.BackfillKeyPointOrders(ctx); err != nil {
+		log.Fatalf("Failed to backfill keypoint orders: %v", err)
+	}
+	if err := repo.BackfillKeyPointSummaries(ctx); err != nil {
+		log.Fatalf("Failed to backfill keypoint summaries: %v", err)
+	}
+	if err := repo.DedupePurchaseTokens(ctx); err != nil {
+		log.Fatalf("Failed to dedupe purchase tokens: %v", err)
+	}
+	if err := repo.DedupeReviews(ctx); err != nil {
+		log.Fatalf("Failed to dedupe reviews: %v", err)
+	}
+	if err := repo.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("Failed to create indexes: %v", err)
+	}
+	if err := repo.EnsurePositionHistory(ctx, cfg.PositionHistoryRetention); err != nil {
+		log.Fatalf("Failed to set up position history: %v", err)
+	}
+
+	// Connect to Auth Service for token validation
+	authConn, err := grpc.NewClient(cfg.AuthServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to create auth service client: %v", err)
+	}
+	defer authConn.Close()
+
+	validator := auth.NewCachingValidator(
+		auth.NewGrpcTokenValidator(authpb.NewAuthServiceClient(authConn)),
+		cfg.AuthCacheTTL,
+	)
+	authInterceptor := auth.NewInterceptor(validator)
+	authorizer := auth.NewAuthorizer(handlers.Policy)
 
 	// Create gRPC server
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(authInterceptor.Unary(), authorizer.Unary()),
+		grpc.ChainStreamInterceptor(authInterceptor.Stream(), authorizer.Stream()),
+	)
 
 	// Register Tour Service
-	tourHandler := handlers.NewTourServiceHandler(repo)
+	tourHandler := handlers.NewTourServiceHandler(repo, handlers.WithLegacyErrorEnvelope(cfg.LegacyErrorEnvelope))
 	pb.RegisterTourServiceServer(grpcServer, tourHandler)
 
 	// Start listening
@@ -56,8 +106,48 @@ func main() {
 		log.Fatalf("Failed to listen on %s: %v", address, err)
 	}
 
+	// SIGINT and SIGTERM stop the sweeper and drain the server
+	runCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var background sync.WaitGroup
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		validator.Run(runCtx)
+	}()
+	if cfg.ExecutionInactivityTimeout > 0 {
+		executionSweeper := sweeper.New(repo, cfg.ExecutionInactivityTimeout, cfg.ExecutionSweepInterval)
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			executionSweeper.Run(runCtx)
+		}()
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		served <- grpcServer.Serve(listener)
+	}()
 	log.Printf("Tour Service is running on %s", address)
-	if err := grpcServer.Serve(listener); err != nil {
+
+	select {
+	case err := <-served:
 		log.Fatalf("Failed to serve: %v", err)
+	case <-runCtx.Done():
+	}
+
+	log.Println("Shutting down Tour Service")
+	drained := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(shutdownTimeout):
+		log.Println("RPCs still open after shutdown timeout, stopping")
+		grpcServer.Stop()
 	}
+	background.Wait()
 }
@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,16 +22,70 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type TourSort int32
+
+const (
+	TourSort_TOUR_SORT_NEWEST     TourSort = 0
+	TourSort_TOUR_SORT_PRICE_ASC  TourSort = 1
+	TourSort_TOUR_SORT_PRICE_DESC TourSort = 2
+	TourSort_TOUR_SORT_NAME       TourSort = 3
+)
+
+// Enum value maps for TourSort.
+var (
+	TourSort_name = map[int32]string{
+		0: "TOUR_SORT_NEWEST",
+		1: "TOUR_SORT_PRICE_ASC",
+		2: "TOUR_SORT_PRICE_DESC",
+		3: "TOUR_SORT_NAME",
+	}
+	TourSort_value = map[string]int32{
+		"TOUR_SORT_NEWEST":     0,
+		"TOUR_SORT_PRICE_ASC":  1,
+		"TOUR_SORT_PRICE_DESC": 2,
+		"TOUR_SORT_NAME":       3,
+	}
+)
+
+func (x TourSort) Enum() *TourSort {
+	p := new(TourSort)
+	*p = x
+	return p
+}
+
+func (x TourSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TourSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_tour_proto_enumTypes[0].Descriptor()
+}
+
+func (TourSort) Type() protoreflect.EnumType {
+	return &file_tour_proto_enumTypes[0]
+}
+
+func (x TourSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TourSort.Descriptor instead.
+func (TourSort) EnumDescriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{0}
+}
+
 // ============ Tour Operations ============
 type CreateTourRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	GuideId       string                 `protobuf:"bytes,1,opt,name=guideId,proto3" json:"guideId,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Difficulty    string                 `protobuf:"bytes,4,opt,name=difficulty,proto3" json:"difficulty,omitempty"` // "easy", "medium", "hard"
-	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	GuideId         string                 `protobuf:"bytes,1,opt,name=guideId,proto3" json:"guideId,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Difficulty      string                 `protobuf:"bytes,4,opt,name=difficulty,proto3" json:"difficulty,omitempty"` // "easy", "medium", "hard"
+	Tags            []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	ExecutionMode   string                 `protobuf:"bytes,6,opt,name=executionMode,proto3" json:"executionMode,omitempty"`       // "free_roam" (default) or "sequential"
+	ProximityRadius float64                `protobuf:"fixed64,7,opt,name=proximityRadius,proto3" json:"proximityRadius,omitempty"` // Meters, 5 to 2000; 0 uses the default of 50
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *CreateTourRequest) Reset() {
@@ -98,11 +153,26 @@ func (x *CreateTourRequest) GetTags() []string {
 	return nil
 }
 
+func (x *CreateTourRequest) GetExecutionMode() string {
+	if x != nil {
+		return x.ExecutionMode
+	}
+	return ""
+}
+
+func (x *CreateTourRequest) GetProximityRadius() float64 {
+	if x != nil {
+		return x.ProximityRadius
+	}
+	return 0
+}
+
 type TourResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	Tour          *Tour                  `protobuf:"bytes,3,opt,name=tour,proto3" json:"tour,omitempty"`
+	Violations    []*Violation           `protobuf:"bytes,4,rep,name=violations,proto3" json:"violations,omitempty"` // Everything that blocked the request, e.g. publish requirements
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -158,26 +228,92 @@ func (x *TourResponse) GetTour() *Tour {
 	return nil
 }
 
-type Tour struct {
+func (x *TourResponse) GetViolations() []*Violation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+type Violation struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	GuideId       string                 `protobuf:"bytes,2,opt,name=guideId,proto3" json:"guideId,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Difficulty    string                 `protobuf:"bytes,5,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
-	Tags          []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
-	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"` // "draft", "published"
-	Price         float64                `protobuf:"fixed64,8,opt,name=price,proto3" json:"price,omitempty"`
-	IsPublished   bool                   `protobuf:"varint,9,opt,name=isPublished,proto3" json:"isPublished,omitempty"`
-	PublishedAt   string                 `protobuf:"bytes,10,opt,name=publishedAt,proto3" json:"publishedAt,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,11,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *Violation) Reset() {
+	*x = Violation{}
+	mi := &file_tour_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Violation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Violation) ProtoMessage() {}
+
+func (x *Violation) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Violation.ProtoReflect.Descriptor instead.
+func (*Violation) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Violation) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Violation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type Tour struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	GuideId         string                 `protobuf:"bytes,2,opt,name=guideId,proto3" json:"guideId,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Difficulty      string                 `protobuf:"bytes,5,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Tags            []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Status          string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"` // "draft", "published", "archived"
+	Price           float64                `protobuf:"fixed64,8,opt,name=price,proto3" json:"price,omitempty"`
+	IsPublished     bool                   `protobuf:"varint,9,opt,name=isPublished,proto3" json:"isPublished,omitempty"`
+	PublishedAt     string                 `protobuf:"bytes,10,opt,name=publishedAt,proto3" json:"publishedAt,omitempty"`
+	CreatedAt       string                 `protobuf:"bytes,11,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	ArchivedAt      string                 `protobuf:"bytes,12,opt,name=archivedAt,proto3" json:"archivedAt,omitempty"`
+	LengthKm        float64                `protobuf:"fixed64,13,opt,name=lengthKm,proto3" json:"lengthKm,omitempty"` // Path length through the keypoints in order
+	TransportTimes  []*TransportTime       `protobuf:"bytes,14,rep,name=transportTimes,proto3" json:"transportTimes,omitempty"`
+	AverageRating   float64                `protobuf:"fixed64,15,opt,name=averageRating,proto3" json:"averageRating,omitempty"` // 0 until the tour is reviewed
+	ReviewCount     int32                  `protobuf:"varint,16,opt,name=reviewCount,proto3" json:"reviewCount,omitempty"`
+	ExecutionMode   string                 `protobuf:"bytes,17,opt,name=executionMode,proto3" json:"executionMode,omitempty"`       // "free_roam": keypoints in any order, "sequential": only the next one by order
+	ProximityRadius float64                `protobuf:"fixed64,18,opt,name=proximityRadius,proto3" json:"proximityRadius,omitempty"` // Meters within which keypoints count as reached, unless they set their own
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
 func (x *Tour) Reset() {
 	*x = Tour{}
-	mi := &file_tour_proto_msgTypes[2]
+	mi := &file_tour_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -189,7 +325,7 @@ func (x *Tour) String() string {
 func (*Tour) ProtoMessage() {}
 
 func (x *Tour) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[2]
+	mi := &file_tour_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -202,7 +338,7 @@ func (x *Tour) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Tour.ProtoReflect.Descriptor instead.
 func (*Tour) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{2}
+	return file_tour_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Tour) GetId() string {
@@ -282,17 +418,140 @@ func (x *Tour) GetCreatedAt() string {
 	return ""
 }
 
-type GetToursRequest struct {
+func (x *Tour) GetArchivedAt() string {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return ""
+}
+
+func (x *Tour) GetLengthKm() float64 {
+	if x != nil {
+		return x.LengthKm
+	}
+	return 0
+}
+
+func (x *Tour) GetTransportTimes() []*TransportTime {
+	if x != nil {
+		return x.TransportTimes
+	}
+	return nil
+}
+
+func (x *Tour) GetAverageRating() float64 {
+	if x != nil {
+		return x.AverageRating
+	}
+	return 0
+}
+
+func (x *Tour) GetReviewCount() int32 {
+	if x != nil {
+		return x.ReviewCount
+	}
+	return 0
+}
+
+func (x *Tour) GetExecutionMode() string {
+	if x != nil {
+		return x.ExecutionMode
+	}
+	return ""
+}
+
+func (x *Tour) GetProximityRadius() float64 {
+	if x != nil {
+		return x.ProximityRadius
+	}
+	return 0
+}
+
+type TransportTime struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PublishedOnly bool                   `protobuf:"varint,1,opt,name=publishedOnly,proto3" json:"publishedOnly,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"` // Optional: filter by guide
+	Mode          string                 `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"` // "walking", "bicycle", "car"
+	Minutes       int32                  `protobuf:"varint,2,opt,name=minutes,proto3" json:"minutes,omitempty"`
+	Suggested     bool                   `protobuf:"varint,3,opt,name=suggested,proto3" json:"suggested,omitempty"` // Derived from the tour length rather than set by the guide
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransportTime) Reset() {
+	*x = TransportTime{}
+	mi := &file_tour_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransportTime) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransportTime) ProtoMessage() {}
+
+func (x *TransportTime) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransportTime.ProtoReflect.Descriptor instead.
+func (*TransportTime) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TransportTime) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *TransportTime) GetMinutes() int32 {
+	if x != nil {
+		return x.Minutes
+	}
+	return 0
+}
+
+func (x *TransportTime) GetSuggested() bool {
+	if x != nil {
+		return x.Suggested
+	}
+	return false
+}
+
+type GetToursRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	PublishedOnly      bool                   `protobuf:"varint,1,opt,name=publishedOnly,proto3" json:"publishedOnly,omitempty"`
+	UserId             string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`                          // Optional: filter by guide
+	MaxDurationMinutes int32                  `protobuf:"varint,3,opt,name=maxDurationMinutes,proto3" json:"maxDurationMinutes,omitempty"` // Optional: published tours that take at most this long
+	TransportMode      string                 `protobuf:"bytes,4,opt,name=transportMode,proto3" json:"transportMode,omitempty"`            // Optional: mode maxDurationMinutes applies to, any mode when empty
+	// Paging and filters below apply to published listings
+	PageSize      int32    `protobuf:"varint,5,opt,name=pageSize,proto3" json:"pageSize,omitempty"`  // Defaults to 20, at most 100
+	PageToken     string   `protobuf:"bytes,6,opt,name=pageToken,proto3" json:"pageToken,omitempty"` // nextPageToken of the previous page, issued for the same sort
+	Sort          TourSort `protobuf:"varint,7,opt,name=sort,proto3,enum=tour.TourSort" json:"sort,omitempty"`
+	Difficulty    string   `protobuf:"bytes,8,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Tags          []string `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	MatchAllTags  bool     `protobuf:"varint,10,opt,name=matchAllTags,proto3" json:"matchAllTags,omitempty"` // Require every tag instead of any of them
+	MinPrice      *float64 `protobuf:"fixed64,11,opt,name=minPrice,proto3,oneof" json:"minPrice,omitempty"`
+	MaxPrice      *float64 `protobuf:"fixed64,12,opt,name=maxPrice,proto3,oneof" json:"maxPrice,omitempty"`
+	GuideId       string   `protobuf:"bytes,13,opt,name=guideId,proto3" json:"guideId,omitempty"`
+	CreatedAfter  string   `protobuf:"bytes,14,opt,name=createdAfter,proto3" json:"createdAfter,omitempty"`   // RFC3339
+	CreatedBefore string   `protobuf:"bytes,15,opt,name=createdBefore,proto3" json:"createdBefore,omitempty"` // RFC3339
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetToursRequest) Reset() {
 	*x = GetToursRequest{}
-	mi := &file_tour_proto_msgTypes[3]
+	mi := &file_tour_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -304,7 +563,7 @@ func (x *GetToursRequest) String() string {
 func (*GetToursRequest) ProtoMessage() {}
 
 func (x *GetToursRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[3]
+	mi := &file_tour_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -317,7 +576,7 @@ func (x *GetToursRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetToursRequest.ProtoReflect.Descriptor instead.
 func (*GetToursRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{3}
+	return file_tour_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetToursRequest) GetPublishedOnly() bool {
@@ -334,6 +593,97 @@ func (x *GetToursRequest) GetUserId() string {
 	return ""
 }
 
+func (x *GetToursRequest) GetMaxDurationMinutes() int32 {
+	if x != nil {
+		return x.MaxDurationMinutes
+	}
+	return 0
+}
+
+func (x *GetToursRequest) GetTransportMode() string {
+	if x != nil {
+		return x.TransportMode
+	}
+	return ""
+}
+
+func (x *GetToursRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetToursRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetToursRequest) GetSort() TourSort {
+	if x != nil {
+		return x.Sort
+	}
+	return TourSort_TOUR_SORT_NEWEST
+}
+
+func (x *GetToursRequest) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *GetToursRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *GetToursRequest) GetMatchAllTags() bool {
+	if x != nil {
+		return x.MatchAllTags
+	}
+	return false
+}
+
+func (x *GetToursRequest) GetMinPrice() float64 {
+	if x != nil && x.MinPrice != nil {
+		return *x.MinPrice
+	}
+	return 0
+}
+
+func (x *GetToursRequest) GetMaxPrice() float64 {
+	if x != nil && x.MaxPrice != nil {
+		return *x.MaxPrice
+	}
+	return 0
+}
+
+func (x *GetToursRequest) GetGuideId() string {
+	if x != nil {
+		return x.GuideId
+	}
+	return ""
+}
+
+func (x *GetToursRequest) GetCreatedAfter() string {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return ""
+}
+
+func (x *GetToursRequest) GetCreatedBefore() string {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return ""
+}
+
 type GetMyToursRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	GuideId       string                 `protobuf:"bytes,1,opt,name=guideId,proto3" json:"guideId,omitempty"`
@@ -343,7 +693,7 @@ type GetMyToursRequest struct {
 
 func (x *GetMyToursRequest) Reset() {
 	*x = GetMyToursRequest{}
-	mi := &file_tour_proto_msgTypes[4]
+	mi := &file_tour_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -355,7 +705,7 @@ func (x *GetMyToursRequest) String() string {
 func (*GetMyToursRequest) ProtoMessage() {}
 
 func (x *GetMyToursRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[4]
+	mi := &file_tour_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -368,7 +718,7 @@ func (x *GetMyToursRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMyToursRequest.ProtoReflect.Descriptor instead.
 func (*GetMyToursRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{4}
+	return file_tour_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetMyToursRequest) GetGuideId() string {
@@ -388,7 +738,7 @@ type GetTourByIdRequest struct {
 
 func (x *GetTourByIdRequest) Reset() {
 	*x = GetTourByIdRequest{}
-	mi := &file_tour_proto_msgTypes[5]
+	mi := &file_tour_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -400,7 +750,7 @@ func (x *GetTourByIdRequest) String() string {
 func (*GetTourByIdRequest) ProtoMessage() {}
 
 func (x *GetTourByIdRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[5]
+	mi := &file_tour_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -413,7 +763,7 @@ func (x *GetTourByIdRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTourByIdRequest.ProtoReflect.Descriptor instead.
 func (*GetTourByIdRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{5}
+	return file_tour_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetTourByIdRequest) GetTourId() string {
@@ -435,13 +785,14 @@ type ToursResponse struct {
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	Tours         []*Tour                `protobuf:"bytes,3,rep,name=tours,proto3" json:"tours,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,4,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // Empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ToursResponse) Reset() {
 	*x = ToursResponse{}
-	mi := &file_tour_proto_msgTypes[6]
+	mi := &file_tour_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -453,7 +804,7 @@ func (x *ToursResponse) String() string {
 func (*ToursResponse) ProtoMessage() {}
 
 func (x *ToursResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[6]
+	mi := &file_tour_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -466,7 +817,7 @@ func (x *ToursResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToursResponse.ProtoReflect.Descriptor instead.
 func (*ToursResponse) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{6}
+	return file_tour_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ToursResponse) GetSuccess() bool {
@@ -490,6 +841,13 @@ func (x *ToursResponse) GetTours() []*Tour {
 	return nil
 }
 
+func (x *ToursResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type PublishTourRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TourId        string                 `protobuf:"bytes,1,opt,name=tourId,proto3" json:"tourId,omitempty"`
@@ -501,7 +859,7 @@ type PublishTourRequest struct {
 
 func (x *PublishTourRequest) Reset() {
 	*x = PublishTourRequest{}
-	mi := &file_tour_proto_msgTypes[7]
+	mi := &file_tour_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -513,7 +871,7 @@ func (x *PublishTourRequest) String() string {
 func (*PublishTourRequest) ProtoMessage() {}
 
 func (x *PublishTourRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[7]
+	mi := &file_tour_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -526,7 +884,7 @@ func (x *PublishTourRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublishTourRequest.ProtoReflect.Descriptor instead.
 func (*PublishTourRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{7}
+	return file_tour_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *PublishTourRequest) GetTourId() string {
@@ -550,36 +908,38 @@ func (x *PublishTourRequest) GetPrice() float64 {
 	return 0
 }
 
-// ============ KeyPoint Operations ============
-type AddKeyPointRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TourId        string                 `protobuf:"bytes,1,opt,name=tourId,proto3" json:"tourId,omitempty"`
-	GuideId       string                 `protobuf:"bytes,2,opt,name=guideId,proto3" json:"guideId,omitempty"` // For authorization
-	Latitude      float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude     float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
-	Image         string                 `protobuf:"bytes,7,opt,name=image,proto3" json:"image,omitempty"`
-	Order         int32                  `protobuf:"varint,8,opt,name=order,proto3" json:"order,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *AddKeyPointRequest) Reset() {
-	*x = AddKeyPointRequest{}
-	mi := &file_tour_proto_msgTypes[8]
+type UpdateTourRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TourId          string                 `protobuf:"bytes,1,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	GuideId         string                 `protobuf:"bytes,2,opt,name=guideId,proto3" json:"guideId,omitempty"` // For authorization
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Difficulty      string                 `protobuf:"bytes,5,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Tags            []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Price           float64                `protobuf:"fixed64,7,opt,name=price,proto3" json:"price,omitempty"`                      // Only for published tours; items already in carts keep their price
+	UpdateMask      *fieldmaskpb.FieldMask `protobuf:"bytes,8,opt,name=updateMask,proto3" json:"updateMask,omitempty"`              // Fields to change: name, description, difficulty, tags, price, transportTimes, executionMode, proximityRadius
+	TransportTimes  []*TransportTime       `protobuf:"bytes,9,rep,name=transportTimes,proto3" json:"transportTimes,omitempty"`      // Guide estimates; modes left out get suggested times
+	ExecutionMode   string                 `protobuf:"bytes,10,opt,name=executionMode,proto3" json:"executionMode,omitempty"`       // Applies to executions started afterwards
+	ProximityRadius float64                `protobuf:"fixed64,11,opt,name=proximityRadius,proto3" json:"proximityRadius,omitempty"` // Applies to executions started afterwards; 0 restores the default
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateTourRequest) Reset() {
+	*x = UpdateTourRequest{}
+	mi := &file_tour_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddKeyPointRequest) String() string {
+func (x *UpdateTourRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddKeyPointRequest) ProtoMessage() {}
+func (*UpdateTourRequest) ProtoMessage() {}
 
-func (x *AddKeyPointRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[8]
+func (x *UpdateTourRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -590,91 +950,113 @@ func (x *AddKeyPointRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddKeyPointRequest.ProtoReflect.Descriptor instead.
-func (*AddKeyPointRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use UpdateTourRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTourRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *AddKeyPointRequest) GetTourId() string {
+func (x *UpdateTourRequest) GetTourId() string {
 	if x != nil {
 		return x.TourId
 	}
 	return ""
 }
 
-func (x *AddKeyPointRequest) GetGuideId() string {
+func (x *UpdateTourRequest) GetGuideId() string {
 	if x != nil {
 		return x.GuideId
 	}
 	return ""
 }
 
-func (x *AddKeyPointRequest) GetLatitude() float64 {
+func (x *UpdateTourRequest) GetName() string {
 	if x != nil {
-		return x.Latitude
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *AddKeyPointRequest) GetLongitude() float64 {
+func (x *UpdateTourRequest) GetDescription() string {
 	if x != nil {
-		return x.Longitude
+		return x.Description
 	}
-	return 0
+	return ""
 }
 
-func (x *AddKeyPointRequest) GetName() string {
+func (x *UpdateTourRequest) GetDifficulty() string {
 	if x != nil {
-		return x.Name
+		return x.Difficulty
 	}
 	return ""
 }
 
-func (x *AddKeyPointRequest) GetDescription() string {
+func (x *UpdateTourRequest) GetTags() []string {
 	if x != nil {
-		return x.Description
+		return x.Tags
 	}
-	return ""
+	return nil
 }
 
-func (x *AddKeyPointRequest) GetImage() string {
+func (x *UpdateTourRequest) GetPrice() float64 {
 	if x != nil {
-		return x.Image
+		return x.Price
+	}
+	return 0
+}
+
+func (x *UpdateTourRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateTourRequest) GetTransportTimes() []*TransportTime {
+	if x != nil {
+		return x.TransportTimes
+	}
+	return nil
+}
+
+func (x *UpdateTourRequest) GetExecutionMode() string {
+	if x != nil {
+		return x.ExecutionMode
 	}
 	return ""
 }
 
-func (x *AddKeyPointRequest) GetOrder() int32 {
+func (x *UpdateTourRequest) GetProximityRadius() float64 {
 	if x != nil {
-		return x.Order
+		return x.ProximityRadius
 	}
 	return 0
 }
 
-type KeyPointResponse struct {
+// Archived tours leave the catalogue and cannot be added to carts, but
+// tourists who already bought them can still execute them.
+type ArchiveTourRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	KeyPoint      *KeyPoint              `protobuf:"bytes,3,opt,name=keyPoint,proto3" json:"keyPoint,omitempty"`
+	TourId        string                 `protobuf:"bytes,1,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	GuideId       string                 `protobuf:"bytes,2,opt,name=guideId,proto3" json:"guideId,omitempty"` // For authorization
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *KeyPointResponse) Reset() {
-	*x = KeyPointResponse{}
-	mi := &file_tour_proto_msgTypes[9]
+func (x *ArchiveTourRequest) Reset() {
+	*x = ArchiveTourRequest{}
+	mi := &file_tour_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeyPointResponse) String() string {
+func (x *ArchiveTourRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyPointResponse) ProtoMessage() {}
+func (*ArchiveTourRequest) ProtoMessage() {}
 
-func (x *KeyPointResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[9]
+func (x *ArchiveTourRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -685,61 +1067,48 @@ func (x *KeyPointResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyPointResponse.ProtoReflect.Descriptor instead.
-func (*KeyPointResponse) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *KeyPointResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
+// Deprecated: Use ArchiveTourRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveTourRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *KeyPointResponse) GetMessage() string {
+func (x *ArchiveTourRequest) GetTourId() string {
 	if x != nil {
-		return x.Message
+		return x.TourId
 	}
 	return ""
 }
 
-func (x *KeyPointResponse) GetKeyPoint() *KeyPoint {
+func (x *ArchiveTourRequest) GetGuideId() string {
 	if x != nil {
-		return x.KeyPoint
+		return x.GuideId
 	}
-	return nil
+	return ""
 }
 
-type KeyPoint struct {
+type ReactivateTourRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	TourId        string                 `protobuf:"bytes,2,opt,name=tourId,proto3" json:"tourId,omitempty"`
-	Latitude      float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude     float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
-	Image         string                 `protobuf:"bytes,7,opt,name=image,proto3" json:"image,omitempty"`
-	Order         int32                  `protobuf:"varint,8,opt,name=order,proto3" json:"order,omitempty"`
+	TourId        string                 `protobuf:"bytes,1,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	GuideId       string                 `protobuf:"bytes,2,opt,name=guideId,proto3" json:"guideId,omitempty"` // For authorization
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *KeyPoint) Reset() {
-	*x = KeyPoint{}
-	mi := &file_tour_proto_msgTypes[10]
+func (x *ReactivateTourRequest) Reset() {
+	*x = ReactivateTourRequest{}
+	mi := &file_tour_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeyPoint) String() string {
+func (x *ReactivateTourRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyPoint) ProtoMessage() {}
+func (*ReactivateTourRequest) ProtoMessage() {}
 
-func (x *KeyPoint) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[10]
+func (x *ReactivateTourRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -750,90 +1119,50 @@ func (x *KeyPoint) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyPoint.ProtoReflect.Descriptor instead.
-func (*KeyPoint) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *KeyPoint) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use ReactivateTourRequest.ProtoReflect.Descriptor instead.
+func (*ReactivateTourRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *KeyPoint) GetTourId() string {
+func (x *ReactivateTourRequest) GetTourId() string {
 	if x != nil {
 		return x.TourId
 	}
 	return ""
 }
 
-func (x *KeyPoint) GetLatitude() float64 {
-	if x != nil {
-		return x.Latitude
-	}
-	return 0
-}
-
-func (x *KeyPoint) GetLongitude() float64 {
-	if x != nil {
-		return x.Longitude
-	}
-	return 0
-}
-
-func (x *KeyPoint) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *KeyPoint) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
-}
-
-func (x *KeyPoint) GetImage() string {
+func (x *ReactivateTourRequest) GetGuideId() string {
 	if x != nil {
-		return x.Image
+		return x.GuideId
 	}
 	return ""
 }
 
-func (x *KeyPoint) GetOrder() int32 {
-	if x != nil {
-		return x.Order
-	}
-	return 0
-}
-
-type GetKeyPointsRequest struct {
+type SearchToursNearbyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TourId        string                 `protobuf:"bytes,1,opt,name=tourId,proto3" json:"tourId,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"` // To check if user purchased tour
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	RadiusKm      float64                `protobuf:"fixed64,3,opt,name=radiusKm,proto3" json:"radiusKm,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"` // Defaults to 20, at most 100
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetKeyPointsRequest) Reset() {
-	*x = GetKeyPointsRequest{}
-	mi := &file_tour_proto_msgTypes[11]
+func (x *SearchToursNearbyRequest) Reset() {
+	*x = SearchToursNearbyRequest{}
+	mi := &file_tour_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetKeyPointsRequest) String() string {
+func (x *SearchToursNearbyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetKeyPointsRequest) ProtoMessage() {}
+func (*SearchToursNearbyRequest) ProtoMessage() {}
 
-func (x *GetKeyPointsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[11]
+func (x *SearchToursNearbyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -844,50 +1173,63 @@ func (x *GetKeyPointsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetKeyPointsRequest.ProtoReflect.Descriptor instead.
-func (*GetKeyPointsRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use SearchToursNearbyRequest.ProtoReflect.Descriptor instead.
+func (*SearchToursNearbyRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *GetKeyPointsRequest) GetTourId() string {
+func (x *SearchToursNearbyRequest) GetLatitude() float64 {
 	if x != nil {
-		return x.TourId
+		return x.Latitude
 	}
-	return ""
+	return 0
 }
 
-func (x *GetKeyPointsRequest) GetUserId() string {
+func (x *SearchToursNearbyRequest) GetLongitude() float64 {
 	if x != nil {
-		return x.UserId
+		return x.Longitude
 	}
-	return ""
+	return 0
 }
 
-type KeyPointsResponse struct {
+func (x *SearchToursNearbyRequest) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+func (x *SearchToursNearbyRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type NearbyToursResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	KeyPoints     []*KeyPoint            `protobuf:"bytes,3,rep,name=keyPoints,proto3" json:"keyPoints,omitempty"`
-	IsPurchased   bool                   `protobuf:"varint,4,opt,name=isPurchased,proto3" json:"isPurchased,omitempty"` // Whether user can see all keypoints
+	Tours         []*NearbyTour          `protobuf:"bytes,3,rep,name=tours,proto3" json:"tours,omitempty"` // Nearest first
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *KeyPointsResponse) Reset() {
-	*x = KeyPointsResponse{}
-	mi := &file_tour_proto_msgTypes[12]
+func (x *NearbyToursResponse) Reset() {
+	*x = NearbyToursResponse{}
+	mi := &file_tour_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeyPointsResponse) String() string {
+func (x *NearbyToursResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyPointsResponse) ProtoMessage() {}
+func (*NearbyToursResponse) ProtoMessage() {}
 
-func (x *KeyPointsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[12]
+func (x *NearbyToursResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -898,68 +1240,55 @@ func (x *KeyPointsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyPointsResponse.ProtoReflect.Descriptor instead.
-func (*KeyPointsResponse) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use NearbyToursResponse.ProtoReflect.Descriptor instead.
+func (*NearbyToursResponse) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *KeyPointsResponse) GetSuccess() bool {
+func (x *NearbyToursResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *KeyPointsResponse) GetMessage() string {
+func (x *NearbyToursResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *KeyPointsResponse) GetKeyPoints() []*KeyPoint {
+func (x *NearbyToursResponse) GetTours() []*NearbyTour {
 	if x != nil {
-		return x.KeyPoints
+		return x.Tours
 	}
 	return nil
 }
 
-func (x *KeyPointsResponse) GetIsPurchased() bool {
-	if x != nil {
-		return x.IsPurchased
-	}
-	return false
-}
-
-type UpdateKeyPointRequest struct {
+type NearbyTour struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	KeyPointId    string                 `protobuf:"bytes,1,opt,name=keyPointId,proto3" json:"keyPointId,omitempty"`
-	TourId        string                 `protobuf:"bytes,2,opt,name=tourId,proto3" json:"tourId,omitempty"`
-	GuideId       string                 `protobuf:"bytes,3,opt,name=guideId,proto3" json:"guideId,omitempty"` // For authorization
-	Latitude      float64                `protobuf:"fixed64,4,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude     float64                `protobuf:"fixed64,5,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	Name          string                 `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
-	Image         string                 `protobuf:"bytes,8,opt,name=image,proto3" json:"image,omitempty"`
+	Tour          *Tour                  `protobuf:"bytes,1,opt,name=tour,proto3" json:"tour,omitempty"`
+	DistanceKm    float64                `protobuf:"fixed64,2,opt,name=distanceKm,proto3" json:"distanceKm,omitempty"` // Distance to the first keypoint
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateKeyPointRequest) Reset() {
-	*x = UpdateKeyPointRequest{}
-	mi := &file_tour_proto_msgTypes[13]
+func (x *NearbyTour) Reset() {
+	*x = NearbyTour{}
+	mi := &file_tour_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateKeyPointRequest) String() string {
+func (x *NearbyTour) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateKeyPointRequest) ProtoMessage() {}
+func (*NearbyTour) ProtoMessage() {}
 
-func (x *UpdateKeyPointRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[13]
+func (x *NearbyTour) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -970,91 +1299,112 @@ func (x *UpdateKeyPointRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateKeyPointRequest.ProtoReflect.Descriptor instead.
-func (*UpdateKeyPointRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use NearbyTour.ProtoReflect.Descriptor instead.
+func (*NearbyTour) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *UpdateKeyPointRequest) GetKeyPointId() string {
+func (x *NearbyTour) GetTour() *Tour {
 	if x != nil {
-		return x.KeyPointId
+		return x.Tour
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateKeyPointRequest) GetTourId() string {
+func (x *NearbyTour) GetDistanceKm() float64 {
 	if x != nil {
-		return x.TourId
+		return x.DistanceKm
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateKeyPointRequest) GetGuideId() string {
-	if x != nil {
-		return x.GuideId
-	}
-	return ""
+// Full-text search over the names, descriptions and tags of published tours
+// and the names and descriptions of their keypoints.
+type SearchToursRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=pageSize,proto3" json:"pageSize,omitempty"`  // Defaults to 20, at most 100
+	PageToken     string                 `protobuf:"bytes,3,opt,name=pageToken,proto3" json:"pageToken,omitempty"` // nextPageToken of the previous page, issued for the same query
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateKeyPointRequest) GetLatitude() float64 {
-	if x != nil {
-		return x.Latitude
-	}
-	return 0
+func (x *SearchToursRequest) Reset() {
+	*x = SearchToursRequest{}
+	mi := &file_tour_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateKeyPointRequest) GetLongitude() float64 {
+func (x *SearchToursRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchToursRequest) ProtoMessage() {}
+
+func (x *SearchToursRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[16]
 	if x != nil {
-		return x.Longitude
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateKeyPointRequest) GetName() string {
+// Deprecated: Use SearchToursRequest.ProtoReflect.Descriptor instead.
+func (*SearchToursRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SearchToursRequest) GetQuery() string {
 	if x != nil {
-		return x.Name
+		return x.Query
 	}
 	return ""
 }
 
-func (x *UpdateKeyPointRequest) GetDescription() string {
+func (x *SearchToursRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.Description
+		return x.PageSize
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateKeyPointRequest) GetImage() string {
+func (x *SearchToursRequest) GetPageToken() string {
 	if x != nil {
-		return x.Image
+		return x.PageToken
 	}
 	return ""
 }
 
-type DeleteKeyPointRequest struct {
+type SearchToursResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	KeyPointId    string                 `protobuf:"bytes,1,opt,name=keyPointId,proto3" json:"keyPointId,omitempty"`
-	TourId        string                 `protobuf:"bytes,2,opt,name=tourId,proto3" json:"tourId,omitempty"`
-	GuideId       string                 `protobuf:"bytes,3,opt,name=guideId,proto3" json:"guideId,omitempty"` // For authorization
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Results       []*TourSearchResult    `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`             // Most relevant first
+	NextPageToken string                 `protobuf:"bytes,4,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // Empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteKeyPointRequest) Reset() {
-	*x = DeleteKeyPointRequest{}
-	mi := &file_tour_proto_msgTypes[14]
+func (x *SearchToursResponse) Reset() {
+	*x = SearchToursResponse{}
+	mi := &file_tour_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteKeyPointRequest) String() string {
+func (x *SearchToursResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteKeyPointRequest) ProtoMessage() {}
+func (*SearchToursResponse) ProtoMessage() {}
 
-func (x *DeleteKeyPointRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[14]
+func (x *SearchToursResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1065,55 +1415,63 @@ func (x *DeleteKeyPointRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteKeyPointRequest.ProtoReflect.Descriptor instead.
-func (*DeleteKeyPointRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use SearchToursResponse.ProtoReflect.Descriptor instead.
+func (*SearchToursResponse) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *DeleteKeyPointRequest) GetKeyPointId() string {
+func (x *SearchToursResponse) GetSuccess() bool {
 	if x != nil {
-		return x.KeyPointId
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *DeleteKeyPointRequest) GetTourId() string {
+func (x *SearchToursResponse) GetMessage() string {
 	if x != nil {
-		return x.TourId
+		return x.Message
 	}
 	return ""
 }
 
-func (x *DeleteKeyPointRequest) GetGuideId() string {
+func (x *SearchToursResponse) GetResults() []*TourSearchResult {
 	if x != nil {
-		return x.GuideId
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchToursResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
 	}
 	return ""
 }
 
-type DeleteKeyPointResponse struct {
+type TourSearchResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Tour          *Tour                  `protobuf:"bytes,1,opt,name=tour,proto3" json:"tour,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"` // Relevance, only comparable within one query
+	Snippets      []*Snippet             `protobuf:"bytes,3,rep,name=snippets,proto3" json:"snippets,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteKeyPointResponse) Reset() {
-	*x = DeleteKeyPointResponse{}
-	mi := &file_tour_proto_msgTypes[15]
+func (x *TourSearchResult) Reset() {
+	*x = TourSearchResult{}
+	mi := &file_tour_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteKeyPointResponse) String() string {
+func (x *TourSearchResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteKeyPointResponse) ProtoMessage() {}
+func (*TourSearchResult) ProtoMessage() {}
 
-func (x *DeleteKeyPointResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[15]
+func (x *TourSearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1124,50 +1482,56 @@ func (x *DeleteKeyPointResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteKeyPointResponse.ProtoReflect.Descriptor instead.
-func (*DeleteKeyPointResponse) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use TourSearchResult.ProtoReflect.Descriptor instead.
+func (*TourSearchResult) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *DeleteKeyPointResponse) GetSuccess() bool {
+func (x *TourSearchResult) GetTour() *Tour {
 	if x != nil {
-		return x.Success
+		return x.Tour
 	}
-	return false
+	return nil
 }
 
-func (x *DeleteKeyPointResponse) GetMessage() string {
+func (x *TourSearchResult) GetScore() float64 {
 	if x != nil {
-		return x.Message
+		return x.Score
 	}
-	return ""
+	return 0
 }
 
-// ============ Position Simulator ============
-type UpdatePositionRequest struct {
+func (x *TourSearchResult) GetSnippets() []*Snippet {
+	if x != nil {
+		return x.Snippets
+	}
+	return nil
+}
+
+type Snippet struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TouristId     string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
-	Latitude      float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude     float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"` // "name", "description", "tags", "keyPoints.name", "keyPoints.description"
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Highlights    []*Highlight           `protobuf:"bytes,3,rep,name=highlights,proto3" json:"highlights,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdatePositionRequest) Reset() {
-	*x = UpdatePositionRequest{}
-	mi := &file_tour_proto_msgTypes[16]
+func (x *Snippet) Reset() {
+	*x = Snippet{}
+	mi := &file_tour_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdatePositionRequest) String() string {
+func (x *Snippet) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdatePositionRequest) ProtoMessage() {}
+func (*Snippet) ProtoMessage() {}
 
-func (x *UpdatePositionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[16]
+func (x *Snippet) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1178,54 +1542,56 @@ func (x *UpdatePositionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdatePositionRequest.ProtoReflect.Descriptor instead.
-func (*UpdatePositionRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use Snippet.ProtoReflect.Descriptor instead.
+func (*Snippet) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *UpdatePositionRequest) GetTouristId() string {
+func (x *Snippet) GetField() string {
 	if x != nil {
-		return x.TouristId
+		return x.Field
 	}
 	return ""
 }
 
-func (x *UpdatePositionRequest) GetLatitude() float64 {
+func (x *Snippet) GetText() string {
 	if x != nil {
-		return x.Latitude
+		return x.Text
 	}
-	return 0
+	return ""
 }
 
-func (x *UpdatePositionRequest) GetLongitude() float64 {
+func (x *Snippet) GetHighlights() []*Highlight {
 	if x != nil {
-		return x.Longitude
+		return x.Highlights
 	}
-	return 0
+	return nil
 }
 
-type GetPositionRequest struct {
+// Highlight marks a matched word by character offsets into the snippet text.
+type Highlight struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TouristId     string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	Start         int32                  `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           int32                  `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"` // Exclusive
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPositionRequest) Reset() {
-	*x = GetPositionRequest{}
-	mi := &file_tour_proto_msgTypes[17]
+func (x *Highlight) Reset() {
+	*x = Highlight{}
+	mi := &file_tour_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPositionRequest) String() string {
+func (x *Highlight) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPositionRequest) ProtoMessage() {}
+func (*Highlight) ProtoMessage() {}
 
-func (x *GetPositionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[17]
+func (x *Highlight) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1236,42 +1602,56 @@ func (x *GetPositionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPositionRequest.ProtoReflect.Descriptor instead.
-func (*GetPositionRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use Highlight.ProtoReflect.Descriptor instead.
+func (*Highlight) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *GetPositionRequest) GetTouristId() string {
+func (x *Highlight) GetStart() int32 {
 	if x != nil {
-		return x.TouristId
+		return x.Start
 	}
-	return ""
+	return 0
 }
 
-type PositionResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Position      *Position              `protobuf:"bytes,3,opt,name=position,proto3" json:"position,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Highlight) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
 }
 
-func (x *PositionResponse) Reset() {
-	*x = PositionResponse{}
-	mi := &file_tour_proto_msgTypes[18]
+// ============ KeyPoint Operations ============
+type AddKeyPointRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TourId          string                 `protobuf:"bytes,1,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	GuideId         string                 `protobuf:"bytes,2,opt,name=guideId,proto3" json:"guideId,omitempty"` // For authorization
+	Latitude        float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude       float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Name            string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Image           string                 `protobuf:"bytes,7,opt,name=image,proto3" json:"image,omitempty"`
+	Order           int32                  `protobuf:"varint,8,opt,name=order,proto3" json:"order,omitempty"`                      // Position from 1; 0 or past the end appends, otherwise later keypoints move back
+	ProximityRadius float64                `protobuf:"fixed64,9,opt,name=proximityRadius,proto3" json:"proximityRadius,omitempty"` // Meters, 5 to 2000; 0 uses the tour's radius
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AddKeyPointRequest) Reset() {
+	*x = AddKeyPointRequest{}
+	mi := &file_tour_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PositionResponse) String() string {
+func (x *AddKeyPointRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PositionResponse) ProtoMessage() {}
+func (*AddKeyPointRequest) ProtoMessage() {}
 
-func (x *PositionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[18]
+func (x *AddKeyPointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1282,124 +1662,2438 @@ func (x *PositionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PositionResponse.ProtoReflect.Descriptor instead.
-func (*PositionResponse) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use AddKeyPointRequest.ProtoReflect.Descriptor instead.
+func (*AddKeyPointRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *PositionResponse) GetSuccess() bool {
+func (x *AddKeyPointRequest) GetTourId() string {
 	if x != nil {
-		return x.Success
+		return x.TourId
 	}
-	return false
+	return ""
 }
 
-func (x *PositionResponse) GetMessage() string {
+func (x *AddKeyPointRequest) GetGuideId() string {
 	if x != nil {
-		return x.Message
+		return x.GuideId
 	}
 	return ""
 }
 
-func (x *PositionResponse) GetPosition() *Position {
+func (x *AddKeyPointRequest) GetLatitude() float64 {
 	if x != nil {
-		return x.Position
+		return x.Latitude
 	}
-	return nil
+	return 0
 }
 
-type Position struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TouristId     string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
-	Latitude      float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude     float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,4,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *AddKeyPointRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
 }
 
-func (x *Position) Reset() {
-	*x = Position{}
-	mi := &file_tour_proto_msgTypes[19]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *AddKeyPointRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *Position) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *AddKeyPointRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
 }
 
-func (*Position) ProtoMessage() {}
-
-func (x *Position) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[19]
+func (x *AddKeyPointRequest) GetImage() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Image
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use Position.ProtoReflect.Descriptor instead.
-func (*Position) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{19}
+func (x *AddKeyPointRequest) GetOrder() int32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+func (x *AddKeyPointRequest) GetProximityRadius() float64 {
+	if x != nil {
+		return x.ProximityRadius
+	}
+	return 0
+}
+
+type KeyPointResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	KeyPoint      *KeyPoint              `protobuf:"bytes,3,opt,name=keyPoint,proto3" json:"keyPoint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyPointResponse) Reset() {
+	*x = KeyPointResponse{}
+	mi := &file_tour_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyPointResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyPointResponse) ProtoMessage() {}
+
+func (x *KeyPointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyPointResponse.ProtoReflect.Descriptor instead.
+func (*KeyPointResponse) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *KeyPointResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *KeyPointResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *KeyPointResponse) GetKeyPoint() *KeyPoint {
+	if x != nil {
+		return x.KeyPoint
+	}
+	return nil
+}
+
+type KeyPoint struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Id                       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TourId                   string                 `protobuf:"bytes,2,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	Latitude                 float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude                float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Name                     string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Description              string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Image                    string                 `protobuf:"bytes,7,opt,name=image,proto3" json:"image,omitempty"`
+	Order                    int32                  `protobuf:"varint,8,opt,name=order,proto3" json:"order,omitempty"`                                         // Position in the tour, numbered from 1 without gaps
+	ProximityRadius          float64                `protobuf:"fixed64,9,opt,name=proximityRadius,proto3" json:"proximityRadius,omitempty"`                    // The keypoint's own radius in meters, 0 when it uses the tour's
+	EffectiveProximityRadius float64                `protobuf:"fixed64,10,opt,name=effectiveProximityRadius,proto3" json:"effectiveProximityRadius,omitempty"` // Radius proximity checks use; set by GetKeyPoints, from userId's active execution when there is one
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *KeyPoint) Reset() {
+	*x = KeyPoint{}
+	mi := &file_tour_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyPoint) ProtoMessage() {}
+
+func (x *KeyPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyPoint.ProtoReflect.Descriptor instead.
+func (*KeyPoint) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *KeyPoint) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *KeyPoint) GetTourId() string {
+	if x != nil {
+		return x.TourId
+	}
+	return ""
+}
+
+func (x *KeyPoint) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *KeyPoint) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *KeyPoint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *KeyPoint) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *KeyPoint) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *KeyPoint) GetOrder() int32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+func (x *KeyPoint) GetProximityRadius() float64 {
+	if x != nil {
+		return x.ProximityRadius
+	}
+	return 0
+}
+
+func (x *KeyPoint) GetEffectiveProximityRadius() float64 {
+	if x != nil {
+		return x.EffectiveProximityRadius
+	}
+	return 0
+}
+
+type GetKeyPointsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TourId        string                 `protobuf:"bytes,1,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"` // To check if user purchased tour
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyPointsRequest) Reset() {
+	*x = GetKeyPointsRequest{}
+	mi := &file_tour_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyPointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyPointsRequest) ProtoMessage() {}
+
+func (x *GetKeyPointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyPointsRequest.ProtoReflect.Descriptor instead.
+func (*GetKeyPointsRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetKeyPointsRequest) GetTourId() string {
+	if x != nil {
+		return x.TourId
+	}
+	return ""
+}
+
+func (x *GetKeyPointsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type KeyPointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	KeyPoints     []*KeyPoint            `protobuf:"bytes,3,rep,name=keyPoints,proto3" json:"keyPoints,omitempty"`      // In order
+	IsPurchased   bool                   `protobuf:"varint,4,opt,name=isPurchased,proto3" json:"isPurchased,omitempty"` // Whether user can see all keypoints
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyPointsResponse) Reset() {
+	*x = KeyPointsResponse{}
+	mi := &file_tour_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyPointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyPointsResponse) ProtoMessage() {}
+
+func (x *KeyPointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyPointsResponse.ProtoReflect.Descriptor instead.
+func (*KeyPointsResponse) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *KeyPointsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *KeyPointsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *KeyPointsResponse) GetKeyPoints() []*KeyPoint {
+	if x != nil {
+		return x.KeyPoints
+	}
+	return nil
+}
+
+func (x *KeyPointsResponse) GetIsPurchased() bool {
+	if x != nil {
+		return x.IsPurchased
+	}
+	return false
+}
+
+type UpdateKeyPointRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	KeyPointId      string                 `protobuf:"bytes,1,opt,name=keyPointId,proto3" json:"keyPointId,omitempty"`
+	TourId          string                 `protobuf:"bytes,2,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	GuideId         string                 `protobuf:"bytes,3,opt,name=guideId,proto3" json:"guideId,omitempty"` // For authorization
+	Latitude        float64                `protobuf:"fixed64,4,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude       float64                `protobuf:"fixed64,5,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Name            string                 `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Image           string                 `protobuf:"bytes,8,opt,name=image,proto3" json:"image,omitempty"`
+	ProximityRadius float64                `protobuf:"fixed64,9,opt,name=proximityRadius,proto3" json:"proximityRadius,omitempty"` // Meters, 5 to 2000; 0 uses the tour's radius
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateKeyPointRequest) Reset() {
+	*x = UpdateKeyPointRequest{}
+	mi := &file_tour_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateKeyPointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateKeyPointRequest) ProtoMessage() {}
+
+func (x *UpdateKeyPointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateKeyPointRequest.ProtoReflect.Descriptor instead.
+func (*UpdateKeyPointRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateKeyPointRequest) GetKeyPointId() string {
+	if x != nil {
+		return x.KeyPointId
+	}
+	return ""
+}
+
+func (x *UpdateKeyPointRequest) GetTourId() string {
+	if x != nil {
+		return x.TourId
+	}
+	return ""
+}
+
+func (x *UpdateKeyPointRequest) GetGuideId() string {
+	if x != nil {
+		return x.GuideId
+	}
+	return ""
+}
+
+func (x *UpdateKeyPointRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *UpdateKeyPointRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *UpdateKeyPointRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateKeyPointRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateKeyPointRequest) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *UpdateKeyPointRequest) GetProximityRadius() float64 {
+	if x != nil {
+		return x.ProximityRadius
+	}
+	return 0
+}
+
+type DeleteKeyPointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyPointId    string                 `protobuf:"bytes,1,opt,name=keyPointId,proto3" json:"keyPointId,omitempty"`
+	TourId        string                 `protobuf:"bytes,2,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	GuideId       string                 `protobuf:"bytes,3,opt,name=guideId,proto3" json:"guideId,omitempty"` // For authorization
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteKeyPointRequest) Reset() {
+	*x = DeleteKeyPointRequest{}
+	mi := &file_tour_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteKeyPointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteKeyPointRequest) ProtoMessage() {}
+
+func (x *DeleteKeyPointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteKeyPointRequest.ProtoReflect.Descriptor instead.
+func (*DeleteKeyPointRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteKeyPointRequest) GetKeyPointId() string {
+	if x != nil {
+		return x.KeyPointId
+	}
+	return ""
+}
+
+func (x *DeleteKeyPointRequest) GetTourId() string {
+	if x != nil {
+		return x.TourId
+	}
+	return ""
+}
+
+func (x *DeleteKeyPointRequest) GetGuideId() string {
+	if x != nil {
+		return x.GuideId
+	}
+	return ""
+}
+
+// Keypoint order is only changed through ReorderKeyPoints; UpdateKeyPoint
+// keeps it.
+type ReorderKeyPointsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TourId        string                 `protobuf:"bytes,1,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	GuideId       string                 `protobuf:"bytes,2,opt,name=guideId,proto3" json:"guideId,omitempty"`         // For authorization
+	KeyPointIds   []string               `protobuf:"bytes,3,rep,name=keyPointIds,proto3" json:"keyPointIds,omitempty"` // Every keypoint of the tour, in the new order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderKeyPointsRequest) Reset() {
+	*x = ReorderKeyPointsRequest{}
+	mi := &file_tour_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderKeyPointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderKeyPointsRequest) ProtoMessage() {}
+
+func (x *ReorderKeyPointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderKeyPointsRequest.ProtoReflect.Descriptor instead.
+func (*ReorderKeyPointsRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReorderKeyPointsRequest) GetTourId() string {
+	if x != nil {
+		return x.TourId
+	}
+	return ""
+}
+
+func (x *ReorderKeyPointsRequest) GetGuideId() string {
+	if x != nil {
+		return x.GuideId
+	}
+	return ""
+}
+
+func (x *ReorderKeyPointsRequest) GetKeyPointIds() []string {
+	if x != nil {
+		return x.KeyPointIds
+	}
+	return nil
+}
+
+type ImportKeyPointsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TourId        string                 `protobuf:"bytes,1,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	GuideId       string                 `protobuf:"bytes,2,opt,name=guideId,proto3" json:"guideId,omitempty"` // For authorization
+	Format        string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`   // "gpx", "kml" or "geojson"
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`       // Contents of the file
+	Mode          string                 `protobuf:"bytes,5,opt,name=mode,proto3" json:"mode,omitempty"`       // "append" (default) or "replace"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportKeyPointsRequest) Reset() {
+	*x = ImportKeyPointsRequest{}
+	mi := &file_tour_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportKeyPointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportKeyPointsRequest) ProtoMessage() {}
+
+func (x *ImportKeyPointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportKeyPointsRequest.ProtoReflect.Descriptor instead.
+func (*ImportKeyPointsRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ImportKeyPointsRequest) GetTourId() string {
+	if x != nil {
+		return x.TourId
+	}
+	return ""
+}
+
+func (x *ImportKeyPointsRequest) GetGuideId() string {
+	if x != nil {
+		return x.GuideId
+	}
+	return ""
+}
+
+func (x *ImportKeyPointsRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportKeyPointsRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportKeyPointsRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+type ImportKeyPointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ImportedCount int32                  `protobuf:"varint,3,opt,name=importedCount,proto3" json:"importedCount,omitempty"`
+	RejectedCount int32                  `protobuf:"varint,4,opt,name=rejectedCount,proto3" json:"rejectedCount,omitempty"`
+	Features      []*ImportedFeature     `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"` // One entry per feature in the file, in file order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportKeyPointsResponse) Reset() {
+	*x = ImportKeyPointsResponse{}
+	mi := &file_tour_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportKeyPointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportKeyPointsResponse) ProtoMessage() {}
+
+func (x *ImportKeyPointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportKeyPointsResponse.ProtoReflect.Descriptor instead.
+func (*ImportKeyPointsResponse) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ImportKeyPointsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ImportKeyPointsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ImportKeyPointsResponse) GetImportedCount() int32 {
+	if x != nil {
+		return x.ImportedCount
+	}
+	return 0
+}
+
+func (x *ImportKeyPointsResponse) GetRejectedCount() int32 {
+	if x != nil {
+		return x.RejectedCount
+	}
+	return 0
+}
+
+func (x *ImportKeyPointsResponse) GetFeatures() []*ImportedFeature {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type ImportedFeature struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // Position of the feature in the file, from 0
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Imported      bool                   `protobuf:"varint,3,opt,name=imported,proto3" json:"imported,omitempty"`
+	KeyPoint      *KeyPoint              `protobuf:"bytes,4,opt,name=keyPoint,proto3" json:"keyPoint,omitempty"` // Set when imported
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`     // Why the feature was rejected
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportedFeature) Reset() {
+	*x = ImportedFeature{}
+	mi := &file_tour_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportedFeature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportedFeature) ProtoMessage() {}
+
+func (x *ImportedFeature) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportedFeature.ProtoReflect.Descriptor instead.
+func (*ImportedFeature) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ImportedFeature) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ImportedFeature) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ImportedFeature) GetImported() bool {
+	if x != nil {
+		return x.Imported
+	}
+	return false
+}
+
+func (x *ImportedFeature) GetKeyPoint() *KeyPoint {
+	if x != nil {
+		return x.KeyPoint
+	}
+	return nil
+}
+
+func (x *ImportedFeature) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ExportTourRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TourId        string                 `protobuf:"bytes,1,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"` // Optional; unlocks all keypoints for buyers and the guide
+	Format        string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"` // "gpx", "kml" or "geojson"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTourRequest) Reset() {
+	*x = ExportTourRequest{}
+	mi := &file_tour_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTourRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTourRequest) ProtoMessage() {}
+
+func (x *ExportTourRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTourRequest.ProtoReflect.Descriptor instead.
+func (*ExportTourRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ExportTourRequest) GetTourId() string {
+	if x != nil {
+		return x.TourId
+	}
+	return ""
+}
+
+func (x *ExportTourRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExportTourRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type ExportTourResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	FileName      string                 `protobuf:"bytes,3,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	ContentType   string                 `protobuf:"bytes,4,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	Data          []byte                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	KeyPointCount int32                  `protobuf:"varint,6,opt,name=keyPointCount,proto3" json:"keyPointCount,omitempty"` // Keypoints included in the file
+	IsPurchased   bool                   `protobuf:"varint,7,opt,name=isPurchased,proto3" json:"isPurchased,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTourResponse) Reset() {
+	*x = ExportTourResponse{}
+	mi := &file_tour_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTourResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTourResponse) ProtoMessage() {}
+
+func (x *ExportTourResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTourResponse.ProtoReflect.Descriptor instead.
+func (*ExportTourResponse) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ExportTourResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ExportTourResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ExportTourResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ExportTourResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportTourResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportTourResponse) GetKeyPointCount() int32 {
+	if x != nil {
+		return x.KeyPointCount
+	}
+	return 0
+}
+
+func (x *ExportTourResponse) GetIsPurchased() bool {
+	if x != nil {
+		return x.IsPurchased
+	}
+	return false
+}
+
+type DeleteKeyPointResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteKeyPointResponse) Reset() {
+	*x = DeleteKeyPointResponse{}
+	mi := &file_tour_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteKeyPointResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteKeyPointResponse) ProtoMessage() {}
+
+func (x *DeleteKeyPointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteKeyPointResponse.ProtoReflect.Descriptor instead.
+func (*DeleteKeyPointResponse) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteKeyPointResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteKeyPointResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ============ Position Simulator ============
+type UpdatePositionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TouristId     string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePositionRequest) Reset() {
+	*x = UpdatePositionRequest{}
+	mi := &file_tour_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePositionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePositionRequest) ProtoMessage() {}
+
+func (x *UpdatePositionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePositionRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePositionRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UpdatePositionRequest) GetTouristId() string {
+	if x != nil {
+		return x.TouristId
+	}
+	return ""
+}
+
+func (x *UpdatePositionRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *UpdatePositionRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+type GetPositionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TouristId     string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPositionRequest) Reset() {
+	*x = GetPositionRequest{}
+	mi := &file_tour_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPositionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPositionRequest) ProtoMessage() {}
+
+func (x *GetPositionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPositionRequest.ProtoReflect.Descriptor instead.
+func (*GetPositionRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetPositionRequest) GetTouristId() string {
+	if x != nil {
+		return x.TouristId
+	}
+	return ""
+}
+
+type PositionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Position      *Position              `protobuf:"bytes,3,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PositionResponse) Reset() {
+	*x = PositionResponse{}
+	mi := &file_tour_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PositionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PositionResponse) ProtoMessage() {}
+
+func (x *PositionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PositionResponse.ProtoReflect.Descriptor instead.
+func (*PositionResponse) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PositionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PositionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PositionResponse) GetPosition() *Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+type Position struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TouristId     string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,4,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	mi := &file_tour_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *Position) GetTouristId() string {
+	if x != nil {
+		return x.TouristId
+	}
+	return ""
+}
+
+func (x *Position) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Position) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Position) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// ============ Shopping Cart ============
+type AddToCartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TouristId     string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	TourId        string                 `protobuf:"bytes,2,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddToCartRequest) Reset() {
+	*x = AddToCartRequest{}
+	mi := &file_tour_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddToCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddToCartRequest) ProtoMessage() {}
+
+func (x *AddToCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddToCartRequest.ProtoReflect.Descriptor instead.
+func (*AddToCartRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AddToCartRequest) GetTouristId() string {
+	if x != nil {
+		return x.TouristId
+	}
+	return ""
+}
+
+func (x *AddToCartRequest) GetTourId() string {
+	if x != nil {
+		return x.TourId
+	}
+	return ""
+}
+
+type RemoveFromCartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TouristId     string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	TourId        string                 `protobuf:"bytes,2,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveFromCartRequest) Reset() {
+	*x = RemoveFromCartRequest{}
+	mi := &file_tour_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveFromCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFromCartRequest) ProtoMessage() {}
+
+func (x *RemoveFromCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFromCartRequest.ProtoReflect.Descriptor instead.
+func (*RemoveFromCartRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RemoveFromCartRequest) GetTouristId() string {
+	if x != nil {
+		return x.TouristId
+	}
+	return ""
+}
+
+func (x *RemoveFromCartRequest) GetTourId() string {
+	if x != nil {
+		return x.TourId
+	}
+	return ""
+}
+
+type GetCartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TouristId     string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCartRequest) Reset() {
+	*x = GetCartRequest{}
+	mi := &file_tour_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCartRequest) ProtoMessage() {}
+
+func (x *GetCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCartRequest.ProtoReflect.Descriptor instead.
+func (*GetCartRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetCartRequest) GetTouristId() string {
+	if x != nil {
+		return x.TouristId
+	}
+	return ""
+}
+
+type CartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Cart          *ShoppingCart          `protobuf:"bytes,3,opt,name=cart,proto3" json:"cart,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartResponse) Reset() {
+	*x = CartResponse{}
+	mi := &file_tour_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartResponse) ProtoMessage() {}
+
+func (x *CartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartResponse.ProtoReflect.Descriptor instead.
+func (*CartResponse) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CartResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CartResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CartResponse) GetCart() *ShoppingCart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+type ShoppingCart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TouristId     string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	Items         []*CartItem            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	TotalPrice    float64                `protobuf:"fixed64,3,opt,name=totalPrice,proto3" json:"totalPrice,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoppingCart) Reset() {
+	*x = ShoppingCart{}
+	mi := &file_tour_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingCart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingCart) ProtoMessage() {}
+
+func (x *ShoppingCart) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingCart.ProtoReflect.Descriptor instead.
+func (*ShoppingCart) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ShoppingCart) GetTouristId() string {
+	if x != nil {
+		return x.TouristId
+	}
+	return ""
+}
+
+func (x *ShoppingCart) GetItems() []*CartItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ShoppingCart) GetTotalPrice() float64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+type CartItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TourId        string                 `protobuf:"bytes,1,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	TourName      string                 `protobuf:"bytes,2,opt,name=tourName,proto3" json:"tourName,omitempty"`
+	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartItem) Reset() {
+	*x = CartItem{}
+	mi := &file_tour_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartItem) ProtoMessage() {}
+
+func (x *CartItem) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartItem.ProtoReflect.Descriptor instead.
+func (*CartItem) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CartItem) GetTourId() string {
+	if x != nil {
+		return x.TourId
+	}
+	return ""
+}
+
+func (x *CartItem) GetTourName() string {
+	if x != nil {
+		return x.TourName
+	}
+	return ""
+}
+
+func (x *CartItem) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+type CheckoutRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TouristId      string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,2,opt,name=idempotencyKey,proto3" json:"idempotencyKey,omitempty"` // Optional: retries with the same key replay the first result
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CheckoutRequest) Reset() {
+	*x = CheckoutRequest{}
+	mi := &file_tour_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutRequest) ProtoMessage() {}
+
+func (x *CheckoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutRequest.ProtoReflect.Descriptor instead.
+func (*CheckoutRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CheckoutRequest) GetTouristId() string {
+	if x != nil {
+		return x.TouristId
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type CheckoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Tokens        []*PurchaseToken       `protobuf:"bytes,3,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	Replayed      bool                   `protobuf:"varint,4,opt,name=replayed,proto3" json:"replayed,omitempty"` // True when the result was replayed for a repeated idempotency key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutResponse) Reset() {
+	*x = CheckoutResponse{}
+	mi := &file_tour_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutResponse) ProtoMessage() {}
+
+func (x *CheckoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutResponse.ProtoReflect.Descriptor instead.
+func (*CheckoutResponse) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CheckoutResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CheckoutResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CheckoutResponse) GetTokens() []*PurchaseToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *CheckoutResponse) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
+type PurchaseToken struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TourId        string                 `protobuf:"bytes,1,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	PurchasedAt   string                 `protobuf:"bytes,3,opt,name=purchasedAt,proto3" json:"purchasedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurchaseToken) Reset() {
+	*x = PurchaseToken{}
+	mi := &file_tour_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurchaseToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurchaseToken) ProtoMessage() {}
+
+func (x *PurchaseToken) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurchaseToken.ProtoReflect.Descriptor instead.
+func (*PurchaseToken) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *PurchaseToken) GetTourId() string {
+	if x != nil {
+		return x.TourId
+	}
+	return ""
+}
+
+func (x *PurchaseToken) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *PurchaseToken) GetPurchasedAt() string {
+	if x != nil {
+		return x.PurchasedAt
+	}
+	return ""
+}
+
+// ============ Tour Execution ============
+type StartExecutionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TouristId      string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	TourId         string                 `protobuf:"bytes,2,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	StartLatitude  float64                `protobuf:"fixed64,3,opt,name=startLatitude,proto3" json:"startLatitude,omitempty"`
+	StartLongitude float64                `protobuf:"fixed64,4,opt,name=startLongitude,proto3" json:"startLongitude,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StartExecutionRequest) Reset() {
+	*x = StartExecutionRequest{}
+	mi := &file_tour_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartExecutionRequest) ProtoMessage() {}
+
+func (x *StartExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartExecutionRequest.ProtoReflect.Descriptor instead.
+func (*StartExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *StartExecutionRequest) GetTouristId() string {
+	if x != nil {
+		return x.TouristId
+	}
+	return ""
+}
+
+func (x *StartExecutionRequest) GetTourId() string {
+	if x != nil {
+		return x.TourId
+	}
+	return ""
+}
+
+func (x *StartExecutionRequest) GetStartLatitude() float64 {
+	if x != nil {
+		return x.StartLatitude
+	}
+	return 0
+}
+
+func (x *StartExecutionRequest) GetStartLongitude() float64 {
+	if x != nil {
+		return x.StartLongitude
+	}
+	return 0
+}
+
+type ExecutionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Execution     *TourExecution         `protobuf:"bytes,3,opt,name=execution,proto3" json:"execution,omitempty"`
+	Summary       *CompletionSummary     `protobuf:"bytes,4,opt,name=summary,proto3" json:"summary,omitempty"` // Set by CompleteTour
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutionResponse) Reset() {
+	*x = ExecutionResponse{}
+	mi := &file_tour_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionResponse) ProtoMessage() {}
+
+func (x *ExecutionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionResponse.ProtoReflect.Descriptor instead.
+func (*ExecutionResponse) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ExecutionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ExecutionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ExecutionResponse) GetExecution() *TourExecution {
+	if x != nil {
+		return x.Execution
+	}
+	return nil
+}
+
+func (x *ExecutionResponse) GetSummary() *CompletionSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+// How an execution went, once it is completed.
+type CompletionSummary struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	CompletedKeyPoints   int32                  `protobuf:"varint,1,opt,name=completedKeyPoints,proto3" json:"completedKeyPoints,omitempty"`
+	TotalKeyPoints       int32                  `protobuf:"varint,2,opt,name=totalKeyPoints,proto3" json:"totalKeyPoints,omitempty"`
+	CompletionPercentage float64                `protobuf:"fixed64,3,opt,name=completionPercentage,proto3" json:"completionPercentage,omitempty"` // 0 to 100
+	DurationSeconds      float64                `protobuf:"fixed64,4,opt,name=durationSeconds,proto3" json:"durationSeconds,omitempty"`           // From start to completion
+	Distance             float64                `protobuf:"fixed64,5,opt,name=distance,proto3" json:"distance,omitempty"`                         // Meters walked, from the position history
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CompletionSummary) Reset() {
+	*x = CompletionSummary{}
+	mi := &file_tour_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompletionSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompletionSummary) ProtoMessage() {}
+
+func (x *CompletionSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompletionSummary.ProtoReflect.Descriptor instead.
+func (*CompletionSummary) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *CompletionSummary) GetCompletedKeyPoints() int32 {
+	if x != nil {
+		return x.CompletedKeyPoints
+	}
+	return 0
+}
+
+func (x *CompletionSummary) GetTotalKeyPoints() int32 {
+	if x != nil {
+		return x.TotalKeyPoints
+	}
+	return 0
+}
+
+func (x *CompletionSummary) GetCompletionPercentage() float64 {
+	if x != nil {
+		return x.CompletionPercentage
+	}
+	return 0
+}
+
+func (x *CompletionSummary) GetDurationSeconds() float64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *CompletionSummary) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type TourExecution struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TouristId            string                 `protobuf:"bytes,2,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	TourId               string                 `protobuf:"bytes,3,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	Status               string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // "active", "completed", "abandoned"
+	StartedAt            string                 `protobuf:"bytes,5,opt,name=startedAt,proto3" json:"startedAt,omitempty"`
+	CompletedAt          string                 `protobuf:"bytes,6,opt,name=completedAt,proto3" json:"completedAt,omitempty"`
+	LastActivity         string                 `protobuf:"bytes,7,opt,name=lastActivity,proto3" json:"lastActivity,omitempty"`
+	StartPosition        *Position              `protobuf:"bytes,8,opt,name=startPosition,proto3" json:"startPosition,omitempty"`
+	CompletedKeypoints   []*CompletedKeyPoint   `protobuf:"bytes,9,rep,name=completedKeypoints,proto3" json:"completedKeypoints,omitempty"`
+	Mode                 string                 `protobuf:"bytes,10,opt,name=mode,proto3" json:"mode,omitempty"`                                   // Execution mode of the tour when the execution started
+	CompletionPercentage float64                `protobuf:"fixed64,11,opt,name=completionPercentage,proto3" json:"completionPercentage,omitempty"` // Share of keypoints completed, recorded when the execution completes
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *TourExecution) Reset() {
+	*x = TourExecution{}
+	mi := &file_tour_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TourExecution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TourExecution) ProtoMessage() {}
+
+func (x *TourExecution) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TourExecution.ProtoReflect.Descriptor instead.
+func (*TourExecution) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *TourExecution) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TourExecution) GetTouristId() string {
+	if x != nil {
+		return x.TouristId
+	}
+	return ""
+}
+
+func (x *TourExecution) GetTourId() string {
+	if x != nil {
+		return x.TourId
+	}
+	return ""
+}
+
+func (x *TourExecution) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TourExecution) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *TourExecution) GetCompletedAt() string {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return ""
+}
+
+func (x *TourExecution) GetLastActivity() string {
+	if x != nil {
+		return x.LastActivity
+	}
+	return ""
+}
+
+func (x *TourExecution) GetStartPosition() *Position {
+	if x != nil {
+		return x.StartPosition
+	}
+	return nil
+}
+
+func (x *TourExecution) GetCompletedKeypoints() []*CompletedKeyPoint {
+	if x != nil {
+		return x.CompletedKeypoints
+	}
+	return nil
+}
+
+func (x *TourExecution) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *TourExecution) GetCompletionPercentage() float64 {
+	if x != nil {
+		return x.CompletionPercentage
+	}
+	return 0
+}
+
+type CompletedKeyPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeypointId    string                 `protobuf:"bytes,1,opt,name=keypointId,proto3" json:"keypointId,omitempty"`
+	CompletedAt   string                 `protobuf:"bytes,2,opt,name=completedAt,proto3" json:"completedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompletedKeyPoint) Reset() {
+	*x = CompletedKeyPoint{}
+	mi := &file_tour_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompletedKeyPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompletedKeyPoint) ProtoMessage() {}
+
+func (x *CompletedKeyPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompletedKeyPoint.ProtoReflect.Descriptor instead.
+func (*CompletedKeyPoint) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *CompletedKeyPoint) GetKeypointId() string {
+	if x != nil {
+		return x.KeypointId
+	}
+	return ""
+}
+
+func (x *CompletedKeyPoint) GetCompletedAt() string {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return ""
+}
+
+type CheckProximityRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId      string                 `protobuf:"bytes,1,opt,name=executionId,proto3" json:"executionId,omitempty"`
+	TouristId        string                 `protobuf:"bytes,2,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	CurrentLatitude  float64                `protobuf:"fixed64,3,opt,name=currentLatitude,proto3" json:"currentLatitude,omitempty"`
+	CurrentLongitude float64                `protobuf:"fixed64,4,opt,name=currentLongitude,proto3" json:"currentLongitude,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CheckProximityRequest) Reset() {
+	*x = CheckProximityRequest{}
+	mi := &file_tour_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckProximityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckProximityRequest) ProtoMessage() {}
+
+func (x *CheckProximityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckProximityRequest.ProtoReflect.Descriptor instead.
+func (*CheckProximityRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *CheckProximityRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+func (x *CheckProximityRequest) GetTouristId() string {
+	if x != nil {
+		return x.TouristId
+	}
+	return ""
+}
+
+func (x *CheckProximityRequest) GetCurrentLatitude() float64 {
+	if x != nil {
+		return x.CurrentLatitude
+	}
+	return 0
+}
+
+func (x *CheckProximityRequest) GetCurrentLongitude() float64 {
+	if x != nil {
+		return x.CurrentLongitude
+	}
+	return 0
+}
+
+type ProximityResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Success              bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message              string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	NearKeyPoint         bool                   `protobuf:"varint,3,opt,name=nearKeyPoint,proto3" json:"nearKeyPoint,omitempty"`
+	NearbyKeyPoint       *KeyPoint              `protobuf:"bytes,4,opt,name=nearbyKeyPoint,proto3" json:"nearbyKeyPoint,omitempty"`
+	Distance             float64                `protobuf:"fixed64,5,opt,name=distance,proto3" json:"distance,omitempty"`                         // Distance in meters
+	NextKeyPoint         *KeyPoint              `protobuf:"bytes,6,opt,name=nextKeyPoint,proto3" json:"nextKeyPoint,omitempty"`                   // Next by order in sequential mode, else the nearest uncompleted; unset once all are done
+	NextKeyPointDistance float64                `protobuf:"fixed64,7,opt,name=nextKeyPointDistance,proto3" json:"nextKeyPointDistance,omitempty"` // Distance in meters
+	TourCompleted        bool                   `protobuf:"varint,8,opt,name=tourCompleted,proto3" json:"tourCompleted,omitempty"`                // The last keypoint was reached and the execution completed
+	Summary              *CompletionSummary     `protobuf:"bytes,9,opt,name=summary,proto3" json:"summary,omitempty"`                             // Set when tourCompleted
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ProximityResponse) Reset() {
+	*x = ProximityResponse{}
+	mi := &file_tour_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProximityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProximityResponse) ProtoMessage() {}
+
+func (x *ProximityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProximityResponse.ProtoReflect.Descriptor instead.
+func (*ProximityResponse) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ProximityResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ProximityResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ProximityResponse) GetNearKeyPoint() bool {
+	if x != nil {
+		return x.NearKeyPoint
+	}
+	return false
+}
+
+func (x *ProximityResponse) GetNearbyKeyPoint() *KeyPoint {
+	if x != nil {
+		return x.NearbyKeyPoint
+	}
+	return nil
+}
+
+func (x *ProximityResponse) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *ProximityResponse) GetNextKeyPoint() *KeyPoint {
+	if x != nil {
+		return x.NextKeyPoint
+	}
+	return nil
+}
+
+func (x *ProximityResponse) GetNextKeyPointDistance() float64 {
+	if x != nil {
+		return x.NextKeyPointDistance
+	}
+	return 0
+}
+
+func (x *ProximityResponse) GetTourCompleted() bool {
+	if x != nil {
+		return x.TourCompleted
+	}
+	return false
+}
+
+func (x *ProximityResponse) GetSummary() *CompletionSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+// A position sample of a tracked execution. The first message of the
+// stream must name the execution and tourist; later ones may leave them
+// empty.
+type TrackExecutionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=executionId,proto3" json:"executionId,omitempty"`
+	TouristId     string                 `protobuf:"bytes,2,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrackExecutionRequest) Reset() {
+	*x = TrackExecutionRequest{}
+	mi := &file_tour_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackExecutionRequest) ProtoMessage() {}
+
+func (x *TrackExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackExecutionRequest.ProtoReflect.Descriptor instead.
+func (*TrackExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *TrackExecutionRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
 }
 
-func (x *Position) GetTouristId() string {
+func (x *TrackExecutionRequest) GetTouristId() string {
 	if x != nil {
 		return x.TouristId
 	}
 	return ""
 }
 
-func (x *Position) GetLatitude() float64 {
+func (x *TrackExecutionRequest) GetLatitude() float64 {
 	if x != nil {
 		return x.Latitude
 	}
 	return 0
 }
 
-func (x *Position) GetLongitude() float64 {
+func (x *TrackExecutionRequest) GetLongitude() float64 {
 	if x != nil {
 		return x.Longitude
 	}
 	return 0
 }
 
-func (x *Position) GetUpdatedAt() string {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return ""
-}
-
-// ============ Shopping Cart ============
-type AddToCartRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TouristId     string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
-	TourId        string                 `protobuf:"bytes,2,opt,name=tourId,proto3" json:"tourId,omitempty"`
+type TrackExecutionEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*TrackExecutionEvent_KeyPointReached
+	//	*TrackExecutionEvent_TourCompleted
+	//	*TrackExecutionEvent_ExecutionExpired
+	Event         isTrackExecutionEvent_Event `protobuf_oneof:"event"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddToCartRequest) Reset() {
-	*x = AddToCartRequest{}
-	mi := &file_tour_proto_msgTypes[20]
+func (x *TrackExecutionEvent) Reset() {
+	*x = TrackExecutionEvent{}
+	mi := &file_tour_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddToCartRequest) String() string {
+func (x *TrackExecutionEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddToCartRequest) ProtoMessage() {}
+func (*TrackExecutionEvent) ProtoMessage() {}
 
-func (x *AddToCartRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[20]
+func (x *TrackExecutionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1410,48 +4104,94 @@ func (x *AddToCartRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddToCartRequest.ProtoReflect.Descriptor instead.
-func (*AddToCartRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use TrackExecutionEvent.ProtoReflect.Descriptor instead.
+func (*TrackExecutionEvent) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *AddToCartRequest) GetTouristId() string {
+func (x *TrackExecutionEvent) GetEvent() isTrackExecutionEvent_Event {
 	if x != nil {
-		return x.TouristId
+		return x.Event
 	}
-	return ""
+	return nil
 }
 
-func (x *AddToCartRequest) GetTourId() string {
+func (x *TrackExecutionEvent) GetKeyPointReached() *KeyPointReachedEvent {
 	if x != nil {
-		return x.TourId
+		if x, ok := x.Event.(*TrackExecutionEvent_KeyPointReached); ok {
+			return x.KeyPointReached
+		}
 	}
-	return ""
+	return nil
 }
 
-type RemoveFromCartRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TouristId     string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
-	TourId        string                 `protobuf:"bytes,2,opt,name=tourId,proto3" json:"tourId,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *TrackExecutionEvent) GetTourCompleted() *TourCompletedEvent {
+	if x != nil {
+		if x, ok := x.Event.(*TrackExecutionEvent_TourCompleted); ok {
+			return x.TourCompleted
+		}
+	}
+	return nil
 }
 
-func (x *RemoveFromCartRequest) Reset() {
-	*x = RemoveFromCartRequest{}
-	mi := &file_tour_proto_msgTypes[21]
+func (x *TrackExecutionEvent) GetExecutionExpired() *ExecutionExpiredEvent {
+	if x != nil {
+		if x, ok := x.Event.(*TrackExecutionEvent_ExecutionExpired); ok {
+			return x.ExecutionExpired
+		}
+	}
+	return nil
+}
+
+type isTrackExecutionEvent_Event interface {
+	isTrackExecutionEvent_Event()
+}
+
+type TrackExecutionEvent_KeyPointReached struct {
+	KeyPointReached *KeyPointReachedEvent `protobuf:"bytes,1,opt,name=keyPointReached,proto3,oneof"`
+}
+
+type TrackExecutionEvent_TourCompleted struct {
+	TourCompleted *TourCompletedEvent `protobuf:"bytes,2,opt,name=tourCompleted,proto3,oneof"` // Last event of the stream
+}
+
+type TrackExecutionEvent_ExecutionExpired struct {
+	ExecutionExpired *ExecutionExpiredEvent `protobuf:"bytes,3,opt,name=executionExpired,proto3,oneof"` // Last event of the stream
+}
+
+func (*TrackExecutionEvent_KeyPointReached) isTrackExecutionEvent_Event() {}
+
+func (*TrackExecutionEvent_TourCompleted) isTrackExecutionEvent_Event() {}
+
+func (*TrackExecutionEvent_ExecutionExpired) isTrackExecutionEvent_Event() {}
+
+type KeyPointReachedEvent struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	KeyPoint             *KeyPoint              `protobuf:"bytes,1,opt,name=keyPoint,proto3" json:"keyPoint,omitempty"`
+	Distance             float64                `protobuf:"fixed64,2,opt,name=distance,proto3" json:"distance,omitempty"` // Distance in meters
+	CompletedCount       int32                  `protobuf:"varint,3,opt,name=completedCount,proto3" json:"completedCount,omitempty"`
+	TotalCount           int32                  `protobuf:"varint,4,opt,name=totalCount,proto3" json:"totalCount,omitempty"`
+	NextKeyPoint         *KeyPoint              `protobuf:"bytes,5,opt,name=nextKeyPoint,proto3" json:"nextKeyPoint,omitempty"` // As in ProximityResponse
+	NextKeyPointDistance float64                `protobuf:"fixed64,6,opt,name=nextKeyPointDistance,proto3" json:"nextKeyPointDistance,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *KeyPointReachedEvent) Reset() {
+	*x = KeyPointReachedEvent{}
+	mi := &file_tour_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveFromCartRequest) String() string {
+func (x *KeyPointReachedEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveFromCartRequest) ProtoMessage() {}
+func (*KeyPointReachedEvent) ProtoMessage() {}
 
-func (x *RemoveFromCartRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[21]
+func (x *KeyPointReachedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1462,47 +4202,76 @@ func (x *RemoveFromCartRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveFromCartRequest.ProtoReflect.Descriptor instead.
-func (*RemoveFromCartRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use KeyPointReachedEvent.ProtoReflect.Descriptor instead.
+func (*KeyPointReachedEvent) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *RemoveFromCartRequest) GetTouristId() string {
+func (x *KeyPointReachedEvent) GetKeyPoint() *KeyPoint {
 	if x != nil {
-		return x.TouristId
+		return x.KeyPoint
 	}
-	return ""
+	return nil
 }
 
-func (x *RemoveFromCartRequest) GetTourId() string {
+func (x *KeyPointReachedEvent) GetDistance() float64 {
 	if x != nil {
-		return x.TourId
+		return x.Distance
 	}
-	return ""
+	return 0
 }
 
-type GetCartRequest struct {
+func (x *KeyPointReachedEvent) GetCompletedCount() int32 {
+	if x != nil {
+		return x.CompletedCount
+	}
+	return 0
+}
+
+func (x *KeyPointReachedEvent) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *KeyPointReachedEvent) GetNextKeyPoint() *KeyPoint {
+	if x != nil {
+		return x.NextKeyPoint
+	}
+	return nil
+}
+
+func (x *KeyPointReachedEvent) GetNextKeyPointDistance() float64 {
+	if x != nil {
+		return x.NextKeyPointDistance
+	}
+	return 0
+}
+
+type TourCompletedEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TouristId     string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	Execution     *TourExecution         `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`
+	Summary       *CompletionSummary     `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCartRequest) Reset() {
-	*x = GetCartRequest{}
-	mi := &file_tour_proto_msgTypes[22]
+func (x *TourCompletedEvent) Reset() {
+	*x = TourCompletedEvent{}
+	mi := &file_tour_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCartRequest) String() string {
+func (x *TourCompletedEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCartRequest) ProtoMessage() {}
+func (*TourCompletedEvent) ProtoMessage() {}
 
-func (x *GetCartRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[22]
+func (x *TourCompletedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1513,42 +4282,48 @@ func (x *GetCartRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCartRequest.ProtoReflect.Descriptor instead.
-func (*GetCartRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use TourCompletedEvent.ProtoReflect.Descriptor instead.
+func (*TourCompletedEvent) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *GetCartRequest) GetTouristId() string {
+func (x *TourCompletedEvent) GetExecution() *TourExecution {
 	if x != nil {
-		return x.TouristId
+		return x.Execution
 	}
-	return ""
+	return nil
 }
 
-type CartResponse struct {
+func (x *TourCompletedEvent) GetSummary() *CompletionSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+type ExecutionExpiredEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Cart          *ShoppingCart          `protobuf:"bytes,3,opt,name=cart,proto3" json:"cart,omitempty"`
+	Execution     *TourExecution         `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CartResponse) Reset() {
-	*x = CartResponse{}
-	mi := &file_tour_proto_msgTypes[23]
+func (x *ExecutionExpiredEvent) Reset() {
+	*x = ExecutionExpiredEvent{}
+	mi := &file_tour_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CartResponse) String() string {
+func (x *ExecutionExpiredEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CartResponse) ProtoMessage() {}
+func (*ExecutionExpiredEvent) ProtoMessage() {}
 
-func (x *CartResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[23]
+func (x *ExecutionExpiredEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1559,56 +4334,52 @@ func (x *CartResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CartResponse.ProtoReflect.Descriptor instead.
-func (*CartResponse) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use ExecutionExpiredEvent.ProtoReflect.Descriptor instead.
+func (*ExecutionExpiredEvent) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *CartResponse) GetSuccess() bool {
+func (x *ExecutionExpiredEvent) GetExecution() *TourExecution {
 	if x != nil {
-		return x.Success
+		return x.Execution
 	}
-	return false
+	return nil
 }
 
-func (x *CartResponse) GetMessage() string {
+func (x *ExecutionExpiredEvent) GetReason() string {
 	if x != nil {
-		return x.Message
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *CartResponse) GetCart() *ShoppingCart {
-	if x != nil {
-		return x.Cart
-	}
-	return nil
-}
-
-type ShoppingCart struct {
+// Executions complete on their own once every keypoint is reached.
+// Completing earlier needs partial, and records the share of keypoints
+// reached.
+type CompleteExecutionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TouristId     string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
-	Items         []*CartItem            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
-	TotalPrice    float64                `protobuf:"fixed64,3,opt,name=totalPrice,proto3" json:"totalPrice,omitempty"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=executionId,proto3" json:"executionId,omitempty"`
+	TouristId     string                 `protobuf:"bytes,2,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	Partial       bool                   `protobuf:"varint,3,opt,name=partial,proto3" json:"partial,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShoppingCart) Reset() {
-	*x = ShoppingCart{}
-	mi := &file_tour_proto_msgTypes[24]
+func (x *CompleteExecutionRequest) Reset() {
+	*x = CompleteExecutionRequest{}
+	mi := &file_tour_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShoppingCart) String() string {
+func (x *CompleteExecutionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShoppingCart) ProtoMessage() {}
+func (*CompleteExecutionRequest) ProtoMessage() {}
 
-func (x *ShoppingCart) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[24]
+func (x *CompleteExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1619,56 +4390,55 @@ func (x *ShoppingCart) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShoppingCart.ProtoReflect.Descriptor instead.
-func (*ShoppingCart) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use CompleteExecutionRequest.ProtoReflect.Descriptor instead.
+func (*CompleteExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *ShoppingCart) GetTouristId() string {
+func (x *CompleteExecutionRequest) GetExecutionId() string {
 	if x != nil {
-		return x.TouristId
+		return x.ExecutionId
 	}
 	return ""
 }
 
-func (x *ShoppingCart) GetItems() []*CartItem {
+func (x *CompleteExecutionRequest) GetTouristId() string {
 	if x != nil {
-		return x.Items
+		return x.TouristId
 	}
-	return nil
+	return ""
 }
 
-func (x *ShoppingCart) GetTotalPrice() float64 {
+func (x *CompleteExecutionRequest) GetPartial() bool {
 	if x != nil {
-		return x.TotalPrice
+		return x.Partial
 	}
-	return 0
+	return false
 }
 
-type CartItem struct {
+type AbandonExecutionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TourId        string                 `protobuf:"bytes,1,opt,name=tourId,proto3" json:"tourId,omitempty"`
-	TourName      string                 `protobuf:"bytes,2,opt,name=tourName,proto3" json:"tourName,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=executionId,proto3" json:"executionId,omitempty"`
+	TouristId     string                 `protobuf:"bytes,2,opt,name=touristId,proto3" json:"touristId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CartItem) Reset() {
-	*x = CartItem{}
-	mi := &file_tour_proto_msgTypes[25]
+func (x *AbandonExecutionRequest) Reset() {
+	*x = AbandonExecutionRequest{}
+	mi := &file_tour_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CartItem) String() string {
+func (x *AbandonExecutionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CartItem) ProtoMessage() {}
+func (*AbandonExecutionRequest) ProtoMessage() {}
 
-func (x *CartItem) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[25]
+func (x *AbandonExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1679,54 +4449,48 @@ func (x *CartItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CartItem.ProtoReflect.Descriptor instead.
-func (*CartItem) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use AbandonExecutionRequest.ProtoReflect.Descriptor instead.
+func (*AbandonExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *CartItem) GetTourId() string {
+func (x *AbandonExecutionRequest) GetExecutionId() string {
 	if x != nil {
-		return x.TourId
+		return x.ExecutionId
 	}
 	return ""
 }
 
-func (x *CartItem) GetTourName() string {
+func (x *AbandonExecutionRequest) GetTouristId() string {
 	if x != nil {
-		return x.TourName
+		return x.TouristId
 	}
 	return ""
 }
 
-func (x *CartItem) GetPrice() float64 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
-}
-
-type CheckoutRequest struct {
+type GetExecutionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TouristId     string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=executionId,proto3" json:"executionId,omitempty"`
+	TouristId     string                 `protobuf:"bytes,2,opt,name=touristId,proto3" json:"touristId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckoutRequest) Reset() {
-	*x = CheckoutRequest{}
-	mi := &file_tour_proto_msgTypes[26]
+func (x *GetExecutionRequest) Reset() {
+	*x = GetExecutionRequest{}
+	mi := &file_tour_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckoutRequest) String() string {
+func (x *GetExecutionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckoutRequest) ProtoMessage() {}
+func (*GetExecutionRequest) ProtoMessage() {}
 
-func (x *CheckoutRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[26]
+func (x *GetExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1737,42 +4501,53 @@ func (x *CheckoutRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckoutRequest.ProtoReflect.Descriptor instead.
-func (*CheckoutRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use GetExecutionRequest.ProtoReflect.Descriptor instead.
+func (*GetExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetExecutionRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
 }
 
-func (x *CheckoutRequest) GetTouristId() string {
+func (x *GetExecutionRequest) GetTouristId() string {
 	if x != nil {
 		return x.TouristId
 	}
 	return ""
 }
 
-type CheckoutResponse struct {
+type ListExecutionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Tokens        []*PurchaseToken       `protobuf:"bytes,3,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	TouristId     string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`               // Optional: "active", "completed" or "abandoned"
+	TourId        string                 `protobuf:"bytes,3,opt,name=tourId,proto3" json:"tourId,omitempty"`               // Optional
+	StartedAfter  string                 `protobuf:"bytes,4,opt,name=startedAfter,proto3" json:"startedAfter,omitempty"`   // Optional, RFC3339
+	StartedBefore string                 `protobuf:"bytes,5,opt,name=startedBefore,proto3" json:"startedBefore,omitempty"` // Optional, RFC3339
+	PageSize      int32                  `protobuf:"varint,6,opt,name=pageSize,proto3" json:"pageSize,omitempty"`          // Defaults to 20, at most 100
+	PageToken     string                 `protobuf:"bytes,7,opt,name=pageToken,proto3" json:"pageToken,omitempty"`         // nextPageToken of the previous page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckoutResponse) Reset() {
-	*x = CheckoutResponse{}
-	mi := &file_tour_proto_msgTypes[27]
+func (x *ListExecutionsRequest) Reset() {
+	*x = ListExecutionsRequest{}
+	mi := &file_tour_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckoutResponse) String() string {
+func (x *ListExecutionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckoutResponse) ProtoMessage() {}
+func (*ListExecutionsRequest) ProtoMessage() {}
 
-func (x *CheckoutResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[27]
+func (x *ListExecutionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1783,56 +4558,85 @@ func (x *CheckoutResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckoutResponse.ProtoReflect.Descriptor instead.
-func (*CheckoutResponse) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use ListExecutionsRequest.ProtoReflect.Descriptor instead.
+func (*ListExecutionsRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *CheckoutResponse) GetSuccess() bool {
+func (x *ListExecutionsRequest) GetTouristId() string {
 	if x != nil {
-		return x.Success
+		return x.TouristId
 	}
-	return false
+	return ""
 }
 
-func (x *CheckoutResponse) GetMessage() string {
+func (x *ListExecutionsRequest) GetStatus() string {
 	if x != nil {
-		return x.Message
+		return x.Status
 	}
 	return ""
 }
 
-func (x *CheckoutResponse) GetTokens() []*PurchaseToken {
+func (x *ListExecutionsRequest) GetTourId() string {
 	if x != nil {
-		return x.Tokens
+		return x.TourId
 	}
-	return nil
+	return ""
 }
 
-type PurchaseToken struct {
+func (x *ListExecutionsRequest) GetStartedAfter() string {
+	if x != nil {
+		return x.StartedAfter
+	}
+	return ""
+}
+
+func (x *ListExecutionsRequest) GetStartedBefore() string {
+	if x != nil {
+		return x.StartedBefore
+	}
+	return ""
+}
+
+func (x *ListExecutionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListExecutionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListExecutionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TourId        string                 `protobuf:"bytes,1,opt,name=tourId,proto3" json:"tourId,omitempty"`
-	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
-	PurchasedAt   string                 `protobuf:"bytes,3,opt,name=purchasedAt,proto3" json:"purchasedAt,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Executions    []*ExecutionListEntry  `protobuf:"bytes,3,rep,name=executions,proto3" json:"executions,omitempty"`       // Most recently started first
+	NextPageToken string                 `protobuf:"bytes,4,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // Empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PurchaseToken) Reset() {
-	*x = PurchaseToken{}
-	mi := &file_tour_proto_msgTypes[28]
+func (x *ListExecutionsResponse) Reset() {
+	*x = ListExecutionsResponse{}
+	mi := &file_tour_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PurchaseToken) String() string {
+func (x *ListExecutionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PurchaseToken) ProtoMessage() {}
+func (*ListExecutionsResponse) ProtoMessage() {}
 
-func (x *PurchaseToken) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[28]
+func (x *ListExecutionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1843,58 +4647,64 @@ func (x *PurchaseToken) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PurchaseToken.ProtoReflect.Descriptor instead.
-func (*PurchaseToken) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use ListExecutionsResponse.ProtoReflect.Descriptor instead.
+func (*ListExecutionsResponse) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *PurchaseToken) GetTourId() string {
+func (x *ListExecutionsResponse) GetSuccess() bool {
 	if x != nil {
-		return x.TourId
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *PurchaseToken) GetToken() string {
+func (x *ListExecutionsResponse) GetMessage() string {
 	if x != nil {
-		return x.Token
+		return x.Message
 	}
 	return ""
 }
 
-func (x *PurchaseToken) GetPurchasedAt() string {
+func (x *ListExecutionsResponse) GetExecutions() []*ExecutionListEntry {
 	if x != nil {
-		return x.PurchasedAt
+		return x.Executions
+	}
+	return nil
+}
+
+func (x *ListExecutionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
 	}
 	return ""
 }
 
-// ============ Tour Execution ============
-type StartExecutionRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	TouristId      string                 `protobuf:"bytes,1,opt,name=touristId,proto3" json:"touristId,omitempty"`
-	TourId         string                 `protobuf:"bytes,2,opt,name=tourId,proto3" json:"tourId,omitempty"`
-	StartLatitude  float64                `protobuf:"fixed64,3,opt,name=startLatitude,proto3" json:"startLatitude,omitempty"`
-	StartLongitude float64                `protobuf:"fixed64,4,opt,name=startLongitude,proto3" json:"startLongitude,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type ExecutionListEntry struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Execution          *TourExecution         `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`
+	TourName           string                 `protobuf:"bytes,2,opt,name=tourName,proto3" json:"tourName,omitempty"`                      // Empty when the tour no longer exists
+	CompletedKeyPoints int32                  `protobuf:"varint,3,opt,name=completedKeyPoints,proto3" json:"completedKeyPoints,omitempty"` // Of the keypoints the tour has now
+	TotalKeyPoints     int32                  `protobuf:"varint,4,opt,name=totalKeyPoints,proto3" json:"totalKeyPoints,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
-func (x *StartExecutionRequest) Reset() {
-	*x = StartExecutionRequest{}
-	mi := &file_tour_proto_msgTypes[29]
+func (x *ExecutionListEntry) Reset() {
+	*x = ExecutionListEntry{}
+	mi := &file_tour_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartExecutionRequest) String() string {
+func (x *ExecutionListEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartExecutionRequest) ProtoMessage() {}
+func (*ExecutionListEntry) ProtoMessage() {}
 
-func (x *StartExecutionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[29]
+func (x *ExecutionListEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1905,63 +4715,62 @@ func (x *StartExecutionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartExecutionRequest.ProtoReflect.Descriptor instead.
-func (*StartExecutionRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use ExecutionListEntry.ProtoReflect.Descriptor instead.
+func (*ExecutionListEntry) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *StartExecutionRequest) GetTouristId() string {
+func (x *ExecutionListEntry) GetExecution() *TourExecution {
 	if x != nil {
-		return x.TouristId
+		return x.Execution
 	}
-	return ""
+	return nil
 }
 
-func (x *StartExecutionRequest) GetTourId() string {
+func (x *ExecutionListEntry) GetTourName() string {
 	if x != nil {
-		return x.TourId
+		return x.TourName
 	}
 	return ""
 }
 
-func (x *StartExecutionRequest) GetStartLatitude() float64 {
+func (x *ExecutionListEntry) GetCompletedKeyPoints() int32 {
 	if x != nil {
-		return x.StartLatitude
+		return x.CompletedKeyPoints
 	}
 	return 0
 }
 
-func (x *StartExecutionRequest) GetStartLongitude() float64 {
+func (x *ExecutionListEntry) GetTotalKeyPoints() int32 {
 	if x != nil {
-		return x.StartLongitude
+		return x.TotalKeyPoints
 	}
 	return 0
 }
 
-type ExecutionResponse struct {
+type GetExecutionTrackRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Execution     *TourExecution         `protobuf:"bytes,3,opt,name=execution,proto3" json:"execution,omitempty"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=executionId,proto3" json:"executionId,omitempty"`
+	TouristId     string                 `protobuf:"bytes,2,opt,name=touristId,proto3" json:"touristId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ExecutionResponse) Reset() {
-	*x = ExecutionResponse{}
-	mi := &file_tour_proto_msgTypes[30]
+func (x *GetExecutionTrackRequest) Reset() {
+	*x = GetExecutionTrackRequest{}
+	mi := &file_tour_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExecutionResponse) String() string {
+func (x *GetExecutionTrackRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExecutionResponse) ProtoMessage() {}
+func (*GetExecutionTrackRequest) ProtoMessage() {}
 
-func (x *ExecutionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[30]
+func (x *GetExecutionTrackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1972,62 +4781,55 @@ func (x *ExecutionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExecutionResponse.ProtoReflect.Descriptor instead.
-func (*ExecutionResponse) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{30}
-}
-
-func (x *ExecutionResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
+// Deprecated: Use GetExecutionTrackRequest.ProtoReflect.Descriptor instead.
+func (*GetExecutionTrackRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *ExecutionResponse) GetMessage() string {
+func (x *GetExecutionTrackRequest) GetExecutionId() string {
 	if x != nil {
-		return x.Message
+		return x.ExecutionId
 	}
 	return ""
 }
 
-func (x *ExecutionResponse) GetExecution() *TourExecution {
+func (x *GetExecutionTrackRequest) GetTouristId() string {
 	if x != nil {
-		return x.Execution
+		return x.TouristId
 	}
-	return nil
-}
-
-type TourExecution struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	TouristId          string                 `protobuf:"bytes,2,opt,name=touristId,proto3" json:"touristId,omitempty"`
-	TourId             string                 `protobuf:"bytes,3,opt,name=tourId,proto3" json:"tourId,omitempty"`
-	Status             string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // "active", "completed", "abandoned"
-	StartedAt          string                 `protobuf:"bytes,5,opt,name=startedAt,proto3" json:"startedAt,omitempty"`
-	CompletedAt        string                 `protobuf:"bytes,6,opt,name=completedAt,proto3" json:"completedAt,omitempty"`
-	LastActivity       string                 `protobuf:"bytes,7,opt,name=lastActivity,proto3" json:"lastActivity,omitempty"`
-	StartPosition      *Position              `protobuf:"bytes,8,opt,name=startPosition,proto3" json:"startPosition,omitempty"`
-	CompletedKeypoints []*CompletedKeyPoint   `protobuf:"bytes,9,rep,name=completedKeypoints,proto3" json:"completedKeypoints,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	return ""
 }
 
-func (x *TourExecution) Reset() {
-	*x = TourExecution{}
-	mi := &file_tour_proto_msgTypes[31]
+// The path walked during an execution, from the positions recorded while it
+// was active. Samples older than the retention period are no longer part of
+// it.
+type ExecutionTrackResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Success         bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message         string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Points          []*TrackPoint          `protobuf:"bytes,3,rep,name=points,proto3" json:"points,omitempty"`                     // Oldest first
+	Distance        float64                `protobuf:"fixed64,4,opt,name=distance,proto3" json:"distance,omitempty"`               // Distance walked in meters
+	DurationSeconds float64                `protobuf:"fixed64,5,opt,name=durationSeconds,proto3" json:"durationSeconds,omitempty"` // Time between the first and last point
+	AverageSpeed    float64                `protobuf:"fixed64,6,opt,name=averageSpeed,proto3" json:"averageSpeed,omitempty"`       // Meters per second, 0 with fewer than two points
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExecutionTrackResponse) Reset() {
+	*x = ExecutionTrackResponse{}
+	mi := &file_tour_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TourExecution) String() string {
+func (x *ExecutionTrackResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TourExecution) ProtoMessage() {}
+func (*ExecutionTrackResponse) ProtoMessage() {}
 
-func (x *TourExecution) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[31]
+func (x *ExecutionTrackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2038,97 +4840,143 @@ func (x *TourExecution) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TourExecution.ProtoReflect.Descriptor instead.
-func (*TourExecution) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use ExecutionTrackResponse.ProtoReflect.Descriptor instead.
+func (*ExecutionTrackResponse) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *TourExecution) GetId() string {
+func (x *ExecutionTrackResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Id
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *TourExecution) GetTouristId() string {
+func (x *ExecutionTrackResponse) GetMessage() string {
 	if x != nil {
-		return x.TouristId
+		return x.Message
 	}
 	return ""
 }
 
-func (x *TourExecution) GetTourId() string {
+func (x *ExecutionTrackResponse) GetPoints() []*TrackPoint {
 	if x != nil {
-		return x.TourId
+		return x.Points
 	}
-	return ""
+	return nil
 }
 
-func (x *TourExecution) GetStatus() string {
+func (x *ExecutionTrackResponse) GetDistance() float64 {
 	if x != nil {
-		return x.Status
+		return x.Distance
 	}
-	return ""
+	return 0
 }
 
-func (x *TourExecution) GetStartedAt() string {
+func (x *ExecutionTrackResponse) GetDurationSeconds() float64 {
 	if x != nil {
-		return x.StartedAt
+		return x.DurationSeconds
 	}
-	return ""
+	return 0
 }
 
-func (x *TourExecution) GetCompletedAt() string {
+func (x *ExecutionTrackResponse) GetAverageSpeed() float64 {
 	if x != nil {
-		return x.CompletedAt
+		return x.AverageSpeed
 	}
-	return ""
+	return 0
 }
 
-func (x *TourExecution) GetLastActivity() string {
+type TrackPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	RecordedAt    string                 `protobuf:"bytes,3,opt,name=recordedAt,proto3" json:"recordedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrackPoint) Reset() {
+	*x = TrackPoint{}
+	mi := &file_tour_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackPoint) ProtoMessage() {}
+
+func (x *TrackPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[68]
 	if x != nil {
-		return x.LastActivity
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *TourExecution) GetStartPosition() *Position {
+// Deprecated: Use TrackPoint.ProtoReflect.Descriptor instead.
+func (*TrackPoint) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *TrackPoint) GetLatitude() float64 {
 	if x != nil {
-		return x.StartPosition
+		return x.Latitude
 	}
-	return nil
+	return 0
 }
 
-func (x *TourExecution) GetCompletedKeypoints() []*CompletedKeyPoint {
+func (x *TrackPoint) GetLongitude() float64 {
 	if x != nil {
-		return x.CompletedKeypoints
+		return x.Longitude
 	}
-	return nil
+	return 0
 }
 
-type CompletedKeyPoint struct {
+func (x *TrackPoint) GetRecordedAt() string {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return ""
+}
+
+// ============ Reviews ============
+// Tourists may review a tour they bought once they completed it or were
+// recently active on it.
+type CreateReviewRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	KeypointId    string                 `protobuf:"bytes,1,opt,name=keypointId,proto3" json:"keypointId,omitempty"`
-	CompletedAt   string                 `protobuf:"bytes,2,opt,name=completedAt,proto3" json:"completedAt,omitempty"`
+	TourId        string                 `protobuf:"bytes,1,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	TouristId     string                 `protobuf:"bytes,2,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	Rating        int32                  `protobuf:"varint,3,opt,name=rating,proto3" json:"rating,omitempty"` // 1 to 5
+	Comment       string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	VisitDate     string                 `protobuf:"bytes,5,opt,name=visitDate,proto3" json:"visitDate,omitempty"` // YYYY-MM-DD
+	Images        []string               `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty"`       // Image URLs
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CompletedKeyPoint) Reset() {
-	*x = CompletedKeyPoint{}
-	mi := &file_tour_proto_msgTypes[32]
+func (x *CreateReviewRequest) Reset() {
+	*x = CreateReviewRequest{}
+	mi := &file_tour_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CompletedKeyPoint) String() string {
+func (x *CreateReviewRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CompletedKeyPoint) ProtoMessage() {}
+func (*CreateReviewRequest) ProtoMessage() {}
 
-func (x *CompletedKeyPoint) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[32]
+func (x *CreateReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2139,50 +4987,80 @@ func (x *CompletedKeyPoint) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CompletedKeyPoint.ProtoReflect.Descriptor instead.
-func (*CompletedKeyPoint) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use CreateReviewRequest.ProtoReflect.Descriptor instead.
+func (*CreateReviewRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *CreateReviewRequest) GetTourId() string {
+	if x != nil {
+		return x.TourId
+	}
+	return ""
+}
+
+func (x *CreateReviewRequest) GetTouristId() string {
+	if x != nil {
+		return x.TouristId
+	}
+	return ""
+}
+
+func (x *CreateReviewRequest) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
 }
 
-func (x *CompletedKeyPoint) GetKeypointId() string {
+func (x *CreateReviewRequest) GetComment() string {
 	if x != nil {
-		return x.KeypointId
+		return x.Comment
 	}
 	return ""
 }
 
-func (x *CompletedKeyPoint) GetCompletedAt() string {
+func (x *CreateReviewRequest) GetVisitDate() string {
 	if x != nil {
-		return x.CompletedAt
+		return x.VisitDate
 	}
 	return ""
 }
 
-type CheckProximityRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	ExecutionId      string                 `protobuf:"bytes,1,opt,name=executionId,proto3" json:"executionId,omitempty"`
-	TouristId        string                 `protobuf:"bytes,2,opt,name=touristId,proto3" json:"touristId,omitempty"`
-	CurrentLatitude  float64                `protobuf:"fixed64,3,opt,name=currentLatitude,proto3" json:"currentLatitude,omitempty"`
-	CurrentLongitude float64                `protobuf:"fixed64,4,opt,name=currentLongitude,proto3" json:"currentLongitude,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+func (x *CreateReviewRequest) GetImages() []string {
+	if x != nil {
+		return x.Images
+	}
+	return nil
 }
 
-func (x *CheckProximityRequest) Reset() {
-	*x = CheckProximityRequest{}
-	mi := &file_tour_proto_msgTypes[33]
+type UpdateReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReviewId      string                 `protobuf:"bytes,1,opt,name=reviewId,proto3" json:"reviewId,omitempty"`
+	TouristId     string                 `protobuf:"bytes,2,opt,name=touristId,proto3" json:"touristId,omitempty"` // For authorization
+	Rating        int32                  `protobuf:"varint,3,opt,name=rating,proto3" json:"rating,omitempty"`
+	Comment       string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	VisitDate     string                 `protobuf:"bytes,5,opt,name=visitDate,proto3" json:"visitDate,omitempty"`
+	Images        []string               `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty"` // Replaces the previous images
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateReviewRequest) Reset() {
+	*x = UpdateReviewRequest{}
+	mi := &file_tour_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckProximityRequest) String() string {
+func (x *UpdateReviewRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckProximityRequest) ProtoMessage() {}
+func (*UpdateReviewRequest) ProtoMessage() {}
 
-func (x *CheckProximityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[33]
+func (x *UpdateReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2193,65 +5071,75 @@ func (x *CheckProximityRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckProximityRequest.ProtoReflect.Descriptor instead.
-func (*CheckProximityRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use UpdateReviewRequest.ProtoReflect.Descriptor instead.
+func (*UpdateReviewRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *CheckProximityRequest) GetExecutionId() string {
+func (x *UpdateReviewRequest) GetReviewId() string {
 	if x != nil {
-		return x.ExecutionId
+		return x.ReviewId
 	}
 	return ""
 }
 
-func (x *CheckProximityRequest) GetTouristId() string {
+func (x *UpdateReviewRequest) GetTouristId() string {
 	if x != nil {
 		return x.TouristId
 	}
 	return ""
 }
 
-func (x *CheckProximityRequest) GetCurrentLatitude() float64 {
+func (x *UpdateReviewRequest) GetRating() int32 {
 	if x != nil {
-		return x.CurrentLatitude
+		return x.Rating
 	}
 	return 0
 }
 
-func (x *CheckProximityRequest) GetCurrentLongitude() float64 {
+func (x *UpdateReviewRequest) GetComment() string {
 	if x != nil {
-		return x.CurrentLongitude
+		return x.Comment
 	}
-	return 0
+	return ""
 }
 
-type ProximityResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	NearKeyPoint   bool                   `protobuf:"varint,3,opt,name=nearKeyPoint,proto3" json:"nearKeyPoint,omitempty"`
-	NearbyKeyPoint *KeyPoint              `protobuf:"bytes,4,opt,name=nearbyKeyPoint,proto3" json:"nearbyKeyPoint,omitempty"`
-	Distance       float64                `protobuf:"fixed64,5,opt,name=distance,proto3" json:"distance,omitempty"` // Distance in meters
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *UpdateReviewRequest) GetVisitDate() string {
+	if x != nil {
+		return x.VisitDate
+	}
+	return ""
 }
 
-func (x *ProximityResponse) Reset() {
-	*x = ProximityResponse{}
-	mi := &file_tour_proto_msgTypes[34]
+func (x *UpdateReviewRequest) GetImages() []string {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+type GetReviewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TourId        string                 `protobuf:"bytes,1,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewsRequest) Reset() {
+	*x = GetReviewsRequest{}
+	mi := &file_tour_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProximityResponse) String() string {
+func (x *GetReviewsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProximityResponse) ProtoMessage() {}
+func (*GetReviewsRequest) ProtoMessage() {}
 
-func (x *ProximityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[34]
+func (x *GetReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2262,69 +5150,102 @@ func (x *ProximityResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProximityResponse.ProtoReflect.Descriptor instead.
-func (*ProximityResponse) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use GetReviewsRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *ProximityResponse) GetSuccess() bool {
+func (x *GetReviewsRequest) GetTourId() string {
 	if x != nil {
-		return x.Success
+		return x.TourId
 	}
-	return false
+	return ""
 }
 
-func (x *ProximityResponse) GetMessage() string {
+type ReviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Review        *Review                `protobuf:"bytes,3,opt,name=review,proto3" json:"review,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewResponse) Reset() {
+	*x = ReviewResponse{}
+	mi := &file_tour_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewResponse) ProtoMessage() {}
+
+func (x *ReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[72]
 	if x != nil {
-		return x.Message
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ProximityResponse) GetNearKeyPoint() bool {
+// Deprecated: Use ReviewResponse.ProtoReflect.Descriptor instead.
+func (*ReviewResponse) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ReviewResponse) GetSuccess() bool {
 	if x != nil {
-		return x.NearKeyPoint
+		return x.Success
 	}
 	return false
 }
 
-func (x *ProximityResponse) GetNearbyKeyPoint() *KeyPoint {
+func (x *ReviewResponse) GetMessage() string {
 	if x != nil {
-		return x.NearbyKeyPoint
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
-func (x *ProximityResponse) GetDistance() float64 {
+func (x *ReviewResponse) GetReview() *Review {
 	if x != nil {
-		return x.Distance
+		return x.Review
 	}
-	return 0
+	return nil
 }
 
-type CompleteExecutionRequest struct {
+type ReviewsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ExecutionId   string                 `protobuf:"bytes,1,opt,name=executionId,proto3" json:"executionId,omitempty"`
-	TouristId     string                 `protobuf:"bytes,2,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Reviews       []*Review              `protobuf:"bytes,3,rep,name=reviews,proto3" json:"reviews,omitempty"` // Newest first
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CompleteExecutionRequest) Reset() {
-	*x = CompleteExecutionRequest{}
-	mi := &file_tour_proto_msgTypes[35]
+func (x *ReviewsResponse) Reset() {
+	*x = ReviewsResponse{}
+	mi := &file_tour_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CompleteExecutionRequest) String() string {
+func (x *ReviewsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CompleteExecutionRequest) ProtoMessage() {}
+func (*ReviewsResponse) ProtoMessage() {}
 
-func (x *CompleteExecutionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[35]
+func (x *ReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2335,48 +5256,62 @@ func (x *CompleteExecutionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CompleteExecutionRequest.ProtoReflect.Descriptor instead.
-func (*CompleteExecutionRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use ReviewsResponse.ProtoReflect.Descriptor instead.
+func (*ReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *CompleteExecutionRequest) GetExecutionId() string {
+func (x *ReviewsResponse) GetSuccess() bool {
 	if x != nil {
-		return x.ExecutionId
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *CompleteExecutionRequest) GetTouristId() string {
+func (x *ReviewsResponse) GetMessage() string {
 	if x != nil {
-		return x.TouristId
+		return x.Message
 	}
 	return ""
 }
 
-type AbandonExecutionRequest struct {
+func (x *ReviewsResponse) GetReviews() []*Review {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+type Review struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ExecutionId   string                 `protobuf:"bytes,1,opt,name=executionId,proto3" json:"executionId,omitempty"`
-	TouristId     string                 `protobuf:"bytes,2,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TourId        string                 `protobuf:"bytes,2,opt,name=tourId,proto3" json:"tourId,omitempty"`
+	TouristId     string                 `protobuf:"bytes,3,opt,name=touristId,proto3" json:"touristId,omitempty"`
+	Rating        int32                  `protobuf:"varint,4,opt,name=rating,proto3" json:"rating,omitempty"`
+	Comment       string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+	VisitDate     string                 `protobuf:"bytes,6,opt,name=visitDate,proto3" json:"visitDate,omitempty"`
+	Images        []string               `protobuf:"bytes,7,rep,name=images,proto3" json:"images,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,9,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AbandonExecutionRequest) Reset() {
-	*x = AbandonExecutionRequest{}
-	mi := &file_tour_proto_msgTypes[36]
+func (x *Review) Reset() {
+	*x = Review{}
+	mi := &file_tour_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AbandonExecutionRequest) String() string {
+func (x *Review) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AbandonExecutionRequest) ProtoMessage() {}
+func (*Review) ProtoMessage() {}
 
-func (x *AbandonExecutionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[36]
+func (x *Review) ProtoReflect() protoreflect.Message {
+	mi := &file_tour_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2387,73 +5322,70 @@ func (x *AbandonExecutionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AbandonExecutionRequest.ProtoReflect.Descriptor instead.
-func (*AbandonExecutionRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use Review.ProtoReflect.Descriptor instead.
+func (*Review) Descriptor() ([]byte, []int) {
+	return file_tour_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *AbandonExecutionRequest) GetExecutionId() string {
+func (x *Review) GetId() string {
 	if x != nil {
-		return x.ExecutionId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *AbandonExecutionRequest) GetTouristId() string {
+func (x *Review) GetTourId() string {
 	if x != nil {
-		return x.TouristId
+		return x.TourId
 	}
 	return ""
 }
 
-type GetExecutionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ExecutionId   string                 `protobuf:"bytes,1,opt,name=executionId,proto3" json:"executionId,omitempty"`
-	TouristId     string                 `protobuf:"bytes,2,opt,name=touristId,proto3" json:"touristId,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Review) GetTouristId() string {
+	if x != nil {
+		return x.TouristId
+	}
+	return ""
 }
 
-func (x *GetExecutionRequest) Reset() {
-	*x = GetExecutionRequest{}
-	mi := &file_tour_proto_msgTypes[37]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Review) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
 }
 
-func (x *GetExecutionRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Review) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
 }
 
-func (*GetExecutionRequest) ProtoMessage() {}
-
-func (x *GetExecutionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tour_proto_msgTypes[37]
+func (x *Review) GetVisitDate() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.VisitDate
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use GetExecutionRequest.ProtoReflect.Descriptor instead.
-func (*GetExecutionRequest) Descriptor() ([]byte, []int) {
-	return file_tour_proto_rawDescGZIP(), []int{37}
+func (x *Review) GetImages() []string {
+	if x != nil {
+		return x.Images
+	}
+	return nil
 }
 
-func (x *GetExecutionRequest) GetExecutionId() string {
+func (x *Review) GetCreatedAt() string {
 	if x != nil {
-		return x.ExecutionId
+		return x.CreatedAt
 	}
 	return ""
 }
 
-func (x *GetExecutionRequest) GetTouristId() string {
+func (x *Review) GetUpdatedAt() string {
 	if x != nil {
-		return x.TouristId
+		return x.UpdatedAt
 	}
 	return ""
 }
@@ -2463,7 +5395,7 @@ var File_tour_proto protoreflect.FileDescriptor
 const file_tour_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"tour.proto\x12\x04tour\"\x97\x01\n" +
+	"tour.proto\x12\x04tour\x1a google/protobuf/field_mask.proto\"\xe7\x01\n" +
 	"\x11CreateTourRequest\x12\x18\n" +
 	"\aguideId\x18\x01 \x01(\tR\aguideId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -2471,12 +5403,20 @@ const file_tour_proto_rawDesc = "" +
 	"\n" +
 	"difficulty\x18\x04 \x01(\tR\n" +
 	"difficulty\x12\x12\n" +
-	"\x04tags\x18\x05 \x03(\tR\x04tags\"b\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12$\n" +
+	"\rexecutionMode\x18\x06 \x01(\tR\rexecutionMode\x12(\n" +
+	"\x0fproximityRadius\x18\a \x01(\x01R\x0fproximityRadius\"\x93\x01\n" +
 	"\fTourResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1e\n" +
 	"\x04tour\x18\x03 \x01(\v2\n" +
-	".tour.TourR\x04tour\"\xaa\x02\n" +
+	".tour.TourR\x04tour\x12/\n" +
+	"\n" +
+	"violations\x18\x04 \x03(\v2\x0f.tour.ViolationR\n" +
+	"violations\"C\n" +
+	"\tViolation\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\"\xbb\x04\n" +
 	"\x04Tour\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
 	"\aguideId\x18\x02 \x01(\tR\aguideId\x12\x12\n" +
@@ -2491,24 +5431,118 @@ const file_tour_proto_rawDesc = "" +
 	"\visPublished\x18\t \x01(\bR\visPublished\x12 \n" +
 	"\vpublishedAt\x18\n" +
 	" \x01(\tR\vpublishedAt\x12\x1c\n" +
-	"\tcreatedAt\x18\v \x01(\tR\tcreatedAt\"O\n" +
+	"\tcreatedAt\x18\v \x01(\tR\tcreatedAt\x12\x1e\n" +
+	"\n" +
+	"archivedAt\x18\f \x01(\tR\n" +
+	"archivedAt\x12\x1a\n" +
+	"\blengthKm\x18\r \x01(\x01R\blengthKm\x12;\n" +
+	"\x0etransportTimes\x18\x0e \x03(\v2\x13.tour.TransportTimeR\x0etransportTimes\x12$\n" +
+	"\raverageRating\x18\x0f \x01(\x01R\raverageRating\x12 \n" +
+	"\vreviewCount\x18\x10 \x01(\x05R\vreviewCount\x12$\n" +
+	"\rexecutionMode\x18\x11 \x01(\tR\rexecutionMode\x12(\n" +
+	"\x0fproximityRadius\x18\x12 \x01(\x01R\x0fproximityRadius\"[\n" +
+	"\rTransportTime\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12\x18\n" +
+	"\aminutes\x18\x02 \x01(\x05R\aminutes\x12\x1c\n" +
+	"\tsuggested\x18\x03 \x01(\bR\tsuggested\"\x9b\x04\n" +
 	"\x0fGetToursRequest\x12$\n" +
 	"\rpublishedOnly\x18\x01 \x01(\bR\rpublishedOnly\x12\x16\n" +
-	"\x06userId\x18\x02 \x01(\tR\x06userId\"-\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\x12.\n" +
+	"\x12maxDurationMinutes\x18\x03 \x01(\x05R\x12maxDurationMinutes\x12$\n" +
+	"\rtransportMode\x18\x04 \x01(\tR\rtransportMode\x12\x1a\n" +
+	"\bpageSize\x18\x05 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\x06 \x01(\tR\tpageToken\x12\"\n" +
+	"\x04sort\x18\a \x01(\x0e2\x0e.tour.TourSortR\x04sort\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\b \x01(\tR\n" +
+	"difficulty\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\x12\"\n" +
+	"\fmatchAllTags\x18\n" +
+	" \x01(\bR\fmatchAllTags\x12\x1f\n" +
+	"\bminPrice\x18\v \x01(\x01H\x00R\bminPrice\x88\x01\x01\x12\x1f\n" +
+	"\bmaxPrice\x18\f \x01(\x01H\x01R\bmaxPrice\x88\x01\x01\x12\x18\n" +
+	"\aguideId\x18\r \x01(\tR\aguideId\x12\"\n" +
+	"\fcreatedAfter\x18\x0e \x01(\tR\fcreatedAfter\x12$\n" +
+	"\rcreatedBefore\x18\x0f \x01(\tR\rcreatedBeforeB\v\n" +
+	"\t_minPriceB\v\n" +
+	"\t_maxPrice\"-\n" +
 	"\x11GetMyToursRequest\x12\x18\n" +
 	"\aguideId\x18\x01 \x01(\tR\aguideId\"D\n" +
 	"\x12GetTourByIdRequest\x12\x16\n" +
 	"\x06tourId\x18\x01 \x01(\tR\x06tourId\x12\x16\n" +
-	"\x06userId\x18\x02 \x01(\tR\x06userId\"e\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\"\x8b\x01\n" +
 	"\rToursResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12 \n" +
 	"\x05tours\x18\x03 \x03(\v2\n" +
-	".tour.TourR\x05tours\"\\\n" +
+	".tour.TourR\x05tours\x12$\n" +
+	"\rnextPageToken\x18\x04 \x01(\tR\rnextPageToken\"\\\n" +
 	"\x12PublishTourRequest\x12\x16\n" +
 	"\x06tourId\x18\x01 \x01(\tR\x06tourId\x12\x18\n" +
 	"\aguideId\x18\x02 \x01(\tR\aguideId\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\"\xe2\x01\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\"\x8e\x03\n" +
+	"\x11UpdateTourRequest\x12\x16\n" +
+	"\x06tourId\x18\x01 \x01(\tR\x06tourId\x12\x18\n" +
+	"\aguideId\x18\x02 \x01(\tR\aguideId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x05 \x01(\tR\n" +
+	"difficulty\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x14\n" +
+	"\x05price\x18\a \x01(\x01R\x05price\x12:\n" +
+	"\n" +
+	"updateMask\x18\b \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12;\n" +
+	"\x0etransportTimes\x18\t \x03(\v2\x13.tour.TransportTimeR\x0etransportTimes\x12$\n" +
+	"\rexecutionMode\x18\n" +
+	" \x01(\tR\rexecutionMode\x12(\n" +
+	"\x0fproximityRadius\x18\v \x01(\x01R\x0fproximityRadius\"F\n" +
+	"\x12ArchiveTourRequest\x12\x16\n" +
+	"\x06tourId\x18\x01 \x01(\tR\x06tourId\x12\x18\n" +
+	"\aguideId\x18\x02 \x01(\tR\aguideId\"I\n" +
+	"\x15ReactivateTourRequest\x12\x16\n" +
+	"\x06tourId\x18\x01 \x01(\tR\x06tourId\x12\x18\n" +
+	"\aguideId\x18\x02 \x01(\tR\aguideId\"\x86\x01\n" +
+	"\x18SearchToursNearbyRequest\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\bradiusKm\x18\x03 \x01(\x01R\bradiusKm\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"q\n" +
+	"\x13NearbyToursResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12&\n" +
+	"\x05tours\x18\x03 \x03(\v2\x10.tour.NearbyTourR\x05tours\"L\n" +
+	"\n" +
+	"NearbyTour\x12\x1e\n" +
+	"\x04tour\x18\x01 \x01(\v2\n" +
+	".tour.TourR\x04tour\x12\x1e\n" +
+	"\n" +
+	"distanceKm\x18\x02 \x01(\x01R\n" +
+	"distanceKm\"d\n" +
+	"\x12SearchToursRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1a\n" +
+	"\bpageSize\x18\x02 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\x03 \x01(\tR\tpageToken\"\xa1\x01\n" +
+	"\x13SearchToursResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\aresults\x18\x03 \x03(\v2\x16.tour.TourSearchResultR\aresults\x12$\n" +
+	"\rnextPageToken\x18\x04 \x01(\tR\rnextPageToken\"s\n" +
+	"\x10TourSearchResult\x12\x1e\n" +
+	"\x04tour\x18\x01 \x01(\v2\n" +
+	".tour.TourR\x04tour\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12)\n" +
+	"\bsnippets\x18\x03 \x03(\v2\r.tour.SnippetR\bsnippets\"d\n" +
+	"\aSnippet\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12/\n" +
+	"\n" +
+	"highlights\x18\x03 \x03(\v2\x0f.tour.HighlightR\n" +
+	"highlights\"3\n" +
+	"\tHighlight\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\x05R\x03end\"\x8c\x02\n" +
 	"\x12AddKeyPointRequest\x12\x16\n" +
 	"\x06tourId\x18\x01 \x01(\tR\x06tourId\x12\x18\n" +
 	"\aguideId\x18\x02 \x01(\tR\aguideId\x12\x1a\n" +
@@ -2517,11 +5551,12 @@ const file_tour_proto_rawDesc = "" +
 	"\x04name\x18\x05 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x14\n" +
 	"\x05image\x18\a \x01(\tR\x05image\x12\x14\n" +
-	"\x05order\x18\b \x01(\x05R\x05order\"r\n" +
+	"\x05order\x18\b \x01(\x05R\x05order\x12(\n" +
+	"\x0fproximityRadius\x18\t \x01(\x01R\x0fproximityRadius\"r\n" +
 	"\x10KeyPointResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12*\n" +
-	"\bkeyPoint\x18\x03 \x01(\v2\x0e.tour.KeyPointR\bkeyPoint\"\xce\x01\n" +
+	"\bkeyPoint\x18\x03 \x01(\v2\x0e.tour.KeyPointR\bkeyPoint\"\xb4\x02\n" +
 	"\bKeyPoint\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06tourId\x18\x02 \x01(\tR\x06tourId\x12\x1a\n" +
@@ -2530,7 +5565,10 @@ const file_tour_proto_rawDesc = "" +
 	"\x04name\x18\x05 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x14\n" +
 	"\x05image\x18\a \x01(\tR\x05image\x12\x14\n" +
-	"\x05order\x18\b \x01(\x05R\x05order\"E\n" +
+	"\x05order\x18\b \x01(\x05R\x05order\x12(\n" +
+	"\x0fproximityRadius\x18\t \x01(\x01R\x0fproximityRadius\x12:\n" +
+	"\x18effectiveProximityRadius\x18\n" +
+	" \x01(\x01R\x18effectiveProximityRadius\"E\n" +
 	"\x13GetKeyPointsRequest\x12\x16\n" +
 	"\x06tourId\x18\x01 \x01(\tR\x06tourId\x12\x16\n" +
 	"\x06userId\x18\x02 \x01(\tR\x06userId\"\x97\x01\n" +
@@ -2538,7 +5576,7 @@ const file_tour_proto_rawDesc = "" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
 	"\tkeyPoints\x18\x03 \x03(\v2\x0e.tour.KeyPointR\tkeyPoints\x12 \n" +
-	"\visPurchased\x18\x04 \x01(\bR\visPurchased\"\xef\x01\n" +
+	"\visPurchased\x18\x04 \x01(\bR\visPurchased\"\x99\x02\n" +
 	"\x15UpdateKeyPointRequest\x12\x1e\n" +
 	"\n" +
 	"keyPointId\x18\x01 \x01(\tR\n" +
@@ -2549,13 +5587,48 @@ const file_tour_proto_rawDesc = "" +
 	"\tlongitude\x18\x05 \x01(\x01R\tlongitude\x12\x12\n" +
 	"\x04name\x18\x06 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\a \x01(\tR\vdescription\x12\x14\n" +
-	"\x05image\x18\b \x01(\tR\x05image\"i\n" +
+	"\x05image\x18\b \x01(\tR\x05image\x12(\n" +
+	"\x0fproximityRadius\x18\t \x01(\x01R\x0fproximityRadius\"i\n" +
 	"\x15DeleteKeyPointRequest\x12\x1e\n" +
 	"\n" +
 	"keyPointId\x18\x01 \x01(\tR\n" +
 	"keyPointId\x12\x16\n" +
 	"\x06tourId\x18\x02 \x01(\tR\x06tourId\x12\x18\n" +
-	"\aguideId\x18\x03 \x01(\tR\aguideId\"L\n" +
+	"\aguideId\x18\x03 \x01(\tR\aguideId\"m\n" +
+	"\x17ReorderKeyPointsRequest\x12\x16\n" +
+	"\x06tourId\x18\x01 \x01(\tR\x06tourId\x12\x18\n" +
+	"\aguideId\x18\x02 \x01(\tR\aguideId\x12 \n" +
+	"\vkeyPointIds\x18\x03 \x03(\tR\vkeyPointIds\"\x8a\x01\n" +
+	"\x16ImportKeyPointsRequest\x12\x16\n" +
+	"\x06tourId\x18\x01 \x01(\tR\x06tourId\x12\x18\n" +
+	"\aguideId\x18\x02 \x01(\tR\aguideId\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\x12\x12\n" +
+	"\x04mode\x18\x05 \x01(\tR\x04mode\"\xcc\x01\n" +
+	"\x17ImportKeyPointsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12$\n" +
+	"\rimportedCount\x18\x03 \x01(\x05R\rimportedCount\x12$\n" +
+	"\rrejectedCount\x18\x04 \x01(\x05R\rrejectedCount\x121\n" +
+	"\bfeatures\x18\x05 \x03(\v2\x15.tour.ImportedFeatureR\bfeatures\"\x9b\x01\n" +
+	"\x0fImportedFeature\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bimported\x18\x03 \x01(\bR\bimported\x12*\n" +
+	"\bkeyPoint\x18\x04 \x01(\v2\x0e.tour.KeyPointR\bkeyPoint\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"[\n" +
+	"\x11ExportTourRequest\x12\x16\n" +
+	"\x06tourId\x18\x01 \x01(\tR\x06tourId\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\"\xe2\x01\n" +
+	"\x12ExportTourResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
+	"\bfileName\x18\x03 \x01(\tR\bfileName\x12 \n" +
+	"\vcontentType\x18\x04 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\fR\x04data\x12$\n" +
+	"\rkeyPointCount\x18\x06 \x01(\x05R\rkeyPointCount\x12 \n" +
+	"\visPurchased\x18\a \x01(\bR\visPurchased\"L\n" +
 	"\x16DeleteKeyPointResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"o\n" +
@@ -2595,13 +5668,15 @@ const file_tour_proto_rawDesc = "" +
 	"\bCartItem\x12\x16\n" +
 	"\x06tourId\x18\x01 \x01(\tR\x06tourId\x12\x1a\n" +
 	"\btourName\x18\x02 \x01(\tR\btourName\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\"/\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\"W\n" +
 	"\x0fCheckoutRequest\x12\x1c\n" +
-	"\ttouristId\x18\x01 \x01(\tR\ttouristId\"s\n" +
+	"\ttouristId\x18\x01 \x01(\tR\ttouristId\x12&\n" +
+	"\x0eidempotencyKey\x18\x02 \x01(\tR\x0eidempotencyKey\"\x8f\x01\n" +
 	"\x10CheckoutResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12+\n" +
-	"\x06tokens\x18\x03 \x03(\v2\x13.tour.PurchaseTokenR\x06tokens\"_\n" +
+	"\x06tokens\x18\x03 \x03(\v2\x13.tour.PurchaseTokenR\x06tokens\x12\x1a\n" +
+	"\breplayed\x18\x04 \x01(\bR\breplayed\"_\n" +
 	"\rPurchaseToken\x12\x16\n" +
 	"\x06tourId\x18\x01 \x01(\tR\x06tourId\x12\x14\n" +
 	"\x05token\x18\x02 \x01(\tR\x05token\x12 \n" +
@@ -2610,11 +5685,18 @@ const file_tour_proto_rawDesc = "" +
 	"\ttouristId\x18\x01 \x01(\tR\ttouristId\x12\x16\n" +
 	"\x06tourId\x18\x02 \x01(\tR\x06tourId\x12$\n" +
 	"\rstartLatitude\x18\x03 \x01(\x01R\rstartLatitude\x12&\n" +
-	"\x0estartLongitude\x18\x04 \x01(\x01R\x0estartLongitude\"z\n" +
+	"\x0estartLongitude\x18\x04 \x01(\x01R\x0estartLongitude\"\xad\x01\n" +
 	"\x11ExecutionResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x121\n" +
-	"\texecution\x18\x03 \x01(\v2\x13.tour.TourExecutionR\texecution\"\xd0\x02\n" +
+	"\texecution\x18\x03 \x01(\v2\x13.tour.TourExecutionR\texecution\x121\n" +
+	"\asummary\x18\x04 \x01(\v2\x17.tour.CompletionSummaryR\asummary\"\xe5\x01\n" +
+	"\x11CompletionSummary\x12.\n" +
+	"\x12completedKeyPoints\x18\x01 \x01(\x05R\x12completedKeyPoints\x12&\n" +
+	"\x0etotalKeyPoints\x18\x02 \x01(\x05R\x0etotalKeyPoints\x122\n" +
+	"\x14completionPercentage\x18\x03 \x01(\x01R\x14completionPercentage\x12(\n" +
+	"\x0fdurationSeconds\x18\x04 \x01(\x01R\x0fdurationSeconds\x12\x1a\n" +
+	"\bdistance\x18\x05 \x01(\x01R\bdistance\"\x98\x03\n" +
 	"\rTourExecution\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
 	"\ttouristId\x18\x02 \x01(\tR\ttouristId\x12\x16\n" +
@@ -2624,7 +5706,10 @@ const file_tour_proto_rawDesc = "" +
 	"\vcompletedAt\x18\x06 \x01(\tR\vcompletedAt\x12\"\n" +
 	"\flastActivity\x18\a \x01(\tR\flastActivity\x124\n" +
 	"\rstartPosition\x18\b \x01(\v2\x0e.tour.PositionR\rstartPosition\x12G\n" +
-	"\x12completedKeypoints\x18\t \x03(\v2\x17.tour.CompletedKeyPointR\x12completedKeypoints\"U\n" +
+	"\x12completedKeypoints\x18\t \x03(\v2\x17.tour.CompletedKeyPointR\x12completedKeypoints\x12\x12\n" +
+	"\x04mode\x18\n" +
+	" \x01(\tR\x04mode\x122\n" +
+	"\x14completionPercentage\x18\v \x01(\x01R\x14completionPercentage\"U\n" +
 	"\x11CompletedKeyPoint\x12\x1e\n" +
 	"\n" +
 	"keypointId\x18\x01 \x01(\tR\n" +
@@ -2634,23 +5719,128 @@ const file_tour_proto_rawDesc = "" +
 	"\vexecutionId\x18\x01 \x01(\tR\vexecutionId\x12\x1c\n" +
 	"\ttouristId\x18\x02 \x01(\tR\ttouristId\x12(\n" +
 	"\x0fcurrentLatitude\x18\x03 \x01(\x01R\x0fcurrentLatitude\x12*\n" +
-	"\x10currentLongitude\x18\x04 \x01(\x01R\x10currentLongitude\"\xbf\x01\n" +
+	"\x10currentLongitude\x18\x04 \x01(\x01R\x10currentLongitude\"\x80\x03\n" +
 	"\x11ProximityResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\"\n" +
 	"\fnearKeyPoint\x18\x03 \x01(\bR\fnearKeyPoint\x126\n" +
 	"\x0enearbyKeyPoint\x18\x04 \x01(\v2\x0e.tour.KeyPointR\x0enearbyKeyPoint\x12\x1a\n" +
-	"\bdistance\x18\x05 \x01(\x01R\bdistance\"Z\n" +
+	"\bdistance\x18\x05 \x01(\x01R\bdistance\x122\n" +
+	"\fnextKeyPoint\x18\x06 \x01(\v2\x0e.tour.KeyPointR\fnextKeyPoint\x122\n" +
+	"\x14nextKeyPointDistance\x18\a \x01(\x01R\x14nextKeyPointDistance\x12$\n" +
+	"\rtourCompleted\x18\b \x01(\bR\rtourCompleted\x121\n" +
+	"\asummary\x18\t \x01(\v2\x17.tour.CompletionSummaryR\asummary\"\x91\x01\n" +
+	"\x15TrackExecutionRequest\x12 \n" +
+	"\vexecutionId\x18\x01 \x01(\tR\vexecutionId\x12\x1c\n" +
+	"\ttouristId\x18\x02 \x01(\tR\ttouristId\x12\x1a\n" +
+	"\blatitude\x18\x03 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x04 \x01(\x01R\tlongitude\"\xf3\x01\n" +
+	"\x13TrackExecutionEvent\x12F\n" +
+	"\x0fkeyPointReached\x18\x01 \x01(\v2\x1a.tour.KeyPointReachedEventH\x00R\x0fkeyPointReached\x12@\n" +
+	"\rtourCompleted\x18\x02 \x01(\v2\x18.tour.TourCompletedEventH\x00R\rtourCompleted\x12I\n" +
+	"\x10executionExpired\x18\x03 \x01(\v2\x1b.tour.ExecutionExpiredEventH\x00R\x10executionExpiredB\a\n" +
+	"\x05event\"\x8e\x02\n" +
+	"\x14KeyPointReachedEvent\x12*\n" +
+	"\bkeyPoint\x18\x01 \x01(\v2\x0e.tour.KeyPointR\bkeyPoint\x12\x1a\n" +
+	"\bdistance\x18\x02 \x01(\x01R\bdistance\x12&\n" +
+	"\x0ecompletedCount\x18\x03 \x01(\x05R\x0ecompletedCount\x12\x1e\n" +
+	"\n" +
+	"totalCount\x18\x04 \x01(\x05R\n" +
+	"totalCount\x122\n" +
+	"\fnextKeyPoint\x18\x05 \x01(\v2\x0e.tour.KeyPointR\fnextKeyPoint\x122\n" +
+	"\x14nextKeyPointDistance\x18\x06 \x01(\x01R\x14nextKeyPointDistance\"z\n" +
+	"\x12TourCompletedEvent\x121\n" +
+	"\texecution\x18\x01 \x01(\v2\x13.tour.TourExecutionR\texecution\x121\n" +
+	"\asummary\x18\x02 \x01(\v2\x17.tour.CompletionSummaryR\asummary\"b\n" +
+	"\x15ExecutionExpiredEvent\x121\n" +
+	"\texecution\x18\x01 \x01(\v2\x13.tour.TourExecutionR\texecution\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"t\n" +
 	"\x18CompleteExecutionRequest\x12 \n" +
 	"\vexecutionId\x18\x01 \x01(\tR\vexecutionId\x12\x1c\n" +
-	"\ttouristId\x18\x02 \x01(\tR\ttouristId\"Y\n" +
+	"\ttouristId\x18\x02 \x01(\tR\ttouristId\x12\x18\n" +
+	"\apartial\x18\x03 \x01(\bR\apartial\"Y\n" +
 	"\x17AbandonExecutionRequest\x12 \n" +
 	"\vexecutionId\x18\x01 \x01(\tR\vexecutionId\x12\x1c\n" +
 	"\ttouristId\x18\x02 \x01(\tR\ttouristId\"U\n" +
 	"\x13GetExecutionRequest\x12 \n" +
 	"\vexecutionId\x18\x01 \x01(\tR\vexecutionId\x12\x1c\n" +
-	"\ttouristId\x18\x02 \x01(\tR\ttouristId2\xb2\n" +
+	"\ttouristId\x18\x02 \x01(\tR\ttouristId\"\xe9\x01\n" +
+	"\x15ListExecutionsRequest\x12\x1c\n" +
+	"\ttouristId\x18\x01 \x01(\tR\ttouristId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06tourId\x18\x03 \x01(\tR\x06tourId\x12\"\n" +
+	"\fstartedAfter\x18\x04 \x01(\tR\fstartedAfter\x12$\n" +
+	"\rstartedBefore\x18\x05 \x01(\tR\rstartedBefore\x12\x1a\n" +
+	"\bpageSize\x18\x06 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\a \x01(\tR\tpageToken\"\xac\x01\n" +
+	"\x16ListExecutionsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x128\n" +
+	"\n" +
+	"executions\x18\x03 \x03(\v2\x18.tour.ExecutionListEntryR\n" +
+	"executions\x12$\n" +
+	"\rnextPageToken\x18\x04 \x01(\tR\rnextPageToken\"\xbb\x01\n" +
+	"\x12ExecutionListEntry\x121\n" +
+	"\texecution\x18\x01 \x01(\v2\x13.tour.TourExecutionR\texecution\x12\x1a\n" +
+	"\btourName\x18\x02 \x01(\tR\btourName\x12.\n" +
+	"\x12completedKeyPoints\x18\x03 \x01(\x05R\x12completedKeyPoints\x12&\n" +
+	"\x0etotalKeyPoints\x18\x04 \x01(\x05R\x0etotalKeyPoints\"Z\n" +
+	"\x18GetExecutionTrackRequest\x12 \n" +
+	"\vexecutionId\x18\x01 \x01(\tR\vexecutionId\x12\x1c\n" +
+	"\ttouristId\x18\x02 \x01(\tR\ttouristId\"\xe0\x01\n" +
+	"\x16ExecutionTrackResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12(\n" +
+	"\x06points\x18\x03 \x03(\v2\x10.tour.TrackPointR\x06points\x12\x1a\n" +
+	"\bdistance\x18\x04 \x01(\x01R\bdistance\x12(\n" +
+	"\x0fdurationSeconds\x18\x05 \x01(\x01R\x0fdurationSeconds\x12\"\n" +
+	"\faverageSpeed\x18\x06 \x01(\x01R\faverageSpeed\"f\n" +
+	"\n" +
+	"TrackPoint\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x1e\n" +
 	"\n" +
+	"recordedAt\x18\x03 \x01(\tR\n" +
+	"recordedAt\"\xb3\x01\n" +
+	"\x13CreateReviewRequest\x12\x16\n" +
+	"\x06tourId\x18\x01 \x01(\tR\x06tourId\x12\x1c\n" +
+	"\ttouristId\x18\x02 \x01(\tR\ttouristId\x12\x16\n" +
+	"\x06rating\x18\x03 \x01(\x05R\x06rating\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\x12\x1c\n" +
+	"\tvisitDate\x18\x05 \x01(\tR\tvisitDate\x12\x16\n" +
+	"\x06images\x18\x06 \x03(\tR\x06images\"\xb7\x01\n" +
+	"\x13UpdateReviewRequest\x12\x1a\n" +
+	"\breviewId\x18\x01 \x01(\tR\breviewId\x12\x1c\n" +
+	"\ttouristId\x18\x02 \x01(\tR\ttouristId\x12\x16\n" +
+	"\x06rating\x18\x03 \x01(\x05R\x06rating\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\x12\x1c\n" +
+	"\tvisitDate\x18\x05 \x01(\tR\tvisitDate\x12\x16\n" +
+	"\x06images\x18\x06 \x03(\tR\x06images\"+\n" +
+	"\x11GetReviewsRequest\x12\x16\n" +
+	"\x06tourId\x18\x01 \x01(\tR\x06tourId\"j\n" +
+	"\x0eReviewResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12$\n" +
+	"\x06review\x18\x03 \x01(\v2\f.tour.ReviewR\x06review\"m\n" +
+	"\x0fReviewsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12&\n" +
+	"\areviews\x18\x03 \x03(\v2\f.tour.ReviewR\areviews\"\xf2\x01\n" +
+	"\x06Review\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06tourId\x18\x02 \x01(\tR\x06tourId\x12\x1c\n" +
+	"\ttouristId\x18\x03 \x01(\tR\ttouristId\x12\x16\n" +
+	"\x06rating\x18\x04 \x01(\x05R\x06rating\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\x12\x1c\n" +
+	"\tvisitDate\x18\x06 \x01(\tR\tvisitDate\x12\x16\n" +
+	"\x06images\x18\a \x03(\tR\x06images\x12\x1c\n" +
+	"\tcreatedAt\x18\b \x01(\tR\tcreatedAt\x12\x1c\n" +
+	"\tupdatedAt\x18\t \x01(\tR\tupdatedAt*g\n" +
+	"\bTourSort\x12\x14\n" +
+	"\x10TOUR_SORT_NEWEST\x10\x00\x12\x17\n" +
+	"\x13TOUR_SORT_PRICE_ASC\x10\x01\x12\x18\n" +
+	"\x14TOUR_SORT_PRICE_DESC\x10\x02\x12\x12\n" +
+	"\x0eTOUR_SORT_NAME\x10\x032\x8c\x12\n" +
 	"\vTourService\x129\n" +
 	"\n" +
 	"CreateTour\x12\x17.tour.CreateTourRequest\x1a\x12.tour.TourResponse\x126\n" +
@@ -2658,11 +5848,21 @@ const file_tour_proto_rawDesc = "" +
 	"\vGetTourById\x12\x18.tour.GetTourByIdRequest\x1a\x12.tour.TourResponse\x12;\n" +
 	"\vPublishTour\x12\x18.tour.PublishTourRequest\x1a\x12.tour.TourResponse\x12:\n" +
 	"\n" +
-	"GetMyTours\x12\x17.tour.GetMyToursRequest\x1a\x13.tour.ToursResponse\x12?\n" +
+	"GetMyTours\x12\x17.tour.GetMyToursRequest\x1a\x13.tour.ToursResponse\x129\n" +
+	"\n" +
+	"UpdateTour\x12\x17.tour.UpdateTourRequest\x1a\x12.tour.TourResponse\x12;\n" +
+	"\vArchiveTour\x12\x18.tour.ArchiveTourRequest\x1a\x12.tour.TourResponse\x12A\n" +
+	"\x0eReactivateTour\x12\x1b.tour.ReactivateTourRequest\x1a\x12.tour.TourResponse\x12N\n" +
+	"\x11SearchToursNearby\x12\x1e.tour.SearchToursNearbyRequest\x1a\x19.tour.NearbyToursResponse\x12B\n" +
+	"\vSearchTours\x12\x18.tour.SearchToursRequest\x1a\x19.tour.SearchToursResponse\x12?\n" +
 	"\vAddKeyPoint\x12\x18.tour.AddKeyPointRequest\x1a\x16.tour.KeyPointResponse\x12B\n" +
 	"\fGetKeyPoints\x12\x19.tour.GetKeyPointsRequest\x1a\x17.tour.KeyPointsResponse\x12E\n" +
 	"\x0eUpdateKeyPoint\x12\x1b.tour.UpdateKeyPointRequest\x1a\x16.tour.KeyPointResponse\x12K\n" +
-	"\x0eDeleteKeyPoint\x12\x1b.tour.DeleteKeyPointRequest\x1a\x1c.tour.DeleteKeyPointResponse\x12E\n" +
+	"\x0eDeleteKeyPoint\x12\x1b.tour.DeleteKeyPointRequest\x1a\x1c.tour.DeleteKeyPointResponse\x12J\n" +
+	"\x10ReorderKeyPoints\x12\x1d.tour.ReorderKeyPointsRequest\x1a\x17.tour.KeyPointsResponse\x12N\n" +
+	"\x0fImportKeyPoints\x12\x1c.tour.ImportKeyPointsRequest\x1a\x1d.tour.ImportKeyPointsResponse\x12?\n" +
+	"\n" +
+	"ExportTour\x12\x17.tour.ExportTourRequest\x1a\x18.tour.ExportTourResponse\x12E\n" +
 	"\x0eUpdatePosition\x12\x1b.tour.UpdatePositionRequest\x1a\x16.tour.PositionResponse\x12F\n" +
 	"\x12GetCurrentPosition\x12\x18.tour.GetPositionRequest\x1a\x16.tour.PositionResponse\x127\n" +
 	"\tAddToCart\x12\x16.tour.AddToCartRequest\x1a\x12.tour.CartResponse\x12A\n" +
@@ -2673,7 +5873,14 @@ const file_tour_proto_rawDesc = "" +
 	"\x0eCheckProximity\x12\x1b.tour.CheckProximityRequest\x1a\x17.tour.ProximityResponse\x12G\n" +
 	"\fCompleteTour\x12\x1e.tour.CompleteExecutionRequest\x1a\x17.tour.ExecutionResponse\x12E\n" +
 	"\vAbandonTour\x12\x1d.tour.AbandonExecutionRequest\x1a\x17.tour.ExecutionResponse\x12B\n" +
-	"\fGetExecution\x12\x19.tour.GetExecutionRequest\x1a\x17.tour.ExecutionResponseB*Z(tourism-microservices/tour-service/protob\x06proto3"
+	"\fGetExecution\x12\x19.tour.GetExecutionRequest\x1a\x17.tour.ExecutionResponse\x12L\n" +
+	"\x0eTrackExecution\x12\x1b.tour.TrackExecutionRequest\x1a\x19.tour.TrackExecutionEvent(\x010\x01\x12Q\n" +
+	"\x11GetExecutionTrack\x12\x1e.tour.GetExecutionTrackRequest\x1a\x1c.tour.ExecutionTrackResponse\x12K\n" +
+	"\x0eListExecutions\x12\x1b.tour.ListExecutionsRequest\x1a\x1c.tour.ListExecutionsResponse\x12?\n" +
+	"\fCreateReview\x12\x19.tour.CreateReviewRequest\x1a\x14.tour.ReviewResponse\x12<\n" +
+	"\n" +
+	"GetReviews\x12\x17.tour.GetReviewsRequest\x1a\x15.tour.ReviewsResponse\x12?\n" +
+	"\fUpdateReview\x12\x19.tour.UpdateReviewRequest\x1a\x14.tour.ReviewResponseB?Z(tourism-microservices/tour-service/proto\xaa\x02\x12TourService.Protosb\x06proto3"
 
 var (
 	file_tour_proto_rawDescOnce sync.Once
@@ -2687,105 +5894,202 @@ func file_tour_proto_rawDescGZIP() []byte {
 	return file_tour_proto_rawDescData
 }
 
-var file_tour_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
+var file_tour_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_tour_proto_msgTypes = make([]protoimpl.MessageInfo, 75)
 var file_tour_proto_goTypes = []any{
-	(*CreateTourRequest)(nil),        // 0: tour.CreateTourRequest
-	(*TourResponse)(nil),             // 1: tour.TourResponse
-	(*Tour)(nil),                     // 2: tour.Tour
-	(*GetToursRequest)(nil),          // 3: tour.GetToursRequest
-	(*GetMyToursRequest)(nil),        // 4: tour.GetMyToursRequest
-	(*GetTourByIdRequest)(nil),       // 5: tour.GetTourByIdRequest
-	(*ToursResponse)(nil),            // 6: tour.ToursResponse
-	(*PublishTourRequest)(nil),       // 7: tour.PublishTourRequest
-	(*AddKeyPointRequest)(nil),       // 8: tour.AddKeyPointRequest
-	(*KeyPointResponse)(nil),         // 9: tour.KeyPointResponse
-	(*KeyPoint)(nil),                 // 10: tour.KeyPoint
-	(*GetKeyPointsRequest)(nil),      // 11: tour.GetKeyPointsRequest
-	(*KeyPointsResponse)(nil),        // 12: tour.KeyPointsResponse
-	(*UpdateKeyPointRequest)(nil),    // 13: tour.UpdateKeyPointRequest
-	(*DeleteKeyPointRequest)(nil),    // 14: tour.DeleteKeyPointRequest
-	(*DeleteKeyPointResponse)(nil),   // 15: tour.DeleteKeyPointResponse
-	(*UpdatePositionRequest)(nil),    // 16: tour.UpdatePositionRequest
-	(*GetPositionRequest)(nil),       // 17: tour.GetPositionRequest
-	(*PositionResponse)(nil),         // 18: tour.PositionResponse
-	(*Position)(nil),                 // 19: tour.Position
-	(*AddToCartRequest)(nil),         // 20: tour.AddToCartRequest
-	(*RemoveFromCartRequest)(nil),    // 21: tour.RemoveFromCartRequest
-	(*GetCartRequest)(nil),           // 22: tour.GetCartRequest
-	(*CartResponse)(nil),             // 23: tour.CartResponse
-	(*ShoppingCart)(nil),             // 24: tour.ShoppingCart
-	(*CartItem)(nil),                 // 25: tour.CartItem
-	(*CheckoutRequest)(nil),          // 26: tour.CheckoutRequest
-	(*CheckoutResponse)(nil),         // 27: tour.CheckoutResponse
-	(*PurchaseToken)(nil),            // 28: tour.PurchaseToken
-	(*StartExecutionRequest)(nil),    // 29: tour.StartExecutionRequest
-	(*ExecutionResponse)(nil),        // 30: tour.ExecutionResponse
-	(*TourExecution)(nil),            // 31: tour.TourExecution
-	(*CompletedKeyPoint)(nil),        // 32: tour.CompletedKeyPoint
-	(*CheckProximityRequest)(nil),    // 33: tour.CheckProximityRequest
-	(*ProximityResponse)(nil),        // 34: tour.ProximityResponse
-	(*CompleteExecutionRequest)(nil), // 35: tour.CompleteExecutionRequest
-	(*AbandonExecutionRequest)(nil),  // 36: tour.AbandonExecutionRequest
-	(*GetExecutionRequest)(nil),      // 37: tour.GetExecutionRequest
+	(TourSort)(0),                    // 0: tour.TourSort
+	(*CreateTourRequest)(nil),        // 1: tour.CreateTourRequest
+	(*TourResponse)(nil),             // 2: tour.TourResponse
+	(*Violation)(nil),                // 3: tour.Violation
+	(*Tour)(nil),                     // 4: tour.Tour
+	(*TransportTime)(nil),            // 5: tour.TransportTime
+	(*GetToursRequest)(nil),          // 6: tour.GetToursRequest
+	(*GetMyToursRequest)(nil),        // 7: tour.GetMyToursRequest
+	(*GetTourByIdRequest)(nil),       // 8: tour.GetTourByIdRequest
+	(*ToursResponse)(nil),            // 9: tour.ToursResponse
+	(*PublishTourRequest)(nil),       // 10: tour.PublishTourRequest
+	(*UpdateTourRequest)(nil),        // 11: tour.UpdateTourRequest
+	(*ArchiveTourRequest)(nil),       // 12: tour.ArchiveTourRequest
+	(*ReactivateTourRequest)(nil),    // 13: tour.ReactivateTourRequest
+	(*SearchToursNearbyRequest)(nil), // 14: tour.SearchToursNearbyRequest
+	(*NearbyToursResponse)(nil),      // 15: tour.NearbyToursResponse
+	(*NearbyTour)(nil),               // 16: tour.NearbyTour
+	(*SearchToursRequest)(nil),       // 17: tour.SearchToursRequest
+	(*SearchToursResponse)(nil),      // 18: tour.SearchToursResponse
+	(*TourSearchResult)(nil),         // 19: tour.TourSearchResult
+	(*Snippet)(nil),                  // 20: tour.Snippet
+	(*Highlight)(nil),                // 21: tour.Highlight
+	(*AddKeyPointRequest)(nil),       // 22: tour.AddKeyPointRequest
+	(*KeyPointResponse)(nil),         // 23: tour.KeyPointResponse
+	(*KeyPoint)(nil),                 // 24: tour.KeyPoint
+	(*GetKeyPointsRequest)(nil),      // 25: tour.GetKeyPointsRequest
+	(*KeyPointsResponse)(nil),        // 26: tour.KeyPointsResponse
+	(*UpdateKeyPointRequest)(nil),    // 27: tour.UpdateKeyPointRequest
+	(*DeleteKeyPointRequest)(nil),    // 28: tour.DeleteKeyPointRequest
+	(*ReorderKeyPointsRequest)(nil),  // 29: tour.ReorderKeyPointsRequest
+	(*ImportKeyPointsRequest)(nil),   // 30: tour.ImportKeyPointsRequest
+	(*ImportKeyPointsResponse)(nil),  // 31: tour.ImportKeyPointsResponse
+	(*ImportedFeature)(nil),          // 32: tour.ImportedFeature
+	(*ExportTourRequest)(nil),        // 33: tour.ExportTourRequest
+	(*ExportTourResponse)(nil),       // 34: tour.ExportTourResponse
+	(*DeleteKeyPointResponse)(nil),   // 35: tour.DeleteKeyPointResponse
+	(*UpdatePositionRequest)(nil),    // 36: tour.UpdatePositionRequest
+	(*GetPositionRequest)(nil),       // 37: tour.GetPositionRequest
+	(*PositionResponse)(nil),         // 38: tour.PositionResponse
+	(*Position)(nil),                 // 39: tour.Position
+	(*AddToCartRequest)(nil),         // 40: tour.AddToCartRequest
+	(*RemoveFromCartRequest)(nil),    // 41: tour.RemoveFromCartRequest
+	(*GetCartRequest)(nil),           // 42: tour.GetCartRequest
+	(*CartResponse)(nil),             // 43: tour.CartResponse
+	(*ShoppingCart)(nil),             // 44: tour.ShoppingCart
+	(*CartItem)(nil),                 // 45: tour.CartItem
+	(*CheckoutRequest)(nil),          // 46: tour.CheckoutRequest
+	(*CheckoutResponse)(nil),         // 47: tour.CheckoutResponse
+	(*PurchaseToken)(nil),            // 48: tour.PurchaseToken
+	(*StartExecutionRequest)(nil),    // 49: tour.StartExecutionRequest
+	(*ExecutionResponse)(nil),        // 50: tour.ExecutionResponse
+	(*CompletionSummary)(nil),        // 51: tour.CompletionSummary
+	(*TourExecution)(nil),            // 52: tour.TourExecution
+	(*CompletedKeyPoint)(nil),        // 53: tour.CompletedKeyPoint
+	(*CheckProximityRequest)(nil),    // 54: tour.CheckProximityRequest
+	(*ProximityResponse)(nil),        // 55: tour.ProximityResponse
+	(*TrackExecutionRequest)(nil),    // 56: tour.TrackExecutionRequest
+	(*TrackExecutionEvent)(nil),      // 57: tour.TrackExecutionEvent
+	(*KeyPointReachedEvent)(nil),     // 58: tour.KeyPointReachedEvent
+	(*TourCompletedEvent)(nil),       // 59: tour.TourCompletedEvent
+	(*ExecutionExpiredEvent)(nil),    // 60: tour.ExecutionExpiredEvent
+	(*CompleteExecutionRequest)(nil), // 61: tour.CompleteExecutionRequest
+	(*AbandonExecutionRequest)(nil),  // 62: tour.AbandonExecutionRequest
+	(*GetExecutionRequest)(nil),      // 63: tour.GetExecutionRequest
+	(*ListExecutionsRequest)(nil),    // 64: tour.ListExecutionsRequest
+	(*ListExecutionsResponse)(nil),   // 65: tour.ListExecutionsResponse
+	(*ExecutionListEntry)(nil),       // 66: tour.ExecutionListEntry
+	(*GetExecutionTrackRequest)(nil), // 67: tour.GetExecutionTrackRequest
+	(*ExecutionTrackResponse)(nil),   // 68: tour.ExecutionTrackResponse
+	(*TrackPoint)(nil),               // 69: tour.TrackPoint
+	(*CreateReviewRequest)(nil),      // 70: tour.CreateReviewRequest
+	(*UpdateReviewRequest)(nil),      // 71: tour.UpdateReviewRequest
+	(*GetReviewsRequest)(nil),        // 72: tour.GetReviewsRequest
+	(*ReviewResponse)(nil),           // 73: tour.ReviewResponse
+	(*ReviewsResponse)(nil),          // 74: tour.ReviewsResponse
+	(*Review)(nil),                   // 75: tour.Review
+	(*fieldmaskpb.FieldMask)(nil),    // 76: google.protobuf.FieldMask
 }
 var file_tour_proto_depIdxs = []int32{
-	2,  // 0: tour.TourResponse.tour:type_name -> tour.Tour
-	2,  // 1: tour.ToursResponse.tours:type_name -> tour.Tour
-	10, // 2: tour.KeyPointResponse.keyPoint:type_name -> tour.KeyPoint
-	10, // 3: tour.KeyPointsResponse.keyPoints:type_name -> tour.KeyPoint
-	19, // 4: tour.PositionResponse.position:type_name -> tour.Position
-	24, // 5: tour.CartResponse.cart:type_name -> tour.ShoppingCart
-	25, // 6: tour.ShoppingCart.items:type_name -> tour.CartItem
-	28, // 7: tour.CheckoutResponse.tokens:type_name -> tour.PurchaseToken
-	31, // 8: tour.ExecutionResponse.execution:type_name -> tour.TourExecution
-	19, // 9: tour.TourExecution.startPosition:type_name -> tour.Position
-	32, // 10: tour.TourExecution.completedKeypoints:type_name -> tour.CompletedKeyPoint
-	10, // 11: tour.ProximityResponse.nearbyKeyPoint:type_name -> tour.KeyPoint
-	0,  // 12: tour.TourService.CreateTour:input_type -> tour.CreateTourRequest
-	3,  // 13: tour.TourService.GetTours:input_type -> tour.GetToursRequest
-	5,  // 14: tour.TourService.GetTourById:input_type -> tour.GetTourByIdRequest
-	7,  // 15: tour.TourService.PublishTour:input_type -> tour.PublishTourRequest
-	4,  // 16: tour.TourService.GetMyTours:input_type -> tour.GetMyToursRequest
-	8,  // 17: tour.TourService.AddKeyPoint:input_type -> tour.AddKeyPointRequest
-	11, // 18: tour.TourService.GetKeyPoints:input_type -> tour.GetKeyPointsRequest
-	13, // 19: tour.TourService.UpdateKeyPoint:input_type -> tour.UpdateKeyPointRequest
-	14, // 20: tour.TourService.DeleteKeyPoint:input_type -> tour.DeleteKeyPointRequest
-	16, // 21: tour.TourService.UpdatePosition:input_type -> tour.UpdatePositionRequest
-	17, // 22: tour.TourService.GetCurrentPosition:input_type -> tour.GetPositionRequest
-	20, // 23: tour.TourService.AddToCart:input_type -> tour.AddToCartRequest
-	21, // 24: tour.TourService.RemoveFromCart:input_type -> tour.RemoveFromCartRequest
-	22, // 25: tour.TourService.GetCart:input_type -> tour.GetCartRequest
-	26, // 26: tour.TourService.Checkout:input_type -> tour.CheckoutRequest
-	29, // 27: tour.TourService.StartTourExecution:input_type -> tour.StartExecutionRequest
-	33, // 28: tour.TourService.CheckProximity:input_type -> tour.CheckProximityRequest
-	35, // 29: tour.TourService.CompleteTour:input_type -> tour.CompleteExecutionRequest
-	36, // 30: tour.TourService.AbandonTour:input_type -> tour.AbandonExecutionRequest
-	37, // 31: tour.TourService.GetExecution:input_type -> tour.GetExecutionRequest
-	1,  // 32: tour.TourService.CreateTour:output_type -> tour.TourResponse
-	6,  // 33: tour.TourService.GetTours:output_type -> tour.ToursResponse
-	1,  // 34: tour.TourService.GetTourById:output_type -> tour.TourResponse
-	1,  // 35: tour.TourService.PublishTour:output_type -> tour.TourResponse
-	6,  // 36: tour.TourService.GetMyTours:output_type -> tour.ToursResponse
-	9,  // 37: tour.TourService.AddKeyPoint:output_type -> tour.KeyPointResponse
-	12, // 38: tour.TourService.GetKeyPoints:output_type -> tour.KeyPointsResponse
-	9,  // 39: tour.TourService.UpdateKeyPoint:output_type -> tour.KeyPointResponse
-	15, // 40: tour.TourService.DeleteKeyPoint:output_type -> tour.DeleteKeyPointResponse
-	18, // 41: tour.TourService.UpdatePosition:output_type -> tour.PositionResponse
-	18, // 42: tour.TourService.GetCurrentPosition:output_type -> tour.PositionResponse
-	23, // 43: tour.TourService.AddToCart:output_type -> tour.CartResponse
-	23, // 44: tour.TourService.RemoveFromCart:output_type -> tour.CartResponse
-	23, // 45: tour.TourService.GetCart:output_type -> tour.CartResponse
-	27, // 46: tour.TourService.Checkout:output_type -> tour.CheckoutResponse
-	30, // 47: tour.TourService.StartTourExecution:output_type -> tour.ExecutionResponse
-	34, // 48: tour.TourService.CheckProximity:output_type -> tour.ProximityResponse
-	30, // 49: tour.TourService.CompleteTour:output_type -> tour.ExecutionResponse
-	30, // 50: tour.TourService.AbandonTour:output_type -> tour.ExecutionResponse
-	30, // 51: tour.TourService.GetExecution:output_type -> tour.ExecutionResponse
-	32, // [32:52] is the sub-list for method output_type
-	12, // [12:32] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	4,  // 0: tour.TourResponse.tour:type_name -> tour.Tour
+	3,  // 1: tour.TourResponse.violations:type_name -> tour.Violation
+	5,  // 2: tour.Tour.transportTimes:type_name -> tour.TransportTime
+	0,  // 3: tour.GetToursRequest.sort:type_name -> tour.TourSort
+	4,  // 4: tour.ToursResponse.tours:type_name -> tour.Tour
+	76, // 5: tour.UpdateTourRequest.updateMask:type_name -> google.protobuf.FieldMask
+	5,  // 6: tour.UpdateTourRequest.transportTimes:type_name -> tour.TransportTime
+	16, // 7: tour.NearbyToursResponse.tours:type_name -> tour.NearbyTour
+	4,  // 8: tour.NearbyTour.tour:type_name -> tour.Tour
+	19, // 9: tour.SearchToursResponse.results:type_name -> tour.TourSearchResult
+	4,  // 10: tour.TourSearchResult.tour:type_name -> tour.Tour
+	20, // 11: tour.TourSearchResult.snippets:type_name -> tour.Snippet
+	21, // 12: tour.Snippet.highlights:type_name -> tour.Highlight
+	24, // 13: tour.KeyPointResponse.keyPoint:type_name -> tour.KeyPoint
+	24, // 14: tour.KeyPointsResponse.keyPoints:type_name -> tour.KeyPoint
+	32, // 15: tour.ImportKeyPointsResponse.features:type_name -> tour.ImportedFeature
+	24, // 16: tour.ImportedFeature.keyPoint:type_name -> tour.KeyPoint
+	39, // 17: tour.PositionResponse.position:type_name -> tour.Position
+	44, // 18: tour.CartResponse.cart:type_name -> tour.ShoppingCart
+	45, // 19: tour.ShoppingCart.items:type_name -> tour.CartItem
+	48, // 20: tour.CheckoutResponse.tokens:type_name -> tour.PurchaseToken
+	52, // 21: tour.ExecutionResponse.execution:type_name -> tour.TourExecution
+	51, // 22: tour.ExecutionResponse.summary:type_name -> tour.CompletionSummary
+	39, // 23: tour.TourExecution.startPosition:type_name -> tour.Position
+	53, // 24: tour.TourExecution.completedKeypoints:type_name -> tour.CompletedKeyPoint
+	24, // 25: tour.ProximityResponse.nearbyKeyPoint:type_name -> tour.KeyPoint
+	24, // 26: tour.ProximityResponse.nextKeyPoint:type_name -> tour.KeyPoint
+	51, // 27: tour.ProximityResponse.summary:type_name -> tour.CompletionSummary
+	58, // 28: tour.TrackExecutionEvent.keyPointReached:type_name -> tour.KeyPointReachedEvent
+	59, // 29: tour.TrackExecutionEvent.tourCompleted:type_name -> tour.TourCompletedEvent
+	60, // 30: tour.TrackExecutionEvent.executionExpired:type_name -> tour.ExecutionExpiredEvent
+	24, // 31: tour.KeyPointReachedEvent.keyPoint:type_name -> tour.KeyPoint
+	24, // 32: tour.KeyPointReachedEvent.nextKeyPoint:type_name -> tour.KeyPoint
+	52, // 33: tour.TourCompletedEvent.execution:type_name -> tour.TourExecution
+	51, // 34: tour.TourCompletedEvent.summary:type_name -> tour.CompletionSummary
+	52, // 35: tour.ExecutionExpiredEvent.execution:type_name -> tour.TourExecution
+	66, // 36: tour.ListExecutionsResponse.executions:type_name -> tour.ExecutionListEntry
+	52, // 37: tour.ExecutionListEntry.execution:type_name -> tour.TourExecution
+	69, // 38: tour.ExecutionTrackResponse.points:type_name -> tour.TrackPoint
+	75, // 39: tour.ReviewResponse.review:type_name -> tour.Review
+	75, // 40: tour.ReviewsResponse.reviews:type_name -> tour.Review
+	1,  // 41: tour.TourService.CreateTour:input_type -> tour.CreateTourRequest
+	6,  // 42: tour.TourService.GetTours:input_type -> tour.GetToursRequest
+	8,  // 43: tour.TourService.GetTourById:input_type -> tour.GetTourByIdRequest
+	10, // 44: tour.TourService.PublishTour:input_type -> tour.PublishTourRequest
+	7,  // 45: tour.TourService.GetMyTours:input_type -> tour.GetMyToursRequest
+	11, // 46: tour.TourService.UpdateTour:input_type -> tour.UpdateTourRequest
+	12, // 47: tour.TourService.ArchiveTour:input_type -> tour.ArchiveTourRequest
+	13, // 48: tour.TourService.ReactivateTour:input_type -> tour.ReactivateTourRequest
+	14, // 49: tour.TourService.SearchToursNearby:input_type -> tour.SearchToursNearbyRequest
+	17, // 50: tour.TourService.SearchTours:input_type -> tour.SearchToursRequest
+	22, // 51: tour.TourService.AddKeyPoint:input_type -> tour.AddKeyPointRequest
+	25, // 52: tour.TourService.GetKeyPoints:input_type -> tour.GetKeyPointsRequest
+	27, // 53: tour.TourService.UpdateKeyPoint:input_type -> tour.UpdateKeyPointRequest
+	28, // 54: tour.TourService.DeleteKeyPoint:input_type -> tour.DeleteKeyPointRequest
+	29, // 55: tour.TourService.ReorderKeyPoints:input_type -> tour.ReorderKeyPointsRequest
+	30, // 56: tour.TourService.ImportKeyPoints:input_type -> tour.ImportKeyPointsRequest
+	33, // 57: tour.TourService.ExportTour:input_type -> tour.ExportTourRequest
+	36, // 58: tour.TourService.UpdatePosition:input_type -> tour.UpdatePositionRequest
+	37, // 59: tour.TourService.GetCurrentPosition:input_type -> tour.GetPositionRequest
+	40, // 60: tour.TourService.AddToCart:input_type -> tour.AddToCartRequest
+	41, // 61: tour.TourService.RemoveFromCart:input_type -> tour.RemoveFromCartRequest
+	42, // 62: tour.TourService.GetCart:input_type -> tour.GetCartRequest
+	46, // 63: tour.TourService.Checkout:input_type -> tour.CheckoutRequest
+	49, // 64: tour.TourService.StartTourExecution:input_type -> tour.StartExecutionRequest
+	54, // 65: tour.TourService.CheckProximity:input_type -> tour.CheckProximityRequest
+	61, // 66: tour.TourService.CompleteTour:input_type -> tour.CompleteExecutionRequest
+	62, // 67: tour.TourService.AbandonTour:input_type -> tour.AbandonExecutionRequest
+	63, // 68: tour.TourService.GetExecution:input_type -> tour.GetExecutionRequest
+	56, // 69: tour.TourService.TrackExecution:input_type -> tour.TrackExecutionRequest
+	67, // 70: tour.TourService.GetExecutionTrack:input_type -> tour.GetExecutionTrackRequest
+	64, // 71: tour.TourService.ListExecutions:input_type -> tour.ListExecutionsRequest
+	70, // 72: tour.TourService.CreateReview:input_type -> tour.CreateReviewRequest
+	72, // 73: tour.TourService.GetReviews:input_type -> tour.GetReviewsRequest
+	71, // 74: tour.TourService.UpdateReview:input_type -> tour.UpdateReviewRequest
+	2,  // 75: tour.TourService.CreateTour:output_type -> tour.TourResponse
+	9,  // 76: tour.TourService.GetTours:output_type -> tour.ToursResponse
+	2,  // 77: tour.TourService.GetTourById:output_type -> tour.TourResponse
+	2,  // 78: tour.TourService.PublishTour:output_type -> tour.TourResponse
+	9,  // 79: tour.TourService.GetMyTours:output_type -> tour.ToursResponse
+	2,  // 80: tour.TourService.UpdateTour:output_type -> tour.TourResponse
+	2,  // 81: tour.TourService.ArchiveTour:output_type -> tour.TourResponse
+	2,  // 82: tour.TourService.ReactivateTour:output_type -> tour.TourResponse
+	15, // 83: tour.TourService.SearchToursNearby:output_type -> tour.NearbyToursResponse
+	18, // 84: tour.TourService.SearchTours:output_type -> tour.SearchToursResponse
+	23, // 85: tour.TourService.AddKeyPoint:output_type -> tour.KeyPointResponse
+	26, // 86: tour.TourService.GetKeyPoints:output_type -> tour.KeyPointsResponse
+	23, // 87: tour.TourService.UpdateKeyPoint:output_type -> tour.KeyPointResponse
+	35, // 88: tour.TourService.DeleteKeyPoint:output_type -> tour.DeleteKeyPointResponse
+	26, // 89: tour.TourService.ReorderKeyPoints:output_type -> tour.KeyPointsResponse
+	31, // 90: tour.TourService.ImportKeyPoints:output_type -> tour.ImportKeyPointsResponse
+	34, // 91: tour.TourService.ExportTour:output_type -> tour.ExportTourResponse
+	38, // 92: tour.TourService.UpdatePosition:output_type -> tour.PositionResponse
+	38, // 93: tour.TourService.GetCurrentPosition:output_type -> tour.PositionResponse
+	43, // 94: tour.TourService.AddToCart:output_type -> tour.CartResponse
+	43, // 95: tour.TourService.RemoveFromCart:output_type -> tour.CartResponse
+	43, // 96: tour.TourService.GetCart:output_type -> tour.CartResponse
+	47, // 97: tour.TourService.Checkout:output_type -> tour.CheckoutResponse
+	50, // 98: tour.TourService.StartTourExecution:output_type -> tour.ExecutionResponse
+	55, // 99: tour.TourService.CheckProximity:output_type -> tour.ProximityResponse
+	50, // 100: tour.TourService.CompleteTour:output_type -> tour.ExecutionResponse
+	50, // 101: tour.TourService.AbandonTour:output_type -> tour.ExecutionResponse
+	50, // 102: tour.TourService.GetExecution:output_type -> tour.ExecutionResponse
+	57, // 103: tour.TourService.TrackExecution:output_type -> tour.TrackExecutionEvent
+	68, // 104: tour.TourService.GetExecutionTrack:output_type -> tour.ExecutionTrackResponse
+	65, // 105: tour.TourService.ListExecutions:output_type -> tour.ListExecutionsResponse
+	73, // 106: tour.TourService.CreateReview:output_type -> tour.ReviewResponse
+	74, // 107: tour.TourService.GetReviews:output_type -> tour.ReviewsResponse
+	73, // 108: tour.TourService.UpdateReview:output_type -> tour.ReviewResponse
+	75, // [75:109] is the sub-list for method output_type
+	41, // [41:75] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
 }
 
 func init() { file_tour_proto_init() }
@@ -2793,18 +6097,25 @@ func file_tour_proto_init() {
 	if File_tour_proto != nil {
 		return
 	}
+	file_tour_proto_msgTypes[5].OneofWrappers = []any{}
+	file_tour_proto_msgTypes[56].OneofWrappers = []any{
+		(*TrackExecutionEvent_KeyPointReached)(nil),
+		(*TrackExecutionEvent_TourCompleted)(nil),
+		(*TrackExecutionEvent_ExecutionExpired)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tour_proto_rawDesc), len(file_tour_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   38,
+			NumEnums:      1,
+			NumMessages:   75,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_tour_proto_goTypes,
 		DependencyIndexes: file_tour_proto_depIdxs,
+		EnumInfos:         file_tour_proto_enumTypes,
 		MessageInfos:      file_tour_proto_msgTypes,
 	}.Build()
 	File_tour_proto = out.File
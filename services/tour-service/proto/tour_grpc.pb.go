@@ -24,10 +24,18 @@ const (
 	TourService_GetTourById_FullMethodName        = "/tour.TourService/GetTourById"
 	TourService_PublishTour_FullMethodName        = "/tour.TourService/PublishTour"
 	TourService_GetMyTours_FullMethodName         = "/tour.TourService/GetMyTours"
+	TourService_UpdateTour_FullMethodName         = "/tour.TourService/UpdateTour"
+	TourService_ArchiveTour_FullMethodName        = "/tour.TourService/ArchiveTour"
+	TourService_ReactivateTour_FullMethodName     = "/tour.TourService/ReactivateTour"
+	TourService_SearchToursNearby_FullMethodName  = "/tour.TourService/SearchToursNearby"
+	TourService_SearchTours_FullMethodName        = "/tour.TourService/SearchTours"
 	TourService_AddKeyPoint_FullMethodName        = "/tour.TourService/AddKeyPoint"
 	TourService_GetKeyPoints_FullMethodName       = "/tour.TourService/GetKeyPoints"
 	TourService_UpdateKeyPoint_FullMethodName     = "/tour.TourService/UpdateKeyPoint"
 	TourService_DeleteKeyPoint_FullMethodName     = "/tour.TourService/DeleteKeyPoint"
+	TourService_ReorderKeyPoints_FullMethodName   = "/tour.TourService/ReorderKeyPoints"
+	TourService_ImportKeyPoints_FullMethodName    = "/tour.TourService/ImportKeyPoints"
+	TourService_ExportTour_FullMethodName         = "/tour.TourService/ExportTour"
 	TourService_UpdatePosition_FullMethodName     = "/tour.TourService/UpdatePosition"
 	TourService_GetCurrentPosition_FullMethodName = "/tour.TourService/GetCurrentPosition"
 	TourService_AddToCart_FullMethodName          = "/tour.TourService/AddToCart"
@@ -39,6 +47,12 @@ const (
 	TourService_CompleteTour_FullMethodName       = "/tour.TourService/CompleteTour"
 	TourService_AbandonTour_FullMethodName        = "/tour.TourService/AbandonTour"
 	TourService_GetExecution_FullMethodName       = "/tour.TourService/GetExecution"
+	TourService_TrackExecution_FullMethodName     = "/tour.TourService/TrackExecution"
+	TourService_GetExecutionTrack_FullMethodName  = "/tour.TourService/GetExecutionTrack"
+	TourService_ListExecutions_FullMethodName     = "/tour.TourService/ListExecutions"
+	TourService_CreateReview_FullMethodName       = "/tour.TourService/CreateReview"
+	TourService_GetReviews_FullMethodName         = "/tour.TourService/GetReviews"
+	TourService_UpdateReview_FullMethodName       = "/tour.TourService/UpdateReview"
 )
 
 // TourServiceClient is the client API for TourService service.
@@ -53,11 +67,19 @@ type TourServiceClient interface {
 	GetTourById(ctx context.Context, in *GetTourByIdRequest, opts ...grpc.CallOption) (*TourResponse, error)
 	PublishTour(ctx context.Context, in *PublishTourRequest, opts ...grpc.CallOption) (*TourResponse, error)
 	GetMyTours(ctx context.Context, in *GetMyToursRequest, opts ...grpc.CallOption) (*ToursResponse, error)
+	UpdateTour(ctx context.Context, in *UpdateTourRequest, opts ...grpc.CallOption) (*TourResponse, error)
+	ArchiveTour(ctx context.Context, in *ArchiveTourRequest, opts ...grpc.CallOption) (*TourResponse, error)
+	ReactivateTour(ctx context.Context, in *ReactivateTourRequest, opts ...grpc.CallOption) (*TourResponse, error)
+	SearchToursNearby(ctx context.Context, in *SearchToursNearbyRequest, opts ...grpc.CallOption) (*NearbyToursResponse, error)
+	SearchTours(ctx context.Context, in *SearchToursRequest, opts ...grpc.CallOption) (*SearchToursResponse, error)
 	// KeyPoint Management
 	AddKeyPoint(ctx context.Context, in *AddKeyPointRequest, opts ...grpc.CallOption) (*KeyPointResponse, error)
 	GetKeyPoints(ctx context.Context, in *GetKeyPointsRequest, opts ...grpc.CallOption) (*KeyPointsResponse, error)
 	UpdateKeyPoint(ctx context.Context, in *UpdateKeyPointRequest, opts ...grpc.CallOption) (*KeyPointResponse, error)
 	DeleteKeyPoint(ctx context.Context, in *DeleteKeyPointRequest, opts ...grpc.CallOption) (*DeleteKeyPointResponse, error)
+	ReorderKeyPoints(ctx context.Context, in *ReorderKeyPointsRequest, opts ...grpc.CallOption) (*KeyPointsResponse, error)
+	ImportKeyPoints(ctx context.Context, in *ImportKeyPointsRequest, opts ...grpc.CallOption) (*ImportKeyPointsResponse, error)
+	ExportTour(ctx context.Context, in *ExportTourRequest, opts ...grpc.CallOption) (*ExportTourResponse, error)
 	// Position Simulator
 	UpdatePosition(ctx context.Context, in *UpdatePositionRequest, opts ...grpc.CallOption) (*PositionResponse, error)
 	GetCurrentPosition(ctx context.Context, in *GetPositionRequest, opts ...grpc.CallOption) (*PositionResponse, error)
@@ -72,6 +94,13 @@ type TourServiceClient interface {
 	CompleteTour(ctx context.Context, in *CompleteExecutionRequest, opts ...grpc.CallOption) (*ExecutionResponse, error)
 	AbandonTour(ctx context.Context, in *AbandonExecutionRequest, opts ...grpc.CallOption) (*ExecutionResponse, error)
 	GetExecution(ctx context.Context, in *GetExecutionRequest, opts ...grpc.CallOption) (*ExecutionResponse, error)
+	TrackExecution(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[TrackExecutionRequest, TrackExecutionEvent], error)
+	GetExecutionTrack(ctx context.Context, in *GetExecutionTrackRequest, opts ...grpc.CallOption) (*ExecutionTrackResponse, error)
+	ListExecutions(ctx context.Context, in *ListExecutionsRequest, opts ...grpc.CallOption) (*ListExecutionsResponse, error)
+	// Reviews
+	CreateReview(ctx context.Context, in *CreateReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error)
+	GetReviews(ctx context.Context, in *GetReviewsRequest, opts ...grpc.CallOption) (*ReviewsResponse, error)
+	UpdateReview(ctx context.Context, in *UpdateReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error)
 }
 
 type tourServiceClient struct {
@@ -132,6 +161,56 @@ func (c *tourServiceClient) GetMyTours(ctx context.Context, in *GetMyToursReques
 	return out, nil
 }
 
+func (c *tourServiceClient) UpdateTour(ctx context.Context, in *UpdateTourRequest, opts ...grpc.CallOption) (*TourResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TourResponse)
+	err := c.cc.Invoke(ctx, TourService_UpdateTour_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tourServiceClient) ArchiveTour(ctx context.Context, in *ArchiveTourRequest, opts ...grpc.CallOption) (*TourResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TourResponse)
+	err := c.cc.Invoke(ctx, TourService_ArchiveTour_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tourServiceClient) ReactivateTour(ctx context.Context, in *ReactivateTourRequest, opts ...grpc.CallOption) (*TourResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TourResponse)
+	err := c.cc.Invoke(ctx, TourService_ReactivateTour_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tourServiceClient) SearchToursNearby(ctx context.Context, in *SearchToursNearbyRequest, opts ...grpc.CallOption) (*NearbyToursResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NearbyToursResponse)
+	err := c.cc.Invoke(ctx, TourService_SearchToursNearby_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tourServiceClient) SearchTours(ctx context.Context, in *SearchToursRequest, opts ...grpc.CallOption) (*SearchToursResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchToursResponse)
+	err := c.cc.Invoke(ctx, TourService_SearchTours_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tourServiceClient) AddKeyPoint(ctx context.Context, in *AddKeyPointRequest, opts ...grpc.CallOption) (*KeyPointResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(KeyPointResponse)
@@ -172,6 +251,36 @@ func (c *tourServiceClient) DeleteKeyPoint(ctx context.Context, in *DeleteKeyPoi
 	return out, nil
 }
 
+func (c *tourServiceClient) ReorderKeyPoints(ctx context.Context, in *ReorderKeyPointsRequest, opts ...grpc.CallOption) (*KeyPointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeyPointsResponse)
+	err := c.cc.Invoke(ctx, TourService_ReorderKeyPoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tourServiceClient) ImportKeyPoints(ctx context.Context, in *ImportKeyPointsRequest, opts ...grpc.CallOption) (*ImportKeyPointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportKeyPointsResponse)
+	err := c.cc.Invoke(ctx, TourService_ImportKeyPoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tourServiceClient) ExportTour(ctx context.Context, in *ExportTourRequest, opts ...grpc.CallOption) (*ExportTourResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportTourResponse)
+	err := c.cc.Invoke(ctx, TourService_ExportTour_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tourServiceClient) UpdatePosition(ctx context.Context, in *UpdatePositionRequest, opts ...grpc.CallOption) (*PositionResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PositionResponse)
@@ -282,6 +391,69 @@ func (c *tourServiceClient) GetExecution(ctx context.Context, in *GetExecutionRe
 	return out, nil
 }
 
+func (c *tourServiceClient) TrackExecution(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[TrackExecutionRequest, TrackExecutionEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TourService_ServiceDesc.Streams[0], TourService_TrackExecution_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TrackExecutionRequest, TrackExecutionEvent]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TourService_TrackExecutionClient = grpc.BidiStreamingClient[TrackExecutionRequest, TrackExecutionEvent]
+
+func (c *tourServiceClient) GetExecutionTrack(ctx context.Context, in *GetExecutionTrackRequest, opts ...grpc.CallOption) (*ExecutionTrackResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExecutionTrackResponse)
+	err := c.cc.Invoke(ctx, TourService_GetExecutionTrack_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tourServiceClient) ListExecutions(ctx context.Context, in *ListExecutionsRequest, opts ...grpc.CallOption) (*ListExecutionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExecutionsResponse)
+	err := c.cc.Invoke(ctx, TourService_ListExecutions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tourServiceClient) CreateReview(ctx context.Context, in *CreateReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewResponse)
+	err := c.cc.Invoke(ctx, TourService_CreateReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tourServiceClient) GetReviews(ctx context.Context, in *GetReviewsRequest, opts ...grpc.CallOption) (*ReviewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewsResponse)
+	err := c.cc.Invoke(ctx, TourService_GetReviews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tourServiceClient) UpdateReview(ctx context.Context, in *UpdateReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewResponse)
+	err := c.cc.Invoke(ctx, TourService_UpdateReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TourServiceServer is the server API for TourService service.
 // All implementations must embed UnimplementedTourServiceServer
 // for forward compatibility.
@@ -294,11 +466,19 @@ type TourServiceServer interface {
 	GetTourById(context.Context, *GetTourByIdRequest) (*TourResponse, error)
 	PublishTour(context.Context, *PublishTourRequest) (*TourResponse, error)
 	GetMyTours(context.Context, *GetMyToursRequest) (*ToursResponse, error)
+	UpdateTour(context.Context, *UpdateTourRequest) (*TourResponse, error)
+	ArchiveTour(context.Context, *ArchiveTourRequest) (*TourResponse, error)
+	ReactivateTour(context.Context, *ReactivateTourRequest) (*TourResponse, error)
+	SearchToursNearby(context.Context, *SearchToursNearbyRequest) (*NearbyToursResponse, error)
+	SearchTours(context.Context, *SearchToursRequest) (*SearchToursResponse, error)
 	// KeyPoint Management
 	AddKeyPoint(context.Context, *AddKeyPointRequest) (*KeyPointResponse, error)
 	GetKeyPoints(context.Context, *GetKeyPointsRequest) (*KeyPointsResponse, error)
 	UpdateKeyPoint(context.Context, *UpdateKeyPointRequest) (*KeyPointResponse, error)
 	DeleteKeyPoint(context.Context, *DeleteKeyPointRequest) (*DeleteKeyPointResponse, error)
+	ReorderKeyPoints(context.Context, *ReorderKeyPointsRequest) (*KeyPointsResponse, error)
+	ImportKeyPoints(context.Context, *ImportKeyPointsRequest) (*ImportKeyPointsResponse, error)
+	ExportTour(context.Context, *ExportTourRequest) (*ExportTourResponse, error)
 	// Position Simulator
 	UpdatePosition(context.Context, *UpdatePositionRequest) (*PositionResponse, error)
 	GetCurrentPosition(context.Context, *GetPositionRequest) (*PositionResponse, error)
@@ -313,6 +493,13 @@ type TourServiceServer interface {
 	CompleteTour(context.Context, *CompleteExecutionRequest) (*ExecutionResponse, error)
 	AbandonTour(context.Context, *AbandonExecutionRequest) (*ExecutionResponse, error)
 	GetExecution(context.Context, *GetExecutionRequest) (*ExecutionResponse, error)
+	TrackExecution(grpc.BidiStreamingServer[TrackExecutionRequest, TrackExecutionEvent]) error
+	GetExecutionTrack(context.Context, *GetExecutionTrackRequest) (*ExecutionTrackResponse, error)
+	ListExecutions(context.Context, *ListExecutionsRequest) (*ListExecutionsResponse, error)
+	// Reviews
+	CreateReview(context.Context, *CreateReviewRequest) (*ReviewResponse, error)
+	GetReviews(context.Context, *GetReviewsRequest) (*ReviewsResponse, error)
+	UpdateReview(context.Context, *UpdateReviewRequest) (*ReviewResponse, error)
 	mustEmbedUnimplementedTourServiceServer()
 }
 
@@ -338,6 +525,21 @@ func (UnimplementedTourServiceServer) PublishTour(context.Context, *PublishTourR
 func (UnimplementedTourServiceServer) GetMyTours(context.Context, *GetMyToursRequest) (*ToursResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetMyTours not implemented")
 }
+func (UnimplementedTourServiceServer) UpdateTour(context.Context, *UpdateTourRequest) (*TourResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateTour not implemented")
+}
+func (UnimplementedTourServiceServer) ArchiveTour(context.Context, *ArchiveTourRequest) (*TourResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ArchiveTour not implemented")
+}
+func (UnimplementedTourServiceServer) ReactivateTour(context.Context, *ReactivateTourRequest) (*TourResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReactivateTour not implemented")
+}
+func (UnimplementedTourServiceServer) SearchToursNearby(context.Context, *SearchToursNearbyRequest) (*NearbyToursResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchToursNearby not implemented")
+}
+func (UnimplementedTourServiceServer) SearchTours(context.Context, *SearchToursRequest) (*SearchToursResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchTours not implemented")
+}
 func (UnimplementedTourServiceServer) AddKeyPoint(context.Context, *AddKeyPointRequest) (*KeyPointResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method AddKeyPoint not implemented")
 }
@@ -350,6 +552,15 @@ func (UnimplementedTourServiceServer) UpdateKeyPoint(context.Context, *UpdateKey
 func (UnimplementedTourServiceServer) DeleteKeyPoint(context.Context, *DeleteKeyPointRequest) (*DeleteKeyPointResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteKeyPoint not implemented")
 }
+func (UnimplementedTourServiceServer) ReorderKeyPoints(context.Context, *ReorderKeyPointsRequest) (*KeyPointsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReorderKeyPoints not implemented")
+}
+func (UnimplementedTourServiceServer) ImportKeyPoints(context.Context, *ImportKeyPointsRequest) (*ImportKeyPointsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportKeyPoints not implemented")
+}
+func (UnimplementedTourServiceServer) ExportTour(context.Context, *ExportTourRequest) (*ExportTourResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportTour not implemented")
+}
 func (UnimplementedTourServiceServer) UpdatePosition(context.Context, *UpdatePositionRequest) (*PositionResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdatePosition not implemented")
 }
@@ -383,6 +594,24 @@ func (UnimplementedTourServiceServer) AbandonTour(context.Context, *AbandonExecu
 func (UnimplementedTourServiceServer) GetExecution(context.Context, *GetExecutionRequest) (*ExecutionResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetExecution not implemented")
 }
+func (UnimplementedTourServiceServer) TrackExecution(grpc.BidiStreamingServer[TrackExecutionRequest, TrackExecutionEvent]) error {
+	return status.Error(codes.Unimplemented, "method TrackExecution not implemented")
+}
+func (UnimplementedTourServiceServer) GetExecutionTrack(context.Context, *GetExecutionTrackRequest) (*ExecutionTrackResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetExecutionTrack not implemented")
+}
+func (UnimplementedTourServiceServer) ListExecutions(context.Context, *ListExecutionsRequest) (*ListExecutionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListExecutions not implemented")
+}
+func (UnimplementedTourServiceServer) CreateReview(context.Context, *CreateReviewRequest) (*ReviewResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateReview not implemented")
+}
+func (UnimplementedTourServiceServer) GetReviews(context.Context, *GetReviewsRequest) (*ReviewsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReviews not implemented")
+}
+func (UnimplementedTourServiceServer) UpdateReview(context.Context, *UpdateReviewRequest) (*ReviewResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateReview not implemented")
+}
 func (UnimplementedTourServiceServer) mustEmbedUnimplementedTourServiceServer() {}
 func (UnimplementedTourServiceServer) testEmbeddedByValue()                     {}
 
@@ -494,6 +723,96 @@ func _TourService_GetMyTours_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TourService_UpdateTour_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTourRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TourServiceServer).UpdateTour(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TourService_UpdateTour_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TourServiceServer).UpdateTour(ctx, req.(*UpdateTourRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TourService_ArchiveTour_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveTourRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TourServiceServer).ArchiveTour(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TourService_ArchiveTour_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TourServiceServer).ArchiveTour(ctx, req.(*ArchiveTourRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TourService_ReactivateTour_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactivateTourRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TourServiceServer).ReactivateTour(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TourService_ReactivateTour_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TourServiceServer).ReactivateTour(ctx, req.(*ReactivateTourRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TourService_SearchToursNearby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchToursNearbyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TourServiceServer).SearchToursNearby(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TourService_SearchToursNearby_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TourServiceServer).SearchToursNearby(ctx, req.(*SearchToursNearbyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TourService_SearchTours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchToursRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TourServiceServer).SearchTours(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TourService_SearchTours_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TourServiceServer).SearchTours(ctx, req.(*SearchToursRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TourService_AddKeyPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddKeyPointRequest)
 	if err := dec(in); err != nil {
@@ -566,6 +885,60 @@ func _TourService_DeleteKeyPoint_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TourService_ReorderKeyPoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderKeyPointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TourServiceServer).ReorderKeyPoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TourService_ReorderKeyPoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TourServiceServer).ReorderKeyPoints(ctx, req.(*ReorderKeyPointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TourService_ImportKeyPoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportKeyPointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TourServiceServer).ImportKeyPoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TourService_ImportKeyPoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TourServiceServer).ImportKeyPoints(ctx, req.(*ImportKeyPointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TourService_ExportTour_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportTourRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TourServiceServer).ExportTour(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TourService_ExportTour_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TourServiceServer).ExportTour(ctx, req.(*ExportTourRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TourService_UpdatePosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdatePositionRequest)
 	if err := dec(in); err != nil {
@@ -764,6 +1137,103 @@ func _TourService_GetExecution_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TourService_TrackExecution_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TourServiceServer).TrackExecution(&grpc.GenericServerStream[TrackExecutionRequest, TrackExecutionEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TourService_TrackExecutionServer = grpc.BidiStreamingServer[TrackExecutionRequest, TrackExecutionEvent]
+
+func _TourService_GetExecutionTrack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExecutionTrackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TourServiceServer).GetExecutionTrack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TourService_GetExecutionTrack_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TourServiceServer).GetExecutionTrack(ctx, req.(*GetExecutionTrackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TourService_ListExecutions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExecutionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TourServiceServer).ListExecutions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TourService_ListExecutions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TourServiceServer).ListExecutions(ctx, req.(*ListExecutionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TourService_CreateReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TourServiceServer).CreateReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TourService_CreateReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TourServiceServer).CreateReview(ctx, req.(*CreateReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TourService_GetReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TourServiceServer).GetReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TourService_GetReviews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TourServiceServer).GetReviews(ctx, req.(*GetReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TourService_UpdateReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TourServiceServer).UpdateReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TourService_UpdateReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TourServiceServer).UpdateReview(ctx, req.(*UpdateReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TourService_ServiceDesc is the grpc.ServiceDesc for TourService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -791,6 +1261,26 @@ var TourService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMyTours",
 			Handler:    _TourService_GetMyTours_Handler,
 		},
+		{
+			MethodName: "UpdateTour",
+			Handler:    _TourService_UpdateTour_Handler,
+		},
+		{
+			MethodName: "ArchiveTour",
+			Handler:    _TourService_ArchiveTour_Handler,
+		},
+		{
+			MethodName: "ReactivateTour",
+			Handler:    _TourService_ReactivateTour_Handler,
+		},
+		{
+			MethodName: "SearchToursNearby",
+			Handler:    _TourService_SearchToursNearby_Handler,
+		},
+		{
+			MethodName: "SearchTours",
+			Handler:    _TourService_SearchTours_Handler,
+		},
 		{
 			MethodName: "AddKeyPoint",
 			Handler:    _TourService_AddKeyPoint_Handler,
@@ -807,6 +1297,18 @@ var TourService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteKeyPoint",
 			Handler:    _TourService_DeleteKeyPoint_Handler,
 		},
+		{
+			MethodName: "ReorderKeyPoints",
+			Handler:    _TourService_ReorderKeyPoints_Handler,
+		},
+		{
+			MethodName: "ImportKeyPoints",
+			Handler:    _TourService_ImportKeyPoints_Handler,
+		},
+		{
+			MethodName: "ExportTour",
+			Handler:    _TourService_ExportTour_Handler,
+		},
 		{
 			MethodName: "UpdatePosition",
 			Handler:    _TourService_UpdatePosition_Handler,
@@ -851,7 +1353,34 @@ var TourService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetExecution",
 			Handler:    _TourService_GetExecution_Handler,
 		},
+		{
+			MethodName: "GetExecutionTrack",
+			Handler:    _TourService_GetExecutionTrack_Handler,
+		},
+		{
+			MethodName: "ListExecutions",
+			Handler:    _TourService_ListExecutions_Handler,
+		},
+		{
+			MethodName: "CreateReview",
+			Handler:    _TourService_CreateReview_Handler,
+		},
+		{
+			MethodName: "GetReviews",
+			Handler:    _TourService_GetReviews_Handler,
+		},
+		{
+			MethodName: "UpdateReview",
+			Handler:    _TourService_UpdateReview_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TrackExecution",
+			Handler:       _TourService_TrackExecution_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "tour.proto",
 }